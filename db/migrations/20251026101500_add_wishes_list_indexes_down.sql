@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_wishes_title;
+DROP INDEX IF EXISTS idx_wishes_updated;
+DROP INDEX IF EXISTS idx_wishes_created;
+
+DROP INDEX IF EXISTS idx_wishes_priority;
+CREATE INDEX idx_wishes_priority ON wishes(organization_id, order_no DESC);
@@ -0,0 +1,10 @@
+-- 一覧APIのkeyset paginationで使う並び順をインデックスで賄う
+-- 既定の並び順（order_no DESC, created_at DESC, id DESC）は idx_wishes_priority を使う
+DROP INDEX IF EXISTS idx_wishes_priority;
+CREATE INDEX idx_wishes_priority ON wishes(organization_id, order_no DESC, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_wishes_created ON wishes(organization_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_wishes_updated ON wishes(organization_id, updated_at DESC, id DESC);
+
+-- title_prefix（前方一致）用
+CREATE INDEX IF NOT EXISTS idx_wishes_title ON wishes(organization_id, title text_pattern_ops);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wishes_note_trgm;
+DROP INDEX IF EXISTS idx_wishes_title_trgm;
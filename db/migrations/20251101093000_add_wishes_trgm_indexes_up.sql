@@ -0,0 +1,5 @@
+-- 日本語は空白で分かち書きされないため、全文検索はトライグラム（pg_trgm）で部分一致させる
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_wishes_title_trgm ON wishes USING gin (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_wishes_note_trgm  ON wishes USING gin (note gin_trgm_ops);
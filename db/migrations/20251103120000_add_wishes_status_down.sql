@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_wishes_status;
+
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS fulfilled_by,
+  DROP COLUMN IF EXISTS fulfilled_at,
+  DROP COLUMN IF EXISTS status;
@@ -0,0 +1,8 @@
+-- Wishのライフサイクル: idea → planned → in_progress → fulfilled → archived
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS status       text        NOT NULL DEFAULT 'idea'
+    CHECK (status IN ('idea', 'planned', 'in_progress', 'fulfilled', 'archived')),
+  ADD COLUMN IF NOT EXISTS fulfilled_at timestamptz,
+  ADD COLUMN IF NOT EXISTS fulfilled_by uuid        REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_wishes_status ON wishes(organization_id, status);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_wishes_rank;
+
+ALTER TABLE wishes DROP COLUMN IF EXISTS rank;
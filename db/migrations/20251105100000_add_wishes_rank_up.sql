@@ -0,0 +1,23 @@
+-- ドラッグ&ドロップ用の並び順。LexoRank形式の文字列で、2つのWishの間に隣を書き換えずに挿入できる
+-- バイト順で比較するため COLLATE "C" とする
+ALTER TABLE wishes ADD COLUMN IF NOT EXISTS rank text COLLATE "C";
+
+-- 既存の並び（order_no DESC, created_at DESC）を保ったまま等間隔のrankを振る
+-- 末尾が'0'にならないよう +1 する（rankは末尾'0'を許さない）
+-- 再実行でドラッグ&ドロップで並べ替えたrankを上書きしないよう、rankの無いWishだけに振る
+UPDATE wishes w
+SET rank = r.rank
+FROM (
+  SELECT id,
+         lpad(to_hex(row_number() OVER (
+           PARTITION BY organization_id
+           ORDER BY order_no DESC, created_at DESC, id DESC
+         ) * 4096 + 1), 8, '0') AS rank
+  FROM wishes
+) r
+WHERE w.id = r.id AND w.rank IS NULL;
+
+ALTER TABLE wishes ALTER COLUMN rank SET NOT NULL;
+
+-- 既定の並び順（rank, id）
+CREATE INDEX IF NOT EXISTS idx_wishes_rank ON wishes(organization_id, rank, id);
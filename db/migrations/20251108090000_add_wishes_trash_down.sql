@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_wishes_trash;
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS trash_retention_days;
+
+ALTER TABLE wishes DROP COLUMN IF EXISTS deleted_by;
@@ -0,0 +1,11 @@
+-- ゴミ箱: 誰が削除したかを記録し、組織ごとの保持期間を過ぎたら完全削除する
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS deleted_by uuid REFERENCES users(id) ON DELETE SET NULL;
+
+-- 0は無期限保持
+ALTER TABLE organizations
+  ADD COLUMN IF NOT EXISTS trash_retention_days int NOT NULL DEFAULT 30
+    CHECK (trash_retention_days >= 0);
+
+-- 自動削除・ゴミ箱一覧用
+CREATE INDEX IF NOT EXISTS idx_wishes_trash ON wishes(organization_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
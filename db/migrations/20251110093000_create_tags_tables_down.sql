@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_wish_tags_tag;
+DROP TABLE IF EXISTS wish_tags;
+DROP INDEX IF EXISTS idx_tags_org_name;
+DROP TABLE IF EXISTS tags;
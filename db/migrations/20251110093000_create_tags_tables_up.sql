@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tags (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name            text        NOT NULL,
+  color           text        NOT NULL DEFAULT '',  -- '#RRGGBB'
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now()
+);
+
+-- 組織内でタグ名は大文字小文字を区別せず一意
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_org_name ON tags(organization_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS wish_tags (
+  wish_id    uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  tag_id     uuid        NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (wish_id, tag_id)
+);
+
+-- tag= での絞り込み用
+CREATE INDEX IF NOT EXISTS idx_wish_tags_tag ON wish_tags(tag_id, wish_id);
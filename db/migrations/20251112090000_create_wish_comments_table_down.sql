@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_wish_comments_parent;
+DROP INDEX IF EXISTS idx_wish_comments_wish;
+DROP TABLE IF EXISTS wish_comments;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS wish_comments (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  -- 返信の場合のみ。返信への返信はアプリ側で禁止（1階層まで）
+  parent_id       uuid        REFERENCES wish_comments(id) ON DELETE CASCADE,
+  author_id       uuid        NOT NULL REFERENCES users(id),
+  body            text        NOT NULL,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now(),
+  edited_at       timestamptz
+);
+
+-- トップレベルのコメント一覧（古い順のカーソルページング）
+CREATE INDEX IF NOT EXISTS idx_wish_comments_wish
+  ON wish_comments(wish_id, created_at, id) WHERE parent_id IS NULL;
+
+-- 返信一覧・返信数
+CREATE INDEX IF NOT EXISTS idx_wish_comments_parent
+  ON wish_comments(parent_id, created_at, id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_wishes_score;
+ALTER TABLE wishes DROP COLUMN IF EXISTS score;
+DROP TABLE IF EXISTS wish_votes;
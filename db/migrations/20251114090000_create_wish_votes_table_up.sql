@@ -0,0 +1,14 @@
+-- メンバーごとのWishへの投票（1メンバー1Wishにつき1票）
+CREATE TABLE IF NOT EXISTS wish_votes (
+  wish_id    uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  user_id    uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  value      smallint    NOT NULL CHECK (value IN (1, -1)),
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (wish_id, user_id)
+);
+
+-- 合計スコアは投票時に wishes.score へ書き込み、sort=score のkeysetに使う
+ALTER TABLE wishes ADD COLUMN IF NOT EXISTS score int NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_wishes_score ON wishes(organization_id, score, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wish_attachments_wish;
+DROP TABLE IF EXISTS wish_attachments;
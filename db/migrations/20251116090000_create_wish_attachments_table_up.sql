@@ -0,0 +1,17 @@
+-- Wishに添付した画像。本体とサムネイルはBlobStore（ローカル or S3互換）に保存し、ここではキーのみ持つ
+CREATE TABLE IF NOT EXISTS wish_attachments (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  uploaded_by     uuid        NOT NULL REFERENCES users(id),
+  file_name       text        NOT NULL,
+  content_type    text        NOT NULL,
+  size            bigint      NOT NULL,
+  width           int         NOT NULL,
+  height          int         NOT NULL,
+  blob_key        text        NOT NULL,
+  thumbnail_key   text        NOT NULL,
+  created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_attachments_wish ON wish_attachments(wish_id, created_at, id);
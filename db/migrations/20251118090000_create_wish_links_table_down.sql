@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_wish_links_pending;
+DROP INDEX IF EXISTS idx_wish_links_wish;
+DROP TABLE IF EXISTS wish_links;
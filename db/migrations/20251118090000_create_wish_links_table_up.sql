@@ -0,0 +1,20 @@
+-- Wishに付けたURLと、バックグラウンドで取得したOpenGraphプレビューのキャッシュ
+CREATE TABLE IF NOT EXISTS wish_links (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  url             text        NOT NULL,
+  status          text        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'failed')),
+  title           text        NOT NULL DEFAULT '',
+  description     text        NOT NULL DEFAULT '',
+  image_url       text        NOT NULL DEFAULT '',
+  site_name       text        NOT NULL DEFAULT '',
+  fetch_error     text        NOT NULL DEFAULT '',
+  fetched_at      timestamptz,
+  created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_links_wish ON wish_links(wish_id, created_at, id);
+
+-- 取得待ちのリンク（バックグラウンド処理が古い順に拾う）
+CREATE INDEX IF NOT EXISTS idx_wish_links_pending ON wish_links(created_at, id) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_wish_contributions_org;
+DROP INDEX IF EXISTS idx_wish_contributions_wish;
+DROP TABLE IF EXISTS wish_contributions;
+
+ALTER TABLE wishes DROP CONSTRAINT IF EXISTS wishes_price_pair;
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS price_currency,
+  DROP COLUMN IF EXISTS price_amount;
@@ -0,0 +1,25 @@
+-- Wishの予算。金額は通貨の最小単位（JPYなら円、USDならセント）の整数で持つ
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS price_amount   bigint CHECK (price_amount >= 0),
+  ADD COLUMN IF NOT EXISTS price_currency text   CHECK (price_currency ~ '^[A-Z]{3}$');
+
+-- 金額と通貨は両方設定するか両方NULL
+ALTER TABLE wishes DROP CONSTRAINT IF EXISTS wishes_price_pair;
+ALTER TABLE wishes ADD CONSTRAINT wishes_price_pair
+  CHECK ((price_amount IS NULL) = (price_currency IS NULL));
+
+-- メンバーがWishのために積み立てた記録（負の金額は取り崩し）
+CREATE TABLE IF NOT EXISTS wish_contributions (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  member_id       uuid        NOT NULL REFERENCES users(id),
+  amount          bigint      NOT NULL CHECK (amount <> 0),
+  currency        text        NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+  note            text        NOT NULL DEFAULT '',
+  contributed_at  timestamptz NOT NULL DEFAULT now(),
+  created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_contributions_wish ON wish_contributions(wish_id, contributed_at, id);
+CREATE INDEX IF NOT EXISTS idx_wish_contributions_org ON wish_contributions(organization_id, wish_id, currency);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_wish_reminders_user;
+DROP TABLE IF EXISTS wish_reminders;
+
+DROP INDEX IF EXISTS idx_wishes_assignee;
+DROP INDEX IF EXISTS idx_wishes_target_date;
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS assignee_id,
+  DROP COLUMN IF EXISTS target_date;
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS timezone;
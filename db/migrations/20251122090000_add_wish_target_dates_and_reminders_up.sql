@@ -0,0 +1,28 @@
+-- 期日・リマインダーの「今日」を判定する組織のタイムゾーン（IANA名）
+ALTER TABLE organizations
+  ADD COLUMN IF NOT EXISTS timezone text NOT NULL DEFAULT 'UTC';
+
+-- Wishの期日と担当メンバー
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS target_date date,
+  ADD COLUMN IF NOT EXISTS assignee_id uuid REFERENCES users(id) ON DELETE SET NULL;
+
+-- upcoming / overdue ビューと target_date ソート用
+CREATE INDEX IF NOT EXISTS idx_wishes_target_date
+  ON wishes(organization_id, target_date, id) WHERE deleted_at IS NULL AND target_date IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_wishes_assignee ON wishes(organization_id, assignee_id) WHERE assignee_id IS NOT NULL;
+
+-- メンバーごとのリマインダー。期日の offset_days 日前に通知する
+-- sent_for は通知した時点の期日で、期日が変わると再び通知対象になる
+CREATE TABLE IF NOT EXISTS wish_reminders (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  user_id         uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  offset_days     int         NOT NULL CHECK (offset_days BETWEEN 0 AND 365),
+  sent_for        date,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (wish_id, user_id, offset_days)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_reminders_user ON wish_reminders(user_id, wish_id);
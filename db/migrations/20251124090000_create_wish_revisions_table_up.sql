@@ -0,0 +1,14 @@
+-- Wishの変更履歴。snapshot は変更後の編集可能な項目、changes は直前の状態との項目ごとの差分
+CREATE TABLE IF NOT EXISTS wish_revisions (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  number          int         NOT NULL CHECK (number > 0),
+  -- 履歴導入前からあるWishの起点（最初の変更時に記録）はNULL
+  actor_id        uuid        REFERENCES users(id) ON DELETE SET NULL,
+  snapshot        jsonb       NOT NULL,
+  changes         jsonb       NOT NULL DEFAULT '[]',
+  reverted_from   int,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (wish_id, number)
+);
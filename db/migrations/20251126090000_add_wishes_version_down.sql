@@ -0,0 +1 @@
+ALTER TABLE wishes DROP COLUMN IF EXISTS version;
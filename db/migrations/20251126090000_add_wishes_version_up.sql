@@ -0,0 +1,4 @@
+-- 楽観的排他制御（ETag / If-Match）用のバージョン。編集・status変更・ソフトデリート・復元のたびに+1
+-- 並び替え（rank）と投票（score）では変わらない
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1 CHECK (version > 0);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_wishes_series;
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS series_id;
+
+DROP INDEX IF EXISTS idx_wish_series_due;
+DROP INDEX IF EXISTS idx_wish_series_org;
+DROP TABLE IF EXISTS wish_series;
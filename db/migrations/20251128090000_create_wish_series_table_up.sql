@@ -0,0 +1,31 @@
+-- 繰り返しWishのテンプレート。rule（RRULE）の各回に、その日付を期日とするWishを作る
+-- next_date はまだWishを作っていない次の回で、ルールが終わるとNULLになる
+CREATE TABLE IF NOT EXISTS wish_series (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  title           text        NOT NULL,
+  note            text        NOT NULL DEFAULT '',
+  price_amount    bigint,
+  price_currency  text,
+  tag_ids         jsonb       NOT NULL DEFAULT '[]',
+  assignee_id     uuid        REFERENCES users(id) ON DELETE SET NULL,
+  rule            text        NOT NULL,
+  start_date      date        NOT NULL,
+  status          text        NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'stopped', 'completed')),
+  next_date       date,
+  last_date       date,
+  instance_count  int         NOT NULL DEFAULT 0,
+  created_by      uuid        NOT NULL REFERENCES users(id),
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now(),
+  CHECK ((price_amount IS NULL) = (price_currency IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_series_org ON wish_series(organization_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_wish_series_due ON wish_series(next_date) WHERE status = 'active';
+
+-- 繰り返しから作られたWishは元のテンプレートを指す。テンプレートを消しても過去のWishは残す
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS series_id uuid REFERENCES wish_series(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_wishes_series ON wishes(series_id, target_date DESC) WHERE series_id IS NOT NULL;
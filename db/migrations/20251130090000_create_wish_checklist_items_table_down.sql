@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_wish_checklist_items_wish;
+DROP TABLE IF EXISTS wish_checklist_items;
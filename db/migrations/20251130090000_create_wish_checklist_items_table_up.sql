@@ -0,0 +1,15 @@
+-- Wishのチェックリスト項目。rank は wishes.rank と同じくバイト順で比較するため COLLATE "C" とする
+CREATE TABLE IF NOT EXISTS wish_checklist_items (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  wish_id         uuid        NOT NULL REFERENCES wishes(id) ON DELETE CASCADE,
+  title           text        NOT NULL,
+  rank            text        COLLATE "C" NOT NULL,
+  assignee_id     uuid        REFERENCES users(id) ON DELETE SET NULL,
+  completed_at    timestamptz,
+  completed_by    uuid        REFERENCES users(id) ON DELETE SET NULL,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_checklist_items_wish ON wish_checklist_items(wish_id, rank);
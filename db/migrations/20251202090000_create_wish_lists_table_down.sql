@@ -0,0 +1,11 @@
+ALTER TABLE wish_series
+  DROP COLUMN IF EXISTS list_id;
+
+DROP INDEX IF EXISTS idx_wishes_list_rank;
+
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS list_id;
+
+DROP INDEX IF EXISTS uq_wish_lists_default;
+DROP INDEX IF EXISTS idx_wish_lists_org;
+DROP TABLE IF EXISTS wish_lists;
@@ -0,0 +1,38 @@
+-- Wishの名前付きリスト（ボード）。Wishはどれか1つのリストに属し、リストの中で rank 順に並ぶ
+-- is_default のリストは組織に1つで、list_id を指定せずに作ったWishが入る（アーカイブ・削除できない）
+CREATE TABLE IF NOT EXISTS wish_lists (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name            text        NOT NULL,
+  icon            text        NOT NULL DEFAULT '',
+  rank            text        COLLATE "C" NOT NULL,
+  is_default      boolean     NOT NULL DEFAULT false,
+  archived_at     timestamptz,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  updated_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_lists_org ON wish_lists(organization_id, rank, id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_wish_lists_default ON wish_lists(organization_id) WHERE is_default;
+
+-- 既存の組織に既定のリストを作り、まだリストに入っていないWishをそこに入れる（以降に作られた組織は初回利用時に作る）
+-- 再実行しても、別のリストへ移したWishは戻さない
+INSERT INTO wish_lists (organization_id, name, rank, is_default)
+SELECT id, 'Wishes', 'i', true FROM organizations
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE wishes ADD COLUMN IF NOT EXISTS list_id uuid REFERENCES wish_lists(id);
+
+UPDATE wishes w
+SET list_id = l.id
+FROM wish_lists l
+WHERE l.organization_id = w.organization_id AND l.is_default AND w.list_id IS NULL;
+
+ALTER TABLE wishes ALTER COLUMN list_id SET NOT NULL;
+
+-- 既定の並び順（rank, id）はリストごとになる
+CREATE INDEX IF NOT EXISTS idx_wishes_list_rank ON wishes(organization_id, list_id, rank, id);
+
+-- 繰り返しの各回を作るリスト。リストが削除された場合は既定のリストに作る
+ALTER TABLE wish_series
+  ADD COLUMN IF NOT EXISTS list_id uuid REFERENCES wish_lists(id) ON DELETE SET NULL;
@@ -0,0 +1,18 @@
+-- 組織外の人向けの読み取り専用の共有リンク。トークンはハッシュだけを保存し、作成時に一度だけ返す
+-- 取り消したリンクも閲覧数の記録として残す
+CREATE TABLE IF NOT EXISTS share_links (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  list_id         uuid        REFERENCES wish_lists(id) ON DELETE CASCADE,
+  token_hash      text        NOT NULL UNIQUE,
+  token_prefix    text        NOT NULL,
+  password_hash   text        NOT NULL DEFAULT '',
+  expires_at      timestamptz,
+  revoked_at      timestamptz,
+  view_count      bigint      NOT NULL DEFAULT 0,
+  last_viewed_at  timestamptz,
+  created_by      uuid        NOT NULL REFERENCES users(id),
+  created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_org ON share_links(organization_id, created_at DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_wishes_origin;
+
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS origin_kind,
+  DROP COLUMN IF EXISTS origin_organization_id,
+  DROP COLUMN IF EXISTS origin_wish_id;
@@ -0,0 +1,8 @@
+-- 別の組織からコピー・移動して作られたWishの元（origin_kind: copy | move）
+-- 元のWishが完全削除されても、どの組織から来たかは残す
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS origin_wish_id uuid REFERENCES wishes(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS origin_organization_id uuid REFERENCES organizations(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS origin_kind text;
+
+CREATE INDEX IF NOT EXISTS idx_wishes_origin ON wishes(origin_wish_id) WHERE origin_wish_id IS NOT NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_wishes_place;
+
+ALTER TABLE wishes
+  DROP CONSTRAINT IF EXISTS chk_wishes_place_location;
+
+ALTER TABLE wishes
+  DROP COLUMN IF EXISTS place_lng,
+  DROP COLUMN IF EXISTS place_lat,
+  DROP COLUMN IF EXISTS place_address,
+  DROP COLUMN IF EXISTS place_name;
@@ -0,0 +1,18 @@
+-- Wishの場所（レストラン・旅行先など）。座標はWGS 84の度で、緯度・経度は両方あるか両方無いか
+ALTER TABLE wishes
+  ADD COLUMN IF NOT EXISTS place_name text,
+  ADD COLUMN IF NOT EXISTS place_address text,
+  ADD COLUMN IF NOT EXISTS place_lat double precision,
+  ADD COLUMN IF NOT EXISTS place_lng double precision;
+
+ALTER TABLE wishes DROP CONSTRAINT IF EXISTS chk_wishes_place_location;
+ALTER TABLE wishes
+  ADD CONSTRAINT chk_wishes_place_location CHECK (
+    (place_lat IS NULL) = (place_lng IS NULL)
+    AND (place_lat IS NULL OR place_lat BETWEEN -90 AND 90)
+    AND (place_lng IS NULL OR place_lng BETWEEN -180 AND 180)
+  );
+
+-- 近くのWishの検索（GET /wishes/nearby）は緯度・経度の範囲で絞り込んでから距離を計算する
+CREATE INDEX IF NOT EXISTS idx_wishes_place ON wishes(organization_id, place_lat, place_lng)
+  WHERE place_lat IS NOT NULL AND deleted_at IS NULL;
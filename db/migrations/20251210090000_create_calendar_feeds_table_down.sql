@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uq_calendar_feeds_member;
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,13 @@
+-- メンバーごと・組織ごとのiCalendar購読URL。トークンはハッシュだけを保存し、作り直すと古いURLは使えなくなる
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+  id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id uuid        NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id         uuid        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash      text        NOT NULL UNIQUE,
+  token_prefix    text        NOT NULL,
+  created_at      timestamptz NOT NULL DEFAULT now(),
+  rotated_at      timestamptz NOT NULL DEFAULT now(),
+  last_fetched_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_calendar_feeds_member ON calendar_feeds(organization_id, user_id);
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrAttachmentTooLarge   = errors.New("attachment too large")
+)
+
+// Attachment is an image attached to a wish. The original and its thumbnail live in a BlobStore.
+type Attachment struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	UploadedBy     uuid.UUID
+	FileName       string
+	ContentType    string
+	Size           int64
+	Width          int
+	Height         int
+	BlobKey        string
+	ThumbnailKey   string
+	CreatedAt      time.Time
+
+	// URL, ThumbnailURL and URLExpiresAt are signed download URLs, set when the attachment is served
+	URL          string
+	ThumbnailURL string
+	URLExpiresAt time.Time
+}
+
+// AttachmentBlobKey returns the blob key of an attachment, scoped by organization and wish
+func AttachmentBlobKey(organizationID, wishID, attachmentID uuid.UUID) string {
+	return organizationID.String() + "/wishes/" + wishID.String() + "/" + attachmentID.String()
+}
+
+// AttachmentRepository defines the interface for attachment data operations.
+// Every method is scoped by organizationID like WishRepository.
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *Attachment) (*Attachment, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*Attachment, error)
+	FindByWishID(ctx context.Context, organizationID, wishID uuid.UUID) ([]*Attachment, error)
+	// FindByTrashedBefore returns the attachments of wishes soft-deleted before the given time,
+	// i.e. those that WishRepository.PurgeDeleted would remove with their wishes
+	FindByTrashedBefore(ctx context.Context, organizationID uuid.UUID, before time.Time) ([]*Attachment, error)
+	Delete(ctx context.Context, organizationID, id uuid.UUID) error
+}
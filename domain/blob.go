@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+)
+
+// BlobStore stores binary objects such as attachment images.
+// Keys are slash-separated paths; callers prefix them with the organization ID
+// so a signed URL can only ever reach that organization's objects.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads key without further authentication until ttl elapses
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
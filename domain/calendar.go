@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+)
+
+// CalendarFeed is a member's secret iCalendar subscription to the dated wishes of an organization.
+// Each member has at most one feed per organization. Only a hash of the token is stored;
+// rotating the feed replaces the token, so the old subscription URL stops working.
+type CalendarFeed struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	// TokenHash is the hex SHA-256 of the token; TokenPrefix is its first characters, for telling feeds apart
+	TokenHash   string
+	TokenPrefix string
+	CreatedAt   time.Time
+	RotatedAt   time.Time
+	// LastFetchedAt is when a calendar client last fetched the feed
+	LastFetchedAt *time.Time
+}
+
+// CalendarWishes is what a feed serves: the organization's wishes that have a target date
+type CalendarWishes struct {
+	OrganizationName string
+	Wishes           []*Wish
+}
+
+// CalendarFeedRepository defines the interface for calendar feed data operations
+type CalendarFeedRepository interface {
+	FindByUserAndOrg(ctx context.Context, userID, organizationID uuid.UUID) (*CalendarFeed, error)
+	// FindByTokenHash looks a feed up across organizations for the public route
+	FindByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeed, error)
+	// Rotate creates the member's feed, or replaces the token of the existing one
+	Rotate(ctx context.Context, userID, organizationID uuid.UUID, tokenHash, tokenPrefix string) (*CalendarFeed, error)
+	// DeleteByUserAndOrg deletes the member's feed; it returns ErrCalendarFeedNotFound when there is none
+	DeleteByUserAndOrg(ctx context.Context, userID, organizationID uuid.UUID) error
+	RecordFetch(ctx context.Context, id uuid.UUID, at time.Time) error
+}
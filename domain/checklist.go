@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrInvalidChecklistItem  = errors.New("invalid checklist item")
+	ErrTooManyChecklistItems = errors.New("too many checklist items")
+	ErrInvalidChecklistMove  = errors.New("invalid checklist move")
+)
+
+// ChecklistItem is one ordered step under a wish
+type ChecklistItem struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	Title          string
+	// Rank orders the items of a wish the same way wishes are ordered on the board (see RankBetween)
+	Rank       string
+	AssigneeID *uuid.UUID
+	// CompletedAt is set while the item is checked
+	CompletedAt *time.Time
+	CompletedBy *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Done reports whether the item is checked
+func (i *ChecklistItem) Done() bool {
+	return i.CompletedAt != nil
+}
+
+// ChecklistProgress counts the checked items of a wish
+type ChecklistProgress struct {
+	Done  int
+	Total int
+}
+
+// Complete reports whether the wish has items and all of them are checked
+func (p ChecklistProgress) Complete() bool {
+	return p.Total > 0 && p.Done == p.Total
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrInvalidComment  = errors.New("invalid comment")
+	// ErrReplyDepth is returned when replying to a reply; threads are one level deep
+	ErrReplyDepth = errors.New("replies cannot be nested")
+)
+
+// Comment is a message on a wish. A comment with ParentID set is a reply
+// to a top-level comment of the same wish.
+type Comment struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	ParentID       *uuid.UUID
+	AuthorID       uuid.UUID
+	// Author is resolved from AuthorID; nil when the user no longer exists
+	Author     *User
+	Body       string
+	ReplyCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	EditedAt   *time.Time
+}
+
+// CommentListQuery selects one page of a wish's comments.
+// Without ParentID the top-level comments are listed, otherwise the replies to ParentID.
+type CommentListQuery struct {
+	Limit    int
+	After    *CommentCursor
+	ParentID *uuid.UUID
+}
+
+// CommentPage is one page of a keyset-paginated comment list
+type CommentPage struct {
+	Comments   []*Comment
+	NextCursor string
+}
+
+// CommentCursor is the keyset position after the last comment of a page.
+// Comments are always listed oldest first.
+type CommentCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// NewCommentCursor builds the cursor pointing just after the given comment
+func NewCommentCursor(comment *Comment) *CommentCursor {
+	return &CommentCursor{CreatedAt: comment.CreatedAt, ID: comment.ID}
+}
+
+// Encode returns the opaque string handed to clients as next_cursor
+func (c *CommentCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCommentCursor parses a cursor produced by Encode
+func DecodeCommentCursor(s string) (*CommentCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c CommentCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CommentRepository defines the interface for comment data operations.
+// Every method is scoped by organizationID like WishRepository.
+type CommentRepository interface {
+	Create(ctx context.Context, comment *Comment) (*Comment, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*Comment, error)
+	List(ctx context.Context, organizationID, wishID uuid.UUID, query CommentListQuery) ([]*Comment, error)
+	UpdateBody(ctx context.Context, organizationID, id uuid.UUID, body string) (*Comment, error)
+	// Delete removes the comment together with its replies
+	Delete(ctx context.Context, organizationID, id uuid.UUID) error
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrContributionNotFound = errors.New("contribution not found")
+	ErrInvalidContribution  = errors.New("invalid contribution")
+)
+
+// Contribution is money a member set aside toward a wish.
+// A negative amount records a withdrawal.
+type Contribution struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	MemberID       uuid.UUID
+	// Member is resolved from MemberID; nil when the user no longer exists
+	Member        *User
+	Amount        Money
+	Note          string
+	ContributedAt time.Time
+	CreatedAt     time.Time
+}
+
+// ContributionTotal is the sum of a wish's contributions in one currency
+type ContributionTotal struct {
+	WishID   uuid.UUID
+	Currency string
+	Amount   int64
+}
+
+// CurrencyProgress compares the price target with the money set aside, in one currency
+type CurrencyProgress struct {
+	Currency    string
+	Target      int64
+	Contributed int64
+}
+
+// WishProgress is the savings progress of one wish, grouped by currency
+type WishProgress struct {
+	Wish   *Wish
+	Totals []CurrencyProgress
+}
+
+// OrganizationProgress is the savings progress of every budgeted wish of an organization
+type OrganizationProgress struct {
+	Totals []CurrencyProgress
+	Wishes []*WishProgress
+}
+
+// ContributionRepository defines the interface for contribution data operations.
+// Every method is scoped by organizationID like WishRepository.
+type ContributionRepository interface {
+	Create(ctx context.Context, contribution *Contribution) (*Contribution, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*Contribution, error)
+	FindByWishID(ctx context.Context, organizationID, wishID uuid.UUID) ([]*Contribution, error)
+	Delete(ctx context.Context, organizationID, id uuid.UUID) error
+	// SumByWishIDs totals contributions per wish and currency
+	SumByWishIDs(ctx context.Context, organizationID uuid.UUID, wishIDs []uuid.UUID) ([]*ContributionTotal, error)
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// DateLayout is the wire format of calendar dates such as target dates
+const DateLayout = "2006-01-02"
+
+// DateOf returns the calendar date of t (in t's own location) as midnight UTC.
+// Calendar dates are always carried this way so they compare and store without zone shifts.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ParseDate parses a calendar date in DateLayout
+func ParseDate(s string) (time.Time, error) {
+	return time.Parse(DateLayout, s)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLinkNotFound = errors.New("link not found")
+	ErrInvalidLink  = errors.New("invalid link")
+	ErrTooManyLinks = errors.New("too many links")
+	// ErrUnsafeURL is returned by a LinkPreviewFetcher for URLs resolving to private networks
+	ErrUnsafeURL = errors.New("url points to a disallowed address")
+)
+
+// LinkStatus is the state of a link's preview
+type LinkStatus string
+
+const (
+	LinkStatusPending LinkStatus = "pending"
+	LinkStatusReady   LinkStatus = "ready"
+	LinkStatusFailed  LinkStatus = "failed"
+)
+
+// LinkPreview is the OpenGraph / Twitter card metadata of a page
+type LinkPreview struct {
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// WishLink is a URL attached to a wish together with its cached preview
+type WishLink struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	URL            string
+	Status         LinkStatus
+	Preview        LinkPreview
+	FetchError     string
+	FetchedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+// LinkPreviewFetcher fetches the preview metadata of a URL
+type LinkPreviewFetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*LinkPreview, error)
+}
+
+// LinkRepository defines the interface for wish link data operations.
+// Methods taking organizationID are scoped like WishRepository.
+type LinkRepository interface {
+	Create(ctx context.Context, link *WishLink) (*WishLink, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*WishLink, error)
+	CountByWishID(ctx context.Context, wishID uuid.UUID) (int, error)
+	Delete(ctx context.Context, organizationID, id uuid.UUID) error
+	// MarkPending queues the link for another fetch
+	MarkPending(ctx context.Context, organizationID, id uuid.UUID) error
+	// FindPending returns links waiting for a fetch across all organizations, oldest first
+	FindPending(ctx context.Context, limit int) ([]*WishLink, error)
+	// SaveResult stores a fetch result; preview is nil when the fetch failed
+	SaveResult(ctx context.Context, id uuid.UUID, preview *LinkPreview, fetchErr error) error
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrListNotFound = errors.New("list not found")
+	ErrInvalidList  = errors.New("invalid list")
+	// ErrListArchived is returned when a wish would be created in or moved into an archived list
+	ErrListArchived = errors.New("list is archived")
+	// ErrDefaultList is returned when archiving or deleting an organization's default list
+	ErrDefaultList = errors.New("the default list cannot be archived or deleted")
+	// ErrListNotEmpty is returned when deleting a list that still has live wishes
+	ErrListNotEmpty = errors.New("list still has wishes")
+)
+
+// DefaultListName is the name of the list every organization starts with
+const DefaultListName = "Wishes"
+
+// List is a named board of wishes within an organization.
+// Each wish belongs to exactly one list and is ordered by rank within it.
+type List struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	// Icon is an emoji or icon key chosen by the client; empty for none
+	Icon string
+	// Rank orders the lists of an organization (see RankBetween)
+	Rank string
+	// IsDefault marks the list that receives wishes created without a list; it cannot be archived or deleted
+	IsDefault  bool
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Archived reports whether the list is archived
+func (l *List) Archived() bool {
+	return l.ArchivedAt != nil
+}
+
+// ListRepository defines the interface for wish list data operations.
+// Every method is scoped by organizationID like WishRepository.
+type ListRepository interface {
+	Create(ctx context.Context, list *List) (*List, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*List, error)
+	// FindByOrganizationID returns the lists in rank order, archived ones only when includeArchived
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID, includeArchived bool) ([]*List, error)
+	// EnsureDefault returns the organization's default list, creating it first in the order when missing
+	EnsureDefault(ctx context.Context, organizationID uuid.UUID) (*List, error)
+	// Update saves the name, icon and archived state of a list
+	Update(ctx context.Context, list *List) (*List, error)
+	// UpdateRanks sets ranks[i] on ids[i] in one transaction
+	UpdateRanks(ctx context.Context, organizationID uuid.UUID, ids []uuid.UUID, ranks []string) error
+	// Delete removes a list without live wishes. Trashed wishes still in it move to moveTrashTo,
+	// so they can be restored after the list is gone.
+	Delete(ctx context.Context, organizationID, id, moveTrashTo uuid.UUID) error
+}
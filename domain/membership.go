@@ -16,4 +16,5 @@ type OrganizationMember struct {
 type MembershipRepository interface {
 	UpsertByUserAndOrg(ctx context.Context, userID, orgID uuid.UUID, role string) (*OrganizationMember, error)
 	SoftDeleteByUserAndOrg(ctx context.Context, userID, orgID uuid.UUID) error
+	FindByUserAndOrg(ctx context.Context, userID, orgID uuid.UUID) (*OrganizationMember, error)
 }
@@ -2,18 +2,53 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidOrganizationSettings = errors.New("invalid organization settings")
+)
+
+// DefaultTrashRetentionDays is how long soft-deleted wishes stay in the trash unless the org overrides it
+const DefaultTrashRetentionDays = 30
+
+// DefaultTimezone is used for date calculations until the org picks its own
+const DefaultTimezone = "UTC"
+
 type Organization struct {
 	ID         uuid.UUID
 	ExternalID string
 	Name       string
+	// TrashRetentionDays is how many days soft-deleted wishes are kept before purge. 0 keeps them forever.
+	TrashRetentionDays int
+	// Timezone is the IANA name in which target dates and reminders are evaluated
+	Timezone string
+}
+
+// Location returns the org's time zone, falling back to UTC for unknown names
+func (o *Organization) Location() *time.Location {
+	loc, err := time.LoadLocation(o.Timezone)
+	if err != nil || o.Timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+// Today returns the org's current calendar date as midnight UTC
+func (o *Organization) Today(now time.Time) time.Time {
+	return DateOf(now.In(o.Location()))
 }
 
 type OrganizationRepository interface {
 	UpsertByExternalID(ctx context.Context, externalID, name string) (*Organization, error)
 	SoftDeleteByExternalID(ctx context.Context, externalID string) error
 	FindByExternalID(ctx context.Context, externalID string) (*Organization, error)
+	// FindByID returns nil for unknown and soft-deleted organizations
+	FindByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	FindAll(ctx context.Context) ([]*Organization, error)
+	UpdateTrashRetentionDays(ctx context.Context, id uuid.UUID, days int) (*Organization, error)
+	UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) (*Organization, error)
 }
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidPlace = errors.New("invalid place")
+)
+
+const (
+	MaxPlaceNameLen    = 200
+	MaxPlaceAddressLen = 500
+	// EarthRadiusMeters is the mean Earth radius used for distances
+	EarthRadiusMeters = 6371008.8
+)
+
+// Place is where a wish happens, such as a restaurant or a travel destination.
+// Lat and Lng are WGS 84 degrees and are either both set or both nil.
+type Place struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Lat     *float64 `json:"lat"`
+	Lng     *float64 `json:"lng"`
+}
+
+// HasLocation reports whether the place has coordinates
+func (p *Place) HasLocation() bool {
+	return p.Lat != nil && p.Lng != nil
+}
+
+// Normalize trims the name and address and returns nil for a place with nothing set
+func (p *Place) Normalize() *Place {
+	if p == nil {
+		return nil
+	}
+	normalized := &Place{
+		Name:    strings.TrimSpace(p.Name),
+		Address: strings.TrimSpace(p.Address),
+		Lat:     p.Lat,
+		Lng:     p.Lng,
+	}
+	if normalized.Name == "" && normalized.Address == "" && normalized.Lat == nil && normalized.Lng == nil {
+		return nil
+	}
+	return normalized
+}
+
+// Validate checks the lengths and that the coordinates are complete and in range
+func (p *Place) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if utf8.RuneCountInString(p.Name) > MaxPlaceNameLen || utf8.RuneCountInString(p.Address) > MaxPlaceAddressLen {
+		return ErrInvalidPlace
+	}
+	if (p.Lat == nil) != (p.Lng == nil) {
+		return ErrInvalidPlace
+	}
+	if p.HasLocation() && !(GeoPoint{Lat: *p.Lat, Lng: *p.Lng}).Valid() {
+		return ErrInvalidPlace
+	}
+	return nil
+}
+
+// GeoPoint is a WGS 84 coordinate in degrees
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// Valid reports whether the point is a finite coordinate in range
+func (g GeoPoint) Valid() bool {
+	return g.Lat >= -90 && g.Lat <= 90 && g.Lng >= -180 && g.Lng <= 180
+}
+
+// DistanceMeters returns the great-circle (haversine) distance between two points
+func DistanceMeters(a, b GeoPoint) float64 {
+	dLat := (b.Lat - a.Lat) * math.Pi / 180
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(a.Lat*math.Pi/180)*math.Cos(b.Lat*math.Pi/180)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// GeoBox is a latitude/longitude range that contains every point within a radius of a center.
+// LngRanges holds one range, or two when the box crosses the antimeridian; it is empty when
+// the box covers a pole, in which case every longitude matches.
+type GeoBox struct {
+	MinLat    float64
+	MaxLat    float64
+	LngRanges [][2]float64
+}
+
+// BoundingBox returns the box around center that contains the circle of radiusMeters
+func BoundingBox(center GeoPoint, radiusMeters float64) GeoBox {
+	angular := radiusMeters / EarthRadiusMeters
+	dLat := angular * 180 / math.Pi
+	box := GeoBox{MinLat: center.Lat - dLat, MaxLat: center.Lat + dLat}
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	// 緯度によって経度1度の距離が変わるため、円に接する経線までの経度差を求める
+	dLng := math.Asin(math.Sin(angular)/math.Cos(center.Lat*math.Pi/180)) * 180 / math.Pi
+	minLng, maxLng := center.Lng-dLng, center.Lng+dLng
+	switch {
+	case dLng >= 180 || math.IsNaN(dLng):
+		box.LngRanges = [][2]float64{{-180, 180}}
+	case minLng < -180:
+		box.LngRanges = [][2]float64{{minLng + 360, 180}, {-180, maxLng}}
+	case maxLng > 180:
+		box.LngRanges = [][2]float64{{minLng, 180}, {-180, maxLng - 360}}
+	default:
+		box.LngRanges = [][2]float64{{minLng, maxLng}}
+	}
+	return box
+}
+
+// NearbyQuery asks for the wishes with a location within RadiusMeters of Center
+type NearbyQuery struct {
+	Center       GeoPoint
+	RadiusMeters float64
+	Limit        int
+}
+
+// NearbyWish is a wish with its distance from the query center
+type NearbyWish struct {
+	Wish           *Wish
+	DistanceMeters float64
+}
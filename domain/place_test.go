@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestPlaceValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		place *Place
+		valid bool
+	}{
+		{"nil", nil, true},
+		{"name only", &Place{Name: "Onsen"}, true},
+		{"coordinates", &Place{Lat: float(35.68), Lng: float(139.76)}, true},
+		{"corners of the range", &Place{Lat: float(-90), Lng: float(180)}, true},
+		{"lat without lng", &Place{Lat: float(35.68)}, false},
+		{"lng without lat", &Place{Lng: float(139.76)}, false},
+		{"lat above 90", &Place{Lat: float(90.0001), Lng: float(0)}, false},
+		{"lat below -90", &Place{Lat: float(-91), Lng: float(0)}, false},
+		{"lng above 180", &Place{Lat: float(0), Lng: float(180.5)}, false},
+		{"lng below -180", &Place{Lat: float(0), Lng: float(-181)}, false},
+		// 緯度と経度を取り違えた値（経度139を緯度に入れた）
+		{"swapped lat and lng", &Place{Lat: float(139.76), Lng: float(35.68)}, false},
+		{"NaN", &Place{Lat: float(math.NaN()), Lng: float(0)}, false},
+		{"infinity", &Place{Lat: float(0), Lng: float(math.Inf(1))}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.place.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidPlace) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidPlace)
+			}
+		})
+	}
+}
+
+func TestDistanceMeters(t *testing.T) {
+	tokyo := GeoPoint{Lat: 35.6812, Lng: 139.7671}
+	osaka := GeoPoint{Lat: 34.7025, Lng: 135.4959}
+	// 東京駅と大阪駅はおよそ403km
+	if d := DistanceMeters(tokyo, osaka); math.Abs(d-403_000) > 2_000 {
+		t.Errorf("DistanceMeters(tokyo, osaka) = %.0f, want about 403000", d)
+	}
+	// 日付変更線をまたいでも短い方の距離になる
+	a, b := GeoPoint{Lat: 0, Lng: 179.99}, GeoPoint{Lat: 0, Lng: -179.99}
+	if d := DistanceMeters(a, b); d > 3_000 {
+		t.Errorf("DistanceMeters across the antimeridian = %.0f, want about 2224", d)
+	}
+}
+
+// boxContains - 点が GeoBox の範囲に入るか（FindNearby の WHERE 句と同じ判定）
+func boxContains(box GeoBox, p GeoPoint) bool {
+	if p.Lat < box.MinLat || p.Lat > box.MaxLat {
+		return false
+	}
+	if len(box.LngRanges) == 0 {
+		return true
+	}
+	for _, r := range box.LngRanges {
+		if p.Lng >= r[0] && p.Lng <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// destination - center から bearing（度）の方向に meters 進んだ点
+func destination(center GeoPoint, bearing, meters float64) GeoPoint {
+	lat1, lng1 := center.Lat*math.Pi/180, center.Lng*math.Pi/180
+	theta, delta := bearing*math.Pi/180, meters/EarthRadiusMeters
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lng2 := lng1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+	lng := math.Mod(lng2*180/math.Pi+540, 360) - 180
+	return GeoPoint{Lat: lat2 * 180 / math.Pi, Lng: lng}
+}
+
+func TestBoundingBox(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		center GeoPoint
+		radius float64
+		ranges int
+	}{
+		{"tokyo", GeoPoint{Lat: 35.68, Lng: 139.76}, 5_000, 1},
+		{"equator", GeoPoint{Lat: 0, Lng: 0}, 50_000, 1},
+		{"southern hemisphere", GeoPoint{Lat: -33.87, Lng: 151.21}, 20_000, 1},
+		{"west of the antimeridian", GeoPoint{Lat: -17.7, Lng: 179.9}, 50_000, 2},
+		{"east of the antimeridian", GeoPoint{Lat: 65.0, Lng: -179.95}, 30_000, 2},
+		{"on the antimeridian", GeoPoint{Lat: 0, Lng: 180}, 1_000, 2},
+		{"near the north pole", GeoPoint{Lat: 89.99, Lng: 10}, 5_000, 0},
+		{"near the south pole", GeoPoint{Lat: -89.95, Lng: -120}, 10_000, 0},
+		{"high latitude widens the lng range", GeoPoint{Lat: 80, Lng: 0}, 1_000_000, 1},
+		{"zero radius", GeoPoint{Lat: 35.68, Lng: 139.76}, 0, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			box := BoundingBox(tt.center, tt.radius)
+
+			if len(box.LngRanges) != tt.ranges {
+				t.Fatalf("LngRanges = %v, want %d ranges", box.LngRanges, tt.ranges)
+			}
+			// 角が入れ替わった範囲や、範囲外の値を作らない
+			if box.MinLat > box.MaxLat || box.MinLat < -90 || box.MaxLat > 90 {
+				t.Errorf("lat range = [%v, %v]", box.MinLat, box.MaxLat)
+			}
+			for _, r := range box.LngRanges {
+				if r[0] > r[1] || r[0] < -180 || r[1] > 180 {
+					t.Errorf("lng range = %v", r)
+				}
+			}
+
+			if !boxContains(box, tt.center) {
+				t.Errorf("box %+v does not contain the center", box)
+			}
+			// 円周上の点はすべて範囲に入る
+			for bearing := 0.0; bearing < 360; bearing += 5 {
+				p := destination(tt.center, bearing, tt.radius*0.999)
+				if !boxContains(box, p) {
+					t.Errorf("box %+v does not contain %+v at bearing %v", box, p, bearing)
+				}
+			}
+		})
+	}
+}
+
+func TestBoundingBoxExcludesFarPoints(t *testing.T) {
+	center := GeoPoint{Lat: 0, Lng: 179.9}
+	box := BoundingBox(center, 50_000)
+
+	for _, p := range []GeoPoint{
+		{Lat: 0, Lng: 0},      // 地球の反対側
+		{Lat: 0, Lng: 178},    // 西へ約210km
+		{Lat: 0, Lng: -178},   // 日付変更線の向こうへ約233km
+		{Lat: 1, Lng: 179.9},  // 北へ約111km
+		{Lat: -1, Lng: 179.9}, // 南へ約111km
+	} {
+		if boxContains(box, p) {
+			t.Errorf("box %+v contains %+v, %.0fm away", box, p, DistanceMeters(center, p))
+		}
+	}
+}
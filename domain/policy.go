@@ -0,0 +1,43 @@
+package domain
+
+import "errors"
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Resource is the kind of object a permission check is made against
+type Resource string
+
+const (
+	ResourceWish     Resource = "wish"
+	ResourceTag      Resource = "tag"
+	ResourceList     Resource = "list"
+	ResourceShare    Resource = "share"
+	ResourceComment  Resource = "comment"
+	ResourceSettings Resource = "settings"
+)
+
+// Action is the operation a permission check is made for
+type Action string
+
+const (
+	ActionRead       Action = "read"
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionSoftDelete Action = "soft_delete"
+	ActionRestore    Action = "restore"
+	ActionDelete     Action = "delete"
+	ActionManage     Action = "manage"
+	ActionVote       Action = "vote"
+)
+
+// PermissionDecision is the result of evaluating the role policy
+type PermissionDecision struct {
+	Allowed      bool     `json:"allowed"`
+	Code         string   `json:"code,omitempty"`
+	Resource     Resource `json:"resource"`
+	Action       Action   `json:"action"`
+	Role         string   `json:"role"`
+	RequiredRole string   `json:"required_role,omitempty"`
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidRank = errors.New("invalid rank")
+)
+
+// rankDigits is the alphabet of ranks. Ranks compare bytewise (COLLATE "C"),
+// so a rank reads as a base-36 fraction 0.d1d2d3...
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankDigits)
+
+// ValidRank reports whether r is a well-formed rank.
+// Ranks never end in '0'; that guarantees there is always room below any rank.
+func ValidRank(r string) bool {
+	if r == "" || r[len(r)-1] == '0' {
+		return false
+	}
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(rankDigits, r[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RankBetween returns a rank strictly between lower and upper.
+// An empty lower means "before everything", an empty upper means "after everything".
+// Neighbours are never rewritten: the result just grows a digit when they are adjacent.
+func RankBetween(lower, upper string) (string, error) {
+	if (lower != "" && !ValidRank(lower)) || (upper != "" && !ValidRank(upper)) {
+		return "", ErrInvalidRank
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return "", ErrInvalidRank
+	}
+
+	var b strings.Builder
+	unbounded := upper == ""
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(lower) {
+			lo = strings.IndexByte(rankDigits, lower[i])
+		}
+		hi := rankBase
+		if !unbounded {
+			if i >= len(upper) {
+				// lower < upper かつ末尾が'0'でない限り到達しない
+				return "", ErrInvalidRank
+			}
+			hi = strings.IndexByte(rankDigits, upper[i])
+		}
+
+		switch {
+		case lo == hi:
+			b.WriteByte(rankDigits[lo])
+		case hi-lo > 1:
+			b.WriteByte(rankDigits[(lo+hi)/2])
+			return b.String(), nil
+		default:
+			// 隣接する桁: loを採用し、以降はupperの制約が外れる
+			b.WriteByte(rankDigits[lo])
+			unbounded = true
+		}
+	}
+}
+
+// RankSequence returns n evenly spaced ascending ranks, used to rebalance a whole board
+func RankSequence(n int) []string {
+	ranks := make([]string, n)
+	if n == 0 {
+		return ranks
+	}
+
+	// 36^width > (n+1)*36 となる桁数を選び、間隔に余裕を持たせる
+	width, space := 1, rankBase
+	for space <= (n+1)*rankBase {
+		width++
+		space *= rankBase
+	}
+	step := space / (n + 1)
+
+	for i := range ranks {
+		v := (i + 1) * step
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[v%rankBase]
+			v /= rankBase
+		}
+		ranks[i] = strings.TrimRight(string(digits), "0")
+	}
+	return ranks
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		lower, upper string
+		want         string
+	}{
+		{"empty board", "", "", "i"},
+		{"before everything", "", "i", "9"},
+		{"after everything", "i", "", "r"},
+		{"after the last digit", "z", "", "zi"},
+		{"before the first digit", "", "1", "0i"},
+		{"wide gap", "a", "c", "b"},
+		{"neighbouring digits", "a", "b", "ai"},
+		{"neighbouring ranks of different lengths", "a", "a1", "a0i"},
+		{"upper extends lower", "ai", "aj", "aii"},
+		{"lower longer than upper", "azz", "b", "azzi"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.lower, tt.upper)
+			if err != nil {
+				t.Fatalf("RankBetween(%q, %q) error = %v", tt.lower, tt.upper, err)
+			}
+			if got != tt.want {
+				t.Errorf("RankBetween(%q, %q) = %q, want %q", tt.lower, tt.upper, got, tt.want)
+			}
+			assertBetween(t, tt.lower, got, tt.upper)
+		})
+	}
+}
+
+func TestRankBetweenRejectsInvalidBounds(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		lower, upper string
+	}{
+		{"equal", "a", "a"},
+		{"swapped", "b", "a"},
+		{"lower is a longer upper", "ab", "a"},
+		{"trailing zero", "a0", ""},
+		{"upper with trailing zero", "", "b0"},
+		{"uppercase", "A", ""},
+		{"outside the alphabet", "", "a-b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := RankBetween(tt.lower, tt.upper); !errors.Is(err, ErrInvalidRank) {
+				t.Errorf("RankBetween(%q, %q) = %q, %v, want %v", tt.lower, tt.upper, got, err, ErrInvalidRank)
+			}
+		})
+	}
+}
+
+func TestRankBetweenNeverRunsOutOfSpace(t *testing.T) {
+	// 同じ位置への挿入を繰り返しても、桁が増えるだけで常に間のrankが作れる
+	for _, tt := range []struct {
+		name         string
+		lower, upper string
+		next         func(lower, upper, inserted string) (string, string)
+	}{
+		{"always right after the lower bound", "a", "b", func(lower, upper, inserted string) (string, string) { return lower, inserted }},
+		{"always right before the upper bound", "a", "b", func(lower, upper, inserted string) (string, string) { return inserted, upper }},
+		{"always at the top", "", "b", func(lower, upper, inserted string) (string, string) { return "", inserted }},
+		{"always at the bottom", "a", "", func(lower, upper, inserted string) (string, string) { return inserted, "" }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := tt.lower, tt.upper
+			var rank string
+			for i := 0; i < 1000; i++ {
+				var err error
+				rank, err = RankBetween(lower, upper)
+				if err != nil {
+					t.Fatalf("insert %d: RankBetween(%q, %q) error = %v", i, lower, upper, err)
+				}
+				assertBetween(t, lower, rank, upper)
+				lower, upper = tt.next(lower, upper, rank)
+			}
+			// 1桁あたり5回ほど挿入できるため、1000回でも200桁程度に収まる
+			if len(rank) > 300 {
+				t.Errorf("rank grew to %d digits after 1000 inserts", len(rank))
+			}
+		})
+	}
+}
+
+func TestRankSequence(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 35, 36, 100, 5000} {
+		ranks := RankSequence(n)
+		if len(ranks) != n {
+			t.Fatalf("RankSequence(%d) returned %d ranks", n, len(ranks))
+		}
+		for i, rank := range ranks {
+			if !ValidRank(rank) {
+				t.Fatalf("RankSequence(%d)[%d] = %q is not a valid rank", n, i, rank)
+			}
+			if i > 0 && ranks[i-1] >= rank {
+				t.Fatalf("RankSequence(%d) is not ascending at %d: %q >= %q", n, i, ranks[i-1], rank)
+			}
+		}
+		// 再配置した直後は、先頭・末尾・隣同士のどの間にも高々1桁増やすだけで挿入できる
+		width := 0
+		for _, rank := range ranks {
+			width = max(width, len(rank))
+		}
+		for i := 0; i <= n && n > 0; i++ {
+			lower, upper := "", ""
+			if i > 0 {
+				lower = ranks[i-1]
+			}
+			if i < n {
+				upper = ranks[i]
+			}
+			if rank, err := RankBetween(lower, upper); err != nil || len(rank) > width+1 {
+				t.Errorf("RankSequence(%d): RankBetween(%q, %q) = %q, %v, want at most %d digits", n, lower, upper, rank, err, width+1)
+			}
+		}
+	}
+}
+
+func assertBetween(t *testing.T, lower, rank, upper string) {
+	t.Helper()
+	if !ValidRank(rank) {
+		t.Fatalf("rank %q is not valid", rank)
+	}
+	if (lower != "" && rank <= lower) || (upper != "" && rank >= upper) {
+		t.Fatalf("rank %q is not between %q and %q", rank, lower, upper)
+	}
+}
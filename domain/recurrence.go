@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+)
+
+// RecurrenceFrequency is the FREQ of a recurrence rule
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+	RecurrenceYearly  RecurrenceFrequency = "YEARLY"
+)
+
+// MaxRecurrenceInterval bounds INTERVAL so that occurrences stay within a sensible range
+const MaxRecurrenceInterval = 999
+
+// maxRecurrencePeriods stops iteration of rules that never produce another occurrence
+const maxRecurrencePeriods = 100000
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// RecurrenceRule is the subset of an RFC 5545 RRULE that wish series support, over calendar dates:
+//
+//	FREQ=DAILY|WEEKLY|MONTHLY|YEARLY (required)
+//	INTERVAL=n           every n periods (default 1)
+//	COUNT=n or UNTIL=YYYYMMDD (not both)
+//	BYDAY=MO,WE,...      WEEKLY only, weekdays without ordinals
+//	BYMONTHDAY=n         MONTHLY only, 1..31 or -1 for the last day
+//
+// As in RFC 5545, months without the requested day (and February 29 in other years) are skipped.
+// The series' start date plays the role of DTSTART; it is an occurrence only if it matches the rule.
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	Count      int        // 0 when unbounded
+	Until      *time.Time // inclusive
+	ByDay      []time.Weekday
+	ByMonthDay int // 0 when unset
+}
+
+// ParseRecurrenceRule parses a rule such as "FREQ=MONTHLY;BYMONTHDAY=1". An "RRULE:" prefix is allowed.
+func ParseRecurrenceRule(s string) (*RecurrenceRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, ErrInvalidRecurrence
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" || seen[name] {
+			return nil, ErrInvalidRecurrence
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(strings.ToUpper(value))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+			if err == nil && (rule.Interval < 1 || rule.Interval > MaxRecurrenceInterval) {
+				err = ErrInvalidRecurrence
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(value)
+			if err == nil && rule.Count < 1 {
+				err = ErrInvalidRecurrence
+			}
+		case "UNTIL":
+			// 日付部分だけを使う（YYYYMMDD または YYYYMMDDTHHMMSSZ）
+			if len(value) < 8 {
+				return nil, ErrInvalidRecurrence
+			}
+			var until time.Time
+			until, err = time.Parse("20060102", value[:8])
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(value), ",") {
+				weekday, ok := recurrenceWeekdays[day]
+				if !ok {
+					return nil, ErrInvalidRecurrence
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = strconv.Atoi(value)
+			if err == nil && (rule.ByMonthDay == 0 || rule.ByMonthDay < -1 || rule.ByMonthDay > 31) {
+				err = ErrInvalidRecurrence
+			}
+		default:
+			return nil, ErrInvalidRecurrence
+		}
+		if err != nil {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+
+	switch rule.Frequency {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly:
+	default:
+		return nil, ErrInvalidRecurrence
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, ErrInvalidRecurrence
+	}
+	if (len(rule.ByDay) > 0 && rule.Frequency != RecurrenceWeekly) ||
+		(rule.ByMonthDay != 0 && rule.Frequency != RecurrenceMonthly) {
+		return nil, ErrInvalidRecurrence
+	}
+	rule.ByDay = uniqueWeekdays(rule.ByDay)
+	return rule, nil
+}
+
+// uniqueWeekdays sorts weekdays Monday first and drops duplicates
+func uniqueWeekdays(days []time.Weekday) []time.Weekday {
+	if len(days) == 0 {
+		return nil
+	}
+	sort.Slice(days, func(i, j int) bool { return mondayOffset(days[i]) < mondayOffset(days[j]) })
+	unique := days[:1]
+	for _, day := range days[1:] {
+		if day != unique[len(unique)-1] {
+			unique = append(unique, day)
+		}
+	}
+	return unique
+}
+
+// mondayOffset is the number of days from Monday to day
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String formats the rule in canonical RRULE form without the "RRULE:" prefix
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.ByMonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// each calls yield for every occurrence from start in order until yield returns false or the rule ends.
+// start is a calendar date (see DateOf).
+func (r *RecurrenceRule) each(start time.Time, yield func(date time.Time) bool) {
+	count := 0
+	emit := func(date time.Time) bool {
+		if date.Before(start) {
+			return true
+		}
+		if r.Until != nil && date.After(*r.Until) {
+			return false
+		}
+		count++
+		if !yield(date) {
+			return false
+		}
+		return r.Count == 0 || count < r.Count
+	}
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		n := period * r.Interval
+		switch r.Frequency {
+		case RecurrenceDaily:
+			if !emit(start.AddDate(0, 0, n)) {
+				return
+			}
+		case RecurrenceWeekly:
+			days := r.ByDay
+			if len(days) == 0 {
+				days = []time.Weekday{start.Weekday()}
+			}
+			monday := start.AddDate(0, 0, 7*n-mondayOffset(start.Weekday()))
+			for _, day := range days {
+				if !emit(monday.AddDate(0, 0, mondayOffset(day))) {
+					return
+				}
+			}
+		case RecurrenceMonthly:
+			first := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+			last := first.AddDate(0, 1, -1).Day()
+			day := r.ByMonthDay
+			switch {
+			case day == 0:
+				day = start.Day()
+			case day < 0:
+				day = last
+			}
+			if day <= last && !emit(first.AddDate(0, 0, day-1)) {
+				return
+			}
+		case RecurrenceYearly:
+			date := time.Date(start.Year()+n, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+			if date.Day() == start.Day() && !emit(date) {
+				return
+			}
+		}
+	}
+}
+
+// Next returns the first occurrence strictly after date, or false when the rule has ended
+func (r *RecurrenceRule) Next(start, date time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(start, func(occurrence time.Time) bool {
+		if occurrence.After(date) {
+			next, found = occurrence, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Latest returns the last occurrence on or before date, or false when there is none
+func (r *RecurrenceRule) Latest(start, date time.Time) (time.Time, bool) {
+	var latest time.Time
+	found := false
+	r.each(start, func(occurrence time.Time) bool {
+		if occurrence.After(date) {
+			return false
+		}
+		latest, found = occurrence, true
+		return true
+	})
+	return latest, found
+}
+
+// First returns the series' first occurrence, or false when the rule produces none
+func (r *RecurrenceRule) First(start time.Time) (time.Time, bool) {
+	return r.Next(start, start.AddDate(0, 0, -1))
+}
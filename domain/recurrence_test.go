@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	d, err := time.Parse(DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// occurrences returns up to limit occurrences of rule from start using First and Next
+func occurrences(t *testing.T, rule string, start string, limit int) []string {
+	t.Helper()
+	r, err := ParseRecurrenceRule(rule)
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule(%q) error = %v", rule, err)
+	}
+	var dates []string
+	next, ok := r.First(mustDate(start))
+	for ok && len(dates) < limit {
+		dates = append(dates, next.Format(DateLayout))
+		next, ok = r.Next(mustDate(start), next)
+	}
+	return dates
+}
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rule  string
+		start string
+		want  []string
+		ends  bool
+	}{
+		{"daily every other day across a month end", "FREQ=DAILY;INTERVAL=2", "2025-01-30",
+			[]string{"2025-01-30", "2025-02-01", "2025-02-03"}, false},
+		{"weekly on the start weekday", "FREQ=WEEKLY;INTERVAL=2", "2025-01-03",
+			[]string{"2025-01-03", "2025-01-17", "2025-01-31"}, false},
+		{"weekly on several weekdays", "FREQ=WEEKLY;BYDAY=WE,MO", "2025-01-01",
+			[]string{"2025-01-01", "2025-01-06", "2025-01-08", "2025-01-13"}, false},
+		{"weekly skips weekdays before the start", "FREQ=WEEKLY;BYDAY=MO,FR", "2025-01-01",
+			[]string{"2025-01-03", "2025-01-06", "2025-01-10"}, false},
+		{"monthly on the 31st skips shorter months", "FREQ=MONTHLY", "2025-01-31",
+			[]string{"2025-01-31", "2025-03-31", "2025-05-31", "2025-07-31", "2025-08-31"}, false},
+		{"monthly on the last day", "FREQ=MONTHLY;BYMONTHDAY=-1", "2024-01-15",
+			[]string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"}, false},
+		{"monthly on a day before the start", "FREQ=MONTHLY;BYMONTHDAY=1", "2025-01-15",
+			[]string{"2025-02-01", "2025-03-01", "2025-04-01"}, false},
+		{"monthly across a year end", "FREQ=MONTHLY;INTERVAL=5", "2025-10-10",
+			[]string{"2025-10-10", "2026-03-10", "2026-08-10"}, false},
+		{"yearly", "FREQ=YEARLY", "2025-06-01",
+			[]string{"2025-06-01", "2026-06-01", "2027-06-01"}, false},
+		{"yearly on a leap day skips other years", "FREQ=YEARLY", "2024-02-29",
+			[]string{"2024-02-29", "2028-02-29", "2032-02-29"}, false},
+		{"count ends the rule", "FREQ=DAILY;COUNT=3", "2025-01-01",
+			[]string{"2025-01-01", "2025-01-02", "2025-01-03"}, true},
+		{"count only counts occurrences from the start", "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=2", "2025-01-15",
+			[]string{"2025-02-01", "2025-03-01"}, true},
+		{"until is inclusive", "FREQ=WEEKLY;UNTIL=20250115", "2025-01-01",
+			[]string{"2025-01-01", "2025-01-08", "2025-01-15"}, true},
+		{"until with a time", "FREQ=DAILY;UNTIL=20250102T235959Z", "2025-01-01",
+			[]string{"2025-01-01", "2025-01-02"}, true},
+		{"until before the start", "FREQ=DAILY;UNTIL=20241231", "2025-01-01", nil, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 終わりのある規則は、want の後に続きが無いことも確かめる
+			limit := len(tt.want)
+			if tt.ends {
+				limit++
+			}
+			got := occurrences(t, tt.rule, tt.start, limit)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("occurrences = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleLatest(t *testing.T) {
+	r, err := ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := mustDate("2024-01-01")
+
+	for _, tt := range []struct {
+		date string
+		want string
+		ok   bool
+	}{
+		{"2024-01-30", "", false},
+		{"2024-01-31", "2024-01-31", true},
+		{"2024-03-15", "2024-02-29", true},
+	} {
+		got, ok := r.Latest(start, mustDate(tt.date))
+		if ok != tt.ok || (ok && got.Format(DateLayout) != tt.want) {
+			t.Errorf("Latest(%s) = %s, %v, want %s, %v", tt.date, got.Format(DateLayout), ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseRecurrenceRuleRejectsInvalidRules(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=1000",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101",
+		"FREQ=DAILY;UNTIL=2025",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=-2",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;WKST=MO",
+	} {
+		if _, err := ParseRecurrenceRule(rule); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Errorf("ParseRecurrenceRule(%q) error = %v, want %v", rule, err, ErrInvalidRecurrence)
+		}
+	}
+}
+
+func TestRecurrenceRuleString(t *testing.T) {
+	r, err := ParseRecurrenceRule("RRULE:freq=weekly;interval=2;byday=we,mo,mo;count=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.String(), "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=MO,WE"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidReminder  = errors.New("invalid reminder offset")
+	ErrTooManyReminders = errors.New("too many reminders")
+)
+
+// MaxReminderOffsetDays is the earliest a reminder can fire before the target date
+const MaxReminderOffsetDays = 365
+
+// ReminderOffset is how many days before a wish's target date a reminder fires.
+// It is written as "<n>d" or "<n>w", e.g. "1d", "1w"; "0d" fires on the day itself.
+type ReminderOffset int
+
+// ParseReminderOffset parses an offset such as "1d" or "2w"
+func ParseReminderOffset(s string) (ReminderOffset, error) {
+	if len(s) < 2 {
+		return 0, ErrInvalidReminder
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, ErrInvalidReminder
+	}
+	switch s[len(s)-1] {
+	case 'd':
+	case 'w':
+		n *= 7
+	default:
+		return 0, ErrInvalidReminder
+	}
+	if n > MaxReminderOffsetDays {
+		return 0, ErrInvalidReminder
+	}
+	return ReminderOffset(n), nil
+}
+
+// Days returns the offset in days
+func (o ReminderOffset) Days() int {
+	return int(o)
+}
+
+// String formats the offset in weeks when it is a whole number of weeks, otherwise in days
+func (o ReminderOffset) String() string {
+	if o > 0 && o%7 == 0 {
+		return strconv.Itoa(int(o)/7) + "w"
+	}
+	return strconv.Itoa(int(o)) + "d"
+}
+
+// FireDate returns the calendar date on which the reminder is due for targetDate
+func (o ReminderOffset) FireDate(targetDate time.Time) time.Time {
+	return targetDate.AddDate(0, 0, -int(o))
+}
+
+// WishReminder is one member's reminder on a wish
+type WishReminder struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	UserID         uuid.UUID
+	Offset         ReminderOffset
+	// SentFor is the target date the reminder last fired for; moving the target date re-arms it
+	SentFor   *time.Time
+	CreatedAt time.Time
+	// DueFor is the target date FindDue matched the reminder against; nil elsewhere
+	DueFor *time.Time
+}
+
+// ReminderNotification is a due reminder handed to a Notifier
+type ReminderNotification struct {
+	UserID     uuid.UUID
+	Wish       *Wish
+	Offset     ReminderOffset
+	TargetDate time.Time
+}
+
+// Notifier delivers reminders to members
+type Notifier interface {
+	NotifyReminder(ctx context.Context, notification *ReminderNotification) error
+}
+
+// ReminderRepository defines the interface for wish reminder data operations
+type ReminderRepository interface {
+	FindByWishAndUser(ctx context.Context, wishID, userID uuid.UUID) ([]*WishReminder, error)
+	// ReplaceForUser sets the member's reminders on a wish to exactly offsets.
+	// Offsets that already exist keep their sent state.
+	ReplaceForUser(ctx context.Context, organizationID, wishID, userID uuid.UUID, offsets []ReminderOffset) ([]*WishReminder, error)
+	// FindDue returns reminders across all organizations whose fire date has arrived in the org's timezone
+	// and which have not fired for the wish's current target date. Only open, live wishes whose
+	// target date is today or later are considered, and only for users still in the wish's organization.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*WishReminder, error)
+	// MarkSent claims the reminder for targetDate. It returns false if it was already claimed.
+	MarkSent(ctx context.Context, id uuid.UUID, targetDate time.Time) (bool, error)
+	// UnmarkSent releases a claim made by MarkSent so that the reminder is retried
+	UnmarkSent(ctx context.Context, id uuid.UUID, targetDate time.Time) error
+}
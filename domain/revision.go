@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+)
+
+// WishSnapshot is the state of a wish as recorded by a revision: its editable fields plus
+// its status, list, rank and whether it is in the trash. Revisions recorded before the latter
+// were added decode them as zero values.
+type WishSnapshot struct {
+	Title      string      `json:"title"`
+	Note       string      `json:"note"`
+	OrderNo    int         `json:"order_no"`
+	Price      *Money      `json:"price"`
+	TargetDate *string     `json:"target_date"` // DateLayout
+	AssigneeID *uuid.UUID  `json:"assignee_id"`
+	TagIDs     []uuid.UUID `json:"tag_ids"` // sorted
+	Place      *Place      `json:"place"`
+	Status     WishStatus  `json:"status"`
+	ListID     uuid.UUID   `json:"list_id"`
+	Rank       string      `json:"rank"`
+	Deleted    bool        `json:"deleted"`
+}
+
+// NewWishSnapshot captures the state of wish; wish.Tags must be loaded
+func NewWishSnapshot(wish *Wish) *WishSnapshot {
+	snapshot := &WishSnapshot{
+		Title:      wish.Title,
+		Note:       wish.Note,
+		OrderNo:    wish.OrderNo,
+		Price:      wish.Price,
+		AssigneeID: wish.AssigneeID,
+		TagIDs:     make([]uuid.UUID, len(wish.Tags)),
+		Place:      wish.Place,
+		Status:     wish.Status,
+		ListID:     wish.ListID,
+		Rank:       wish.Rank,
+		Deleted:    wish.DeletedAt != nil,
+	}
+	if wish.TargetDate != nil {
+		targetDate := wish.TargetDate.Format(DateLayout)
+		snapshot.TargetDate = &targetDate
+	}
+	for i, tag := range wish.Tags {
+		snapshot.TagIDs[i] = tag.ID
+	}
+	sort.Slice(snapshot.TagIDs, func(i, j int) bool { return snapshot.TagIDs[i].String() < snapshot.TagIDs[j].String() })
+	return snapshot
+}
+
+// fields lists the snapshot's fields in display order
+func (s *WishSnapshot) fields() []snapshotField {
+	return []snapshotField{
+		{"title", s.Title},
+		{"note", s.Note},
+		{"order_no", s.OrderNo},
+		{"price", s.Price},
+		{"target_date", s.TargetDate},
+		{"assignee_id", s.AssigneeID},
+		{"tag_ids", s.TagIDs},
+		{"place", s.Place},
+		{"status", s.Status},
+		{"list_id", s.ListID},
+		{"rank", s.Rank},
+		{"deleted", s.Deleted},
+	}
+}
+
+type snapshotField struct {
+	name  string
+	value interface{}
+}
+
+// FieldChange is one field that differs between two snapshots, with JSON-encoded values
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// DiffSnapshots returns the fields that differ from one snapshot to the next.
+// A nil from is treated as "nothing before", so every field is reported with a null old value.
+func DiffSnapshots(from, to *WishSnapshot) []FieldChange {
+	toFields := to.fields()
+	var fromFields []snapshotField
+	if from != nil {
+		fromFields = from.fields()
+	}
+
+	changes := make([]FieldChange, 0, len(toFields))
+	for i, field := range toFields {
+		newValue, _ := json.Marshal(field.value)
+		oldValue := json.RawMessage("null")
+		if from != nil {
+			oldValue, _ = json.Marshal(fromFields[i].value)
+		}
+		if from != nil && bytes.Equal(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field.name, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+// WishRevision is one recorded change of a wish. Numbers start at 1 per wish.
+type WishRevision struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	WishID         uuid.UUID
+	Number         int
+	// ActorID is nil for the baseline recorded for wishes that predate revision history
+	ActorID *uuid.UUID
+	Actor   *User
+	// Snapshot is the state after the change
+	Snapshot *WishSnapshot
+	// Changes is the difference from the previous revision
+	Changes []FieldChange
+	// RevertedFrom is the revision whose state this revision restored
+	RevertedFrom *int
+	CreatedAt    time.Time
+}
+
+// WishRevisionPage is one page of a wish's history, newest first.
+// NextBefore is passed back as before to fetch older revisions; 0 means there are none.
+type WishRevisionPage struct {
+	Revisions  []*WishRevision
+	NextBefore int
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSeriesNotFound = errors.New("series not found")
+	// ErrInvalidSeriesTransition is returned when pausing, resuming or stopping a series in the wrong state
+	ErrInvalidSeriesTransition = errors.New("invalid series transition")
+)
+
+// SeriesStatus is the lifecycle state of a recurring wish series
+type SeriesStatus string
+
+const (
+	// SeriesStatusActive series create instances as their periods start
+	SeriesStatusActive SeriesStatus = "active"
+	// SeriesStatusPaused series create nothing until resumed
+	SeriesStatusPaused SeriesStatus = "paused"
+	// SeriesStatusStopped series were ended by a member and create nothing more
+	SeriesStatusStopped SeriesStatus = "stopped"
+	// SeriesStatusCompleted series have no occurrences left (COUNT or UNTIL reached)
+	SeriesStatusCompleted SeriesStatus = "completed"
+)
+
+// WishSeries is the template of a recurring wish. Each occurrence of Rule becomes a wish instance
+// whose target date is the occurrence date and whose SeriesID points back here.
+// Instances are ordinary wishes: editing, pausing or stopping the series never changes them.
+type WishSeries struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Title          string
+	Note           string
+	Price          *Money
+	TagIDs         []uuid.UUID
+	AssigneeID     *uuid.UUID
+	// ListID is the list instances are created in; nil (or an archived list) means the default list
+	ListID    *uuid.UUID
+	Rule      *RecurrenceRule
+	StartDate time.Time
+	Status    SeriesStatus
+	// NextDate is the next occurrence without an instance; nil once the rule has ended
+	NextDate *time.Time
+	// LastDate is the occurrence of the most recent instance
+	LastDate      *time.Time
+	InstanceCount int
+	CreatedBy     uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// Today is the current date in the organization's timezone as of FindDue; nil elsewhere
+	Today *time.Time
+}
+
+// SeriesAdvance moves a series from one pending occurrence to the next after an instance is created
+type SeriesAdvance struct {
+	// From is the NextDate the advance was computed against; the advance is skipped if it changed
+	From time.Time
+	// Created is the occurrence the new instance was created for
+	Created time.Time
+	// Next is the following occurrence, or nil when the rule has ended
+	Next *time.Time
+}
+
+// WishSeriesRepository defines the interface for recurring wish series data operations
+type WishSeriesRepository interface {
+	Create(ctx context.Context, series *WishSeries) (*WishSeries, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*WishSeries, error)
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*WishSeries, error)
+	// UpdateTemplate updates the fields copied into future instances
+	UpdateTemplate(ctx context.Context, series *WishSeries) (*WishSeries, error)
+	// UpdateStatus changes the status only if it is still from, setting NextDate at the same time
+	UpdateStatus(ctx context.Context, series *WishSeries, from SeriesStatus) (*WishSeries, error)
+	// FindDue returns active series, across all organizations, whose next occurrence has started
+	// in the organization's timezone or whose latest instance has been fulfilled
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*WishSeries, error)
+	// Advance records an instance created for advance.Created. It returns false if the series is no
+	// longer active or its NextDate is no longer advance.From, i.e. another worker got there first.
+	Advance(ctx context.Context, id uuid.UUID, advance SeriesAdvance) (bool, error)
+	// Transaction runs fn with repositories that share one database transaction
+	Transaction(ctx context.Context, fn func(series WishSeriesRepository, wishes WishRepository) error) error
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrShareNotFound = errors.New("share link not found")
+	ErrInvalidShare  = errors.New("invalid share link")
+	// ErrShareExpired is returned for links that were revoked or are past their expiry
+	ErrShareExpired = errors.New("share link is no longer available")
+	// ErrSharePasswordRequired is returned when a password-protected link is opened without the right password
+	ErrSharePasswordRequired = errors.New("share link password is missing or wrong")
+)
+
+// ShareLink is a public read-only link to an organization's wishes for people outside it.
+// Only a hash of the token is stored; the token itself is shown once when the link is created.
+type ShareLink struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	// ListID limits the link to one list; nil shares the wishes of every list
+	ListID *uuid.UUID
+	// TokenHash is the hex SHA-256 of the token; TokenPrefix is its first characters, for telling links apart
+	TokenHash   string
+	TokenPrefix string
+	// PasswordHash is empty for links without a password
+	PasswordHash string
+	ExpiresAt    *time.Time
+	RevokedAt    *time.Time
+	ViewCount    int64
+	LastViewedAt *time.Time
+	CreatedBy    uuid.UUID
+	CreatedAt    time.Time
+}
+
+// HasPassword reports whether the link asks for a password
+func (l *ShareLink) HasPassword() bool {
+	return l.PasswordHash != ""
+}
+
+// Active reports whether the link can still be opened at now
+func (l *ShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && (l.ExpiresAt == nil || now.Before(*l.ExpiresAt))
+}
+
+// SharedWishes is the redacted view served to holders of a share link
+type SharedWishes struct {
+	OrganizationName string
+	// List is set when the link is limited to one list
+	List   *List
+	Wishes []*Wish
+}
+
+// ShareLinkRepository defines the interface for share link data operations.
+// Methods taking organizationID are scoped like WishRepository.
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *ShareLink) (*ShareLink, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*ShareLink, error)
+	// FindByOrganizationID returns the links of an organization, newest first
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*ShareLink, error)
+	// FindByTokenHash looks a link up across organizations for the public route
+	FindByTokenHash(ctx context.Context, tokenHash string) (*ShareLink, error)
+	// Revoke marks the link revoked; revoking an already revoked link keeps the first time
+	Revoke(ctx context.Context, organizationID, id uuid.UUID, at time.Time) (*ShareLink, error)
+	// RecordView increments the view count and sets the last viewed time
+	RecordView(ctx context.Context, id uuid.UUID, at time.Time) error
+}
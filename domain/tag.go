@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagNameConflict = errors.New("tag name already exists")
+	ErrInvalidTag      = errors.New("invalid tag")
+)
+
+// Tag is an organization-scoped label attached to wishes
+type Tag struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Color          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TagRepository defines the interface for tag data operations.
+// Every method is scoped by organizationID like WishRepository.
+type TagRepository interface {
+	Create(ctx context.Context, tag *Tag) (*Tag, error)
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*Tag, error)
+	FindByIDs(ctx context.Context, organizationID uuid.UUID, ids []uuid.UUID) ([]*Tag, error)
+	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*Tag, error)
+	Update(ctx context.Context, tag *Tag) (*Tag, error)
+	Delete(ctx context.Context, organizationID, id uuid.UUID) error
+	// Merge moves every wish of sourceID onto targetID and deletes sourceID
+	Merge(ctx context.Context, organizationID, sourceID, targetID uuid.UUID) error
+	// Transaction runs fn with repositories that share one database transaction
+	Transaction(ctx context.Context, fn func(tags TagRepository, wishes WishRepository) error) error
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// TrashedWish is a soft-deleted wish together with when it will be purged
+type TrashedWish struct {
+	Wish *Wish
+	// PurgeAt is nil when the organization keeps trashed wishes forever
+	PurgeAt *time.Time
+}
+
+// TrashPurgeAt returns when a wish deleted at deletedAt is purged under the given retention
+func TrashPurgeAt(deletedAt time.Time, retentionDays int) *time.Time {
+	if retentionDays <= 0 {
+		return nil
+	}
+	purgeAt := deletedAt.AddDate(0, 0, retentionDays)
+	return &purgeAt
+}
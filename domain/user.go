@@ -23,6 +23,7 @@ type UserRepository interface {
 	UpsertUser(ctx context.Context, user *User) (*User, error)
 	GetUserBySubID(ctx context.Context, subID string) (*User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	SoftDeleteBySubID(ctx context.Context, subID string) error
 }
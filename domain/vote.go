@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidVote = errors.New("invalid vote")
+)
+
+// VoteValue is a member's vote on a wish: +1 (up) or -1 (down)
+type VoteValue int
+
+const (
+	VoteNone VoteValue = 0
+	VoteUp   VoteValue = 1
+	VoteDown VoteValue = -1
+)
+
+// Valid reports whether v can be cast; VoteNone is not a vote
+func (v VoteValue) Valid() bool {
+	return v == VoteUp || v == VoteDown
+}
+
+// VoteRepository defines the interface for wish vote data operations.
+// A member has at most one vote per wish.
+type VoteRepository interface {
+	// SetVote casts, changes or (with VoteNone) withdraws userID's vote and
+	// returns the wish's new score. wishes.score is kept in sync in the same transaction.
+	SetVote(ctx context.Context, wishID, userID uuid.UUID, value VoteValue) (int, error)
+	// FindByUser returns userID's votes on the given wishes, keyed by wish ID
+	FindByUser(ctx context.Context, userID uuid.UUID, wishIDs []uuid.UUID) (map[uuid.UUID]VoteValue, error)
+}
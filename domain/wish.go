@@ -2,31 +2,170 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrWishNotFound   = errors.New("wish not found")
+	ErrInvalidReorder = errors.New("invalid reorder request")
+	// ErrInvalidAssignee is returned when the assignee is not a member of the wish's organization
+	ErrInvalidAssignee   = errors.New("assignee is not a member of the organization")
+	ErrWishTitleRequired = errors.New("title is required")
+	// ErrWishVersionMismatch is returned by conditional writes when the wish was changed since the expected version
+	ErrWishVersionMismatch = errors.New("wish has been modified")
+	ErrWishAlreadyDeleted  = errors.New("wish is already deleted")
+	ErrWishNotDeleted      = errors.New("wish is not deleted")
+	// ErrInvalidBulkOperation is returned for an empty or oversized batch or an unknown operation
+	ErrInvalidBulkOperation = errors.New("invalid bulk operation")
+	// ErrBulkRolledBack marks the items of an atomic batch that were undone because another item failed
+	ErrBulkRolledBack = errors.New("rolled back because another operation failed")
+	// ErrInvalidTransfer is returned for an unknown transfer kind or a move into the wish's own organization
+	ErrInvalidTransfer = errors.New("invalid wish transfer")
+)
+
 // Wish represents a wish domain model
 type Wish struct {
 	ID             uuid.UUID
 	OrganizationID uuid.UUID
+	ListID         uuid.UUID // the list (board) the wish is on; Rank orders it within that list
 	Title          string
 	Note           string
 	OrderNo        int
+	Rank           string
+	Status         WishStatus
+	Price          *Money     // optional budget
+	TargetDate     *time.Time // optional calendar date (see DateOf), evaluated in the org's timezone
+	AssigneeID     *uuid.UUID
+	Version        int        // bumped by edits, status changes, soft delete and restore (not by rank moves or votes)
+	SeriesID       *uuid.UUID // the recurring series this wish is an instance of
+	Place          *Place     // optional place, e.g. for restaurant and travel wishes
+	FulfilledAt    *time.Time
+	FulfilledBy    *uuid.UUID
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	DeletedAt      *time.Time
+	DeletedBy      *uuid.UUID
+	Tags           []*Tag
+	// CommentCount counts comments including replies
+	CommentCount int
+	// Score is the sum of member votes (upvotes minus downvotes)
+	Score int
+	// MyVote is the viewer's own vote; only set where a viewer is known
+	MyVote VoteValue
+	Links  []*WishLink
+	// Checklist counts the wish's checklist items
+	Checklist ChecklistProgress
+	// Origin is set on wishes copied or moved here from another organization
+	Origin *WishOrigin
+}
+
+// TransferKind says whether a wish was copied or moved between organizations
+type TransferKind string
+
+const (
+	TransferCopy TransferKind = "copy"
+	TransferMove TransferKind = "move"
+)
+
+// WishOrigin links a copied or moved wish back to its source.
+// WishID becomes nil once the source wish is purged; OrganizationID once the source organization is deleted.
+type WishOrigin struct {
+	WishID         *uuid.UUID
+	OrganizationID *uuid.UUID
+	Kind           TransferKind
+}
+
+// WishTransfer copies or moves a wish into another organization the actor belongs to
+type WishTransfer struct {
+	Kind TransferKind
+	// OrganizationExternalID is the Clerk ID of the target organization
+	OrganizationExternalID string
+	// ListID is the target list; nil uses the target organization's default list
+	ListID *uuid.UUID
+}
+
+// WishMove places one wish directly after AfterID or directly before BeforeID.
+// With neither set the wish moves to the top of its list.
+// ListID moves the wish into another list; the anchors must then be in that list.
+type WishMove struct {
+	ID       uuid.UUID
+	ListID   *uuid.UUID
+	AfterID  *uuid.UUID
+	BeforeID *uuid.UUID
+}
+
+// WishReorder is either a full ordering of one list or a list of moves, applied atomically.
+// Order must name every live wish of the list exactly once.
+type WishReorder struct {
+	Order []uuid.UUID
+	Moves []WishMove
 }
 
-// WishRepository defines the interface for wish data operations
+// WishRepository defines the interface for wish data operations.
+// Lookups and mutations are scoped by organizationID; wishes of other organizations are treated as not found.
+// Writes taking expectedVersion are compare-and-set when it is positive: they apply only if the stored
+// version still equals it and return ErrWishVersionMismatch otherwise. 0 writes unconditionally.
 type WishRepository interface {
 	Create(ctx context.Context, wish *Wish) (*Wish, error)
-	FindByID(ctx context.Context, id uuid.UUID) (*Wish, error)
-	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*Wish, error)
-	Update(ctx context.Context, wish *Wish) (*Wish, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	SoftDelete(ctx context.Context, id uuid.UUID) error
-	Restore(ctx context.Context, id uuid.UUID) error
-	UpdateOrder(ctx context.Context, id uuid.UUID, orderNo int) error
+	FindByID(ctx context.Context, organizationID, id uuid.UUID) (*Wish, error)
+	List(ctx context.Context, organizationID uuid.UUID, query WishListQuery) ([]*Wish, error)
+	Search(ctx context.Context, organizationID uuid.UUID, q string, limit int) ([]*WishSearchResult, error)
+	// FindNearby returns the live wishes with a location within the query radius, nearest first
+	FindNearby(ctx context.Context, organizationID uuid.UUID, query NearbyQuery) ([]*NearbyWish, error)
+	Update(ctx context.Context, wish *Wish, expectedVersion int) (*Wish, error)
+	Delete(ctx context.Context, organizationID, id uuid.UUID, expectedVersion int) error
+	SoftDelete(ctx context.Context, organizationID, id, deletedBy uuid.UUID, expectedVersion int) error
+	Restore(ctx context.Context, organizationID, id uuid.UUID) error
+	// FindTrash returns soft-deleted wishes, most recently deleted first
+	FindTrash(ctx context.Context, organizationID uuid.UUID) ([]*Wish, error)
+	// PurgeDeleted permanently removes wishes soft-deleted before the given time and returns them
+	PurgeDeleted(ctx context.Context, organizationID uuid.UUID, before time.Time) ([]*Wish, error)
+	// FindByList returns the live wishes of a list in rank order
+	FindByList(ctx context.Context, organizationID, listID uuid.UUID) ([]*Wish, error)
+	// UpdateRank moves a wish to rank. Moves do not change the version, but with expectedVersion > 0
+	// the write only happens while the wish is still at that version (ErrWishVersionMismatch otherwise).
+	UpdateRank(ctx context.Context, organizationID, id uuid.UUID, rank string, expectedVersion int) error
+	// UpdateList moves a wish into listID at rank, checking expectedVersion like UpdateRank
+	UpdateList(ctx context.Context, organizationID, id, listID uuid.UUID, rank string, expectedVersion int) error
+	// AdjacentRank returns the rank of the live wish of listID right after (or before) rank, skipping excludeID.
+	// An empty rank with after=true yields the first rank of the list. "" means there is none.
+	AdjacentRank(ctx context.Context, organizationID, listID uuid.UUID, rank string, after bool, excludeID uuid.UUID) (string, error)
+	// LockRanks serializes rank changes of one organization until the transaction ends
+	LockRanks(ctx context.Context, organizationID uuid.UUID) error
+	// ReplaceTags sets the tags of a wish to exactly tagIDs
+	ReplaceTags(ctx context.Context, wishID uuid.UUID, tagIDs []uuid.UUID) error
+	// FindTagsByWishIDs loads the tags of many wishes in one query, keyed by wish ID
+	FindTagsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*Tag, error)
+	// FindBudgeted returns live wishes that have a price or contributions, in board order
+	FindBudgeted(ctx context.Context, organizationID uuid.UUID) ([]*Wish, error)
+	// ListConsensus returns live wishes upvoted by every current member of the organization
+	ListConsensus(ctx context.Context, organizationID uuid.UUID, limit int) ([]*Wish, error)
+	// FindLinksByWishIDs loads the links of many wishes in one query, keyed by wish ID
+	FindLinksByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*WishLink, error)
+	// CountCommentsByWishIDs counts the comments of many wishes in one query, keyed by wish ID
+	CountCommentsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	// Transaction runs fn with a repository bound to a single database transaction
+	Transaction(ctx context.Context, fn func(repo WishRepository) error) error
+	// UpdateStatus applies wish.Status/FulfilledAt/FulfilledBy only if the stored status is still from
+	UpdateStatus(ctx context.Context, wish *Wish, from WishStatus) (*Wish, error)
+	// FindByIDForUpdate is FindByID that also locks the wish row until the transaction ends
+	FindByIDForUpdate(ctx context.Context, organizationID, id uuid.UUID) (*Wish, error)
+	// CreateRevision appends a revision, numbering it after the wish's latest one.
+	// Callers hold the wish row lock (FindByIDForUpdate) so numbers do not collide.
+	CreateRevision(ctx context.Context, revision *WishRevision) (*WishRevision, error)
+	// FindRevisions returns revisions numbered below before (0 for the latest), newest first
+	FindRevisions(ctx context.Context, wishID uuid.UUID, before, limit int) ([]*WishRevision, error)
+	FindRevision(ctx context.Context, wishID uuid.UUID, number int) (*WishRevision, error)
+	// FindChecklistItems returns the checklist of a wish in rank order
+	FindChecklistItems(ctx context.Context, wishID uuid.UUID) ([]*ChecklistItem, error)
+	FindChecklistItem(ctx context.Context, wishID, id uuid.UUID) (*ChecklistItem, error)
+	CreateChecklistItem(ctx context.Context, item *ChecklistItem) (*ChecklistItem, error)
+	// UpdateChecklistItem saves the title, rank, assignee and completion of an item
+	UpdateChecklistItem(ctx context.Context, item *ChecklistItem) (*ChecklistItem, error)
+	DeleteChecklistItem(ctx context.Context, wishID, id uuid.UUID) error
+	// CountChecklistByWishIDs counts checked and total checklist items of many wishes in one query, keyed by wish ID
+	CountChecklistByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID]ChecklistProgress, error)
 }
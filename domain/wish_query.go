@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrInvalidWishQuery = errors.New("invalid wish query")
+)
+
+// WishSortKey is a selectable ordering for wish lists
+type WishSortKey string
+
+const (
+	// WishSortPriority is the manual board order: rank, id (backed by idx_wishes_list_rank)
+	WishSortPriority  WishSortKey = "priority"
+	WishSortCreatedAt WishSortKey = "created_at"
+	WishSortUpdatedAt WishSortKey = "updated_at"
+	WishSortTitle     WishSortKey = "title"
+	// WishSortScore orders by vote score: score, id
+	WishSortScore WishSortKey = "score"
+	// WishSortTargetDate orders by target date with undated wishes last (ascending): target_date, id
+	WishSortTargetDate WishSortKey = "target_date"
+)
+
+// WishView is a date-based preset of the wish list, evaluated in the org's timezone
+type WishView string
+
+const (
+	// WishViewUpcoming lists open wishes due today or later
+	WishViewUpcoming WishView = "upcoming"
+	// WishViewOverdue lists open wishes whose target date has passed
+	WishViewOverdue WishView = "overdue"
+)
+
+// Valid reports whether the view is supported
+func (v WishView) Valid() bool {
+	return v == WishViewUpcoming || v == WishViewOverdue
+}
+
+// SortOrder is the direction of a wish list ordering
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// Valid reports whether the sort key is supported
+func (k WishSortKey) Valid() bool {
+	switch k {
+	case WishSortPriority, WishSortCreatedAt, WishSortUpdatedAt, WishSortTitle, WishSortScore, WishSortTargetDate:
+		return true
+	}
+	return false
+}
+
+// DefaultOrder returns the direction used when the client does not specify one
+func (k WishSortKey) DefaultOrder() SortOrder {
+	if k == WishSortPriority || k == WishSortTitle || k == WishSortTargetDate {
+		return SortAsc
+	}
+	return SortDesc
+}
+
+// WishListQuery holds the filters, ordering and page position for listing wishes
+type WishListQuery struct {
+	Limit          int
+	After          *WishCursor
+	Sort           WishSortKey
+	Order          SortOrder
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	UpdatedFrom    *time.Time
+	UpdatedTo      *time.Time
+	TitlePrefix    string
+	IncludeDeleted bool
+	Statuses       []WishStatus
+	TagIDs         []uuid.UUID
+	// TagMatchAll requires every tag in TagIDs (AND); otherwise any of them matches (OR)
+	TagMatchAll bool
+	// ViewerID is the caller; when set, each wish carries the viewer's own vote
+	ViewerID *uuid.UUID
+	// View narrows the list by target date relative to the org's today; the usecase
+	// resolves it into TargetDateFrom / TargetDateBefore
+	View WishView
+	// TargetDateFrom / TargetDateBefore bound target_date as [from, before); undated wishes never match
+	TargetDateFrom   *time.Time
+	TargetDateBefore *time.Time
+	AssigneeID       *uuid.UUID
+	// SeriesID limits the list to instances of one recurring series
+	SeriesID *uuid.UUID
+	// ListID limits the result to one list (board). The usecase fills in the default list
+	// when it is nil, unless AllLists asks for the wishes of every list.
+	ListID   *uuid.UUID
+	AllLists bool
+}
+
+// WishPage is one page of a keyset-paginated wish list
+type WishPage struct {
+	Wishes     []*Wish
+	NextCursor string
+}
+
+// WishCursor is the keyset position after the last wish of a page.
+// It carries every sortable key so one shape serves all sort keys.
+type WishCursor struct {
+	Sort       WishSortKey `json:"s"`
+	Order      SortOrder   `json:"o"`
+	Rank       string      `json:"r"`
+	Title      string      `json:"t"`
+	Score      int         `json:"sc"`
+	TargetDate *time.Time  `json:"td,omitempty"` // nil for undated wishes
+	CreatedAt  time.Time   `json:"c"`
+	UpdatedAt  time.Time   `json:"u"`
+	ID         uuid.UUID   `json:"id"`
+}
+
+// NewWishCursor builds the cursor pointing just after the given wish
+func NewWishCursor(sort WishSortKey, order SortOrder, wish *Wish) *WishCursor {
+	return &WishCursor{
+		Sort:       sort,
+		Order:      order,
+		Rank:       wish.Rank,
+		Title:      wish.Title,
+		Score:      wish.Score,
+		TargetDate: wish.TargetDate,
+		CreatedAt:  wish.CreatedAt,
+		UpdatedAt:  wish.UpdatedAt,
+		ID:         wish.ID,
+	}
+}
+
+// Encode returns the opaque string handed to clients as next_cursor
+func (c *WishCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeWishCursor parses a cursor produced by Encode
+func DecodeWishCursor(s string) (*WishCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c WishCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if !c.Sort.Valid() || (c.Order != SortAsc && c.Order != SortDesc) || c.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// WishSearchResult is a wish matched by full-text search, with its rank and highlighted snippets
+type WishSearchResult struct {
+	Wish         *Wish
+	Rank         float64
+	TitleSnippet string
+	NoteSnippet  string
+}
+
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+)
+
+// HighlightSnippet returns an HTML-escaped excerpt of text around the first
+// case-insensitive occurrence of query, with the match wrapped in <mark>.
+// radius is the number of characters kept on each side of the match.
+// It works on runes so Japanese text needs no whitespace tokenization.
+// An empty string is returned when query does not occur in text.
+func HighlightSnippet(text, query string, radius int) string {
+	textRunes := []rune(text)
+	queryRunes := foldRunes([]rune(query))
+	if len(queryRunes) == 0 || len(queryRunes) > len(textRunes) {
+		return ""
+	}
+
+	folded := foldRunes(textRunes)
+	start := -1
+	for i := 0; i+len(queryRunes) <= len(folded); i++ {
+		if runesEqual(folded[i:i+len(queryRunes)], queryRunes) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	end := start + len(queryRunes)
+
+	from := max(0, start-radius)
+	to := min(len(textRunes), end+radius)
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	b.WriteString(html.EscapeString(string(textRunes[from:start])))
+	b.WriteString(highlightOpen)
+	b.WriteString(html.EscapeString(string(textRunes[start:end])))
+	b.WriteString(highlightClose)
+	b.WriteString(html.EscapeString(string(textRunes[end:to])))
+	if to < len(textRunes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// foldRunes lowercases rune by rune so indexes stay aligned with the source text
+func foldRunes(rs []rune) []rune {
+	folded := make([]rune, len(rs))
+	for i, r := range rs {
+		folded[i] = unicode.ToLower(r)
+	}
+	return folded
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
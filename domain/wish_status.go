@@ -0,0 +1,47 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidWishStatus       = errors.New("invalid wish status")
+	ErrInvalidStatusTransition = errors.New("invalid wish status transition")
+)
+
+// WishStatus is the lifecycle state of a wish
+type WishStatus string
+
+const (
+	WishStatusIdea       WishStatus = "idea"
+	WishStatusPlanned    WishStatus = "planned"
+	WishStatusInProgress WishStatus = "in_progress"
+	WishStatusFulfilled  WishStatus = "fulfilled"
+	WishStatusArchived   WishStatus = "archived"
+)
+
+// wishStatusTransitions lists the statuses reachable from each status
+var wishStatusTransitions = map[WishStatus][]WishStatus{
+	WishStatusIdea:       {WishStatusPlanned, WishStatusArchived},
+	WishStatusPlanned:    {WishStatusIdea, WishStatusInProgress, WishStatusFulfilled, WishStatusArchived},
+	WishStatusInProgress: {WishStatusPlanned, WishStatusFulfilled, WishStatusArchived},
+	WishStatusFulfilled:  {WishStatusInProgress, WishStatusArchived},
+	WishStatusArchived:   {WishStatusIdea},
+}
+
+// Valid reports whether the status is a known lifecycle state
+func (s WishStatus) Valid() bool {
+	_, ok := wishStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a wish in status s may move to next
+func (s WishStatus) CanTransitionTo(next WishStatus) bool {
+	for _, to := range wishStatusTransitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenWishStatuses are the statuses of wishes that are still being worked towards
+var OpenWishStatuses = []WishStatus{WishStatusIdea, WishStatusPlanned, WishStatusInProgress}
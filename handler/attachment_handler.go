@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AttachmentHandler struct {
+	attachmentSvc usecase.AttachmentSvc
+	userSvc       usecase.UserUsecase
+}
+
+func NewAttachmentHandler(attachmentSvc usecase.AttachmentSvc, userSvc usecase.UserUsecase) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentSvc: attachmentSvc,
+		userSvc:       userSvc,
+	}
+}
+
+// multipartOverhead - multipartの境界やヘッダー分の余裕
+const multipartOverhead = 64 << 10
+
+type AttachmentResponse struct {
+	ID           string `json:"id"`
+	WishID       string `json:"wish_id"`
+	UploadedBy   string `json:"uploaded_by"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	// URLExpiresAt - url, thumbnail_url の有効期限。過ぎたら GET で取り直す
+	URLExpiresAt string `json:"url_expires_at"`
+	CreatedAt    string `json:"created_at"`
+}
+
+func newAttachmentResponse(attachment *domain.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:           attachment.ID.String(),
+		WishID:       attachment.WishID.String(),
+		UploadedBy:   attachment.UploadedBy.String(),
+		FileName:     attachment.FileName,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		Width:        attachment.Width,
+		Height:       attachment.Height,
+		URL:          attachment.URL,
+		ThumbnailURL: attachment.ThumbnailURL,
+		URLExpiresAt: attachment.URLExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		CreatedAt:    attachment.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// respondAttachmentError - usecaseのエラーをHTTPステータスに変換して返す
+func respondAttachmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAttachmentNotFound), errors.Is(err, domain.ErrWishNotFound),
+		errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrUnsupportedMediaType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAttachmentIDs - パスの :id（Wish）と :attachment_id を解釈する
+func parseAttachmentIDs(c *gin.Context) (wishID, attachmentID uuid.UUID, err error) {
+	wishID, err = uuid.Parse(c.Param("id"))
+	if err != nil {
+		return wishID, attachmentID, errors.New("Invalid wish ID")
+	}
+	attachmentID, err = uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		return wishID, attachmentID, errors.New("Invalid attachment ID")
+	}
+	return wishID, attachmentID, nil
+}
+
+// UploadAttachment - Wishに画像を添付（multipart/form-data の file フィールド）
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	// 上限を大きく超えるリクエストは本文を読み切る前に打ち切る
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, usecase.MaxAttachmentSize+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondAttachmentError(c, domain.ErrAttachmentTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fileHeader.Size > usecase.MaxAttachmentSize {
+		respondAttachmentError(c, domain.ErrAttachmentTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	orgExternalID := c.GetString("org_external_id")
+	attachment, err := h.attachmentSvc.UploadAttachment(c.Request.Context(), orgExternalID, wishID, user.ID, usecase.AttachmentUpload{
+		FileName: fileHeader.Filename,
+		Content:  file,
+	})
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newAttachmentResponse(attachment))
+}
+
+// GetAttachments - Wishの添付一覧を取得（署名付きURL付き）
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	attachments, err := h.attachmentSvc.ListAttachments(c.Request.Context(), orgExternalID, wishID)
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	responses := make([]AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = newAttachmentResponse(attachment)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attachments": responses})
+}
+
+// GetAttachment - 添付を1件取得（期限切れのURLを取り直す用）
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	wishID, attachmentID, err := parseAttachmentIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	attachment, err := h.attachmentSvc.GetAttachment(c.Request.Context(), orgExternalID, wishID, attachmentID)
+	if err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAttachmentResponse(attachment))
+}
+
+// DeleteAttachment - 添付を削除
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	wishID, attachmentID, err := parseAttachmentIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	if err := h.attachmentSvc.DeleteAttachment(c.Request.Context(), orgExternalID, wishID, attachmentID); err != nil {
+		respondAttachmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"taine-api/domain"
+	"taine-api/interface/ical"
+	"taine-api/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	calendarProdID = "-//taine//taine-api//EN"
+	// calendarRefreshInterval - カレンダーアプリに勧める再取得の間隔
+	calendarRefreshInterval = time.Hour
+	// calendarUIDDomain - 予定のUID（"<WishのID>@calendarUIDDomain"）。期日・内容が変わっても同じ値にする
+	calendarUIDDomain = "taine-api"
+)
+
+type CalendarHandler struct {
+	calendarSvc usecase.CalendarSvc
+	userSvc     usecase.UserUsecase
+}
+
+func NewCalendarHandler(calendarSvc usecase.CalendarSvc, userSvc usecase.UserUsecase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarSvc: calendarSvc,
+		userSvc:     userSvc,
+	}
+}
+
+type CalendarFeedResponse struct {
+	TokenPrefix   string  `json:"token_prefix"`
+	CreatedAt     string  `json:"created_at"`
+	RotatedAt     string  `json:"rotated_at"`
+	LastFetchedAt *string `json:"last_fetched_at"`
+}
+
+// RotatedCalendarFeedResponse - 作成・作り直し時だけ購読URLのパスを返す（後から取得する方法は無い）
+type RotatedCalendarFeedResponse struct {
+	CalendarFeedResponse
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+func newCalendarFeedResponse(feed *domain.CalendarFeed) CalendarFeedResponse {
+	response := CalendarFeedResponse{
+		TokenPrefix: feed.TokenPrefix,
+		CreatedAt:   feed.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		RotatedAt:   feed.RotatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if feed.LastFetchedAt != nil {
+		lastFetchedAtStr := feed.LastFetchedAt.Format("2006-01-02T15:04:05Z")
+		response.LastFetchedAt = &lastFetchedAtStr
+	}
+	return response
+}
+
+// respondCalendarError - usecaseのエラーをHTTPステータスに変換して返す
+func respondCalendarError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCalendarFeedNotFound), errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetCalendarFeed - 呼び出し元のカレンダー購読の情報を取得（購読URLは作成・作り直し時にだけ返す）
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	feed, err := h.calendarSvc.GetFeed(c.Request.Context(), orgExternalID, user.ID)
+	if err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newCalendarFeedResponse(feed))
+}
+
+// RotateCalendarFeed - カレンダー購読を作成、または新しいURLに作り直す（古いURLは使えなくなる）
+func (h *CalendarHandler) RotateCalendarFeed(c *gin.Context) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	feed, token, err := h.calendarSvc.RotateFeed(c.Request.Context(), orgExternalID, user.ID)
+	if err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RotatedCalendarFeedResponse{
+		CalendarFeedResponse: newCalendarFeedResponse(feed),
+		Token:                token,
+		Path:                 "/calendar/" + token + ".ics",
+	})
+}
+
+// DeleteCalendarFeed - カレンダー購読を削除（購読URLは使えなくなる）
+func (h *CalendarHandler) DeleteCalendarFeed(c *gin.Context) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	if err := h.calendarSvc.DeleteFeed(c.Request.Context(), orgExternalID, user.ID); err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed deleted successfully"})
+}
+
+// GetCalendar - 期日のあるWishのiCalendarフィード（認証なし。カレンダーアプリはBearerトークンを送れないためURLのトークンで認可する）
+// 各Wishは期日の終日の予定になり、UIDはWishごとに固定、SEQUENCEはWishのバージョン
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	calendar, err := h.calendarSvc.FeedWishes(c.Request.Context(), token)
+	if err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+
+	events := make([]ical.Event, 0, len(calendar.Wishes))
+	for _, wish := range calendar.Wishes {
+		if wish.TargetDate == nil {
+			continue
+		}
+		summary := wish.Title
+		if wish.Status == domain.WishStatusFulfilled {
+			summary = "✓ " + summary
+		}
+		event := ical.Event{
+			UID:          wish.ID.String() + "@" + calendarUIDDomain,
+			Date:         *wish.TargetDate,
+			Summary:      summary,
+			Description:  wish.Note,
+			Categories:   make([]string, len(wish.Tags)),
+			Sequence:     wish.Version,
+			Created:      wish.CreatedAt,
+			LastModified: wish.UpdatedAt,
+		}
+		if wish.Place != nil {
+			var parts []string
+			for _, part := range []string{wish.Place.Name, wish.Place.Address} {
+				if part != "" {
+					parts = append(parts, part)
+				}
+			}
+			event.Location = strings.Join(parts, ", ")
+		}
+		for i, tag := range wish.Tags {
+			event.Categories[i] = tag.Name
+		}
+		events = append(events, event)
+	}
+
+	var body bytes.Buffer
+	if err := ical.Write(&body, ical.Calendar{
+		ProdID:          calendarProdID,
+		Name:            calendar.OrganizationName,
+		RefreshInterval: calendarRefreshInterval,
+		Events:          events,
+	}, time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, ical.ContentType, body.Bytes())
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CommentHandler struct {
+	commentSvc usecase.CommentSvc
+	userSvc    usecase.UserUsecase
+}
+
+func NewCommentHandler(commentSvc usecase.CommentSvc, userSvc usecase.UserUsecase) *CommentHandler {
+	return &CommentHandler{
+		commentSvc: commentSvc,
+		userSvc:    userSvc,
+	}
+}
+
+type CreateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+	// ParentID - 返信先のコメント。トップレベルのコメントのみ指定できる
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type CommentAuthorResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type CommentResponse struct {
+	ID       string  `json:"id"`
+	WishID   string  `json:"wish_id"`
+	ParentID *string `json:"parent_id"`
+	// Author - 退会済みのユーザーの場合はnull
+	Author     *CommentAuthorResponse `json:"author"`
+	Body       string                 `json:"body"`
+	ReplyCount int                    `json:"reply_count"`
+	CreatedAt  string                 `json:"created_at"`
+	UpdatedAt  string                 `json:"updated_at"`
+	EditedAt   *string                `json:"edited_at"`
+}
+
+func newCommentResponse(comment *domain.Comment) CommentResponse {
+	response := CommentResponse{
+		ID:         comment.ID.String(),
+		WishID:     comment.WishID.String(),
+		Body:       comment.Body,
+		ReplyCount: comment.ReplyCount,
+		CreatedAt:  comment.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:  comment.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if comment.ParentID != nil {
+		parentIDStr := comment.ParentID.String()
+		response.ParentID = &parentIDStr
+	}
+	if comment.Author != nil {
+		response.Author = &CommentAuthorResponse{
+			ID:        comment.Author.ID.String(),
+			Name:      comment.Author.Name,
+			AvatarURL: comment.Author.AvatarURL,
+		}
+	}
+	if comment.EditedAt != nil {
+		editedAtStr := comment.EditedAt.Format("2006-01-02T15:04:05Z")
+		response.EditedAt = &editedAtStr
+	}
+	return response
+}
+
+// respondCommentError - usecaseのエラーをHTTPステータスに変換して返す
+func respondCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCommentNotFound), errors.Is(err, domain.ErrWishNotFound),
+		errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidComment), errors.Is(err, domain.ErrReplyDepth),
+		errors.Is(err, domain.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrPermissionDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseCommentIDs - パスの :id（Wish）と :comment_id を解釈する
+func parseCommentIDs(c *gin.Context) (wishID, commentID uuid.UUID, err error) {
+	wishID, err = uuid.Parse(c.Param("id"))
+	if err != nil {
+		return wishID, commentID, errors.New("Invalid wish ID")
+	}
+	commentID, err = uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		return wishID, commentID, errors.New("Invalid comment ID")
+	}
+	return wishID, commentID, nil
+}
+
+// CreateComment - Wishにコメント（またはコメントへの返信）を投稿
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	comment, err := h.commentSvc.CreateComment(c.Request.Context(), orgExternalID, wishID, user.ID, req.ParentID, req.Body)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newCommentResponse(comment))
+}
+
+// GetComments - Wishのコメントを古い順に取得
+// クエリ: limit, cursor, parent_id（指定時はそのコメントへの返信を取得）
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var query domain.CommentListQuery
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid limit: %q", v)})
+			return
+		}
+		query.Limit = limit
+	}
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := domain.DecodeCommentCursor(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		query.After = cursor
+	}
+	if v := c.Query("parent_id"); v != "" {
+		parentID, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid parent_id: %q", v)})
+			return
+		}
+		query.ParentID = &parentID
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	page, err := h.commentSvc.ListComments(c.Request.Context(), orgExternalID, wishID, query)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	responses := make([]CommentResponse, len(page.Comments))
+	for i, comment := range page.Comments {
+		responses[i] = newCommentResponse(comment)
+	}
+
+	var nextCursor *string
+	if page.NextCursor != "" {
+		nextCursor = &page.NextCursor
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comments": responses, "next_cursor": nextCursor})
+}
+
+// UpdateComment - 自分のコメントを編集
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, commentID, err := parseCommentIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	comment, err := h.commentSvc.UpdateComment(c.Request.Context(), orgExternalID, wishID, commentID, user.ID, req.Body)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newCommentResponse(comment))
+}
+
+// DeleteComment - コメントを削除（返信も削除される）
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, commentID, err := parseCommentIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	// role は RequirePermission が設定する
+	err = h.commentSvc.DeleteComment(c.Request.Context(), orgExternalID, wishID, commentID, user.ID, c.GetString("role"))
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ContributionHandler struct {
+	contributionSvc usecase.ContributionSvc
+	userSvc         usecase.UserUsecase
+}
+
+func NewContributionHandler(contributionSvc usecase.ContributionSvc, userSvc usecase.UserUsecase) *ContributionHandler {
+	return &ContributionHandler{
+		contributionSvc: contributionSvc,
+		userSvc:         userSvc,
+	}
+}
+
+// MoneyRequest - 金額は通貨の最小単位（JPYなら円、USDならセント）の整数
+type MoneyRequest struct {
+	Amount   *int64 `json:"amount" binding:"required"`
+	Currency string `json:"currency" binding:"required"`
+}
+
+func (r *MoneyRequest) toDomain() *domain.Money {
+	if r == nil {
+		return nil
+	}
+	return &domain.Money{Amount: *r.Amount, Currency: r.Currency}
+}
+
+type MoneyResponse struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// Exponent - 最小単位の桁数（JPY: 0, USD: 2）。表示時は amount / 10^exponent
+	Exponent int `json:"exponent"`
+}
+
+func newMoneyResponse(money *domain.Money) *MoneyResponse {
+	if money == nil {
+		return nil
+	}
+	return &MoneyResponse{
+		Amount:   money.Amount,
+		Currency: money.Currency,
+		Exponent: domain.CurrencyExponent(money.Currency),
+	}
+}
+
+type CreateContributionRequest struct {
+	MoneyRequest
+	Note string `json:"note"`
+	// ContributedAt - RFC3339。省略時は現在時刻
+	ContributedAt *time.Time `json:"contributed_at"`
+}
+
+type ContributionMemberResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type ContributionResponse struct {
+	ID     string        `json:"id"`
+	WishID string        `json:"wish_id"`
+	Amount MoneyResponse `json:"amount"`
+	Note   string        `json:"note"`
+	// Member - 退会済みのユーザーの場合はnull
+	Member        *ContributionMemberResponse `json:"member"`
+	ContributedAt string                      `json:"contributed_at"`
+	CreatedAt     string                      `json:"created_at"`
+}
+
+func newContributionResponse(contribution *domain.Contribution) ContributionResponse {
+	response := ContributionResponse{
+		ID:            contribution.ID.String(),
+		WishID:        contribution.WishID.String(),
+		Amount:        *newMoneyResponse(&contribution.Amount),
+		Note:          contribution.Note,
+		ContributedAt: contribution.ContributedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		CreatedAt:     contribution.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if contribution.Member != nil {
+		response.Member = &ContributionMemberResponse{
+			ID:        contribution.Member.ID.String(),
+			Name:      contribution.Member.Name,
+			AvatarURL: contribution.Member.AvatarURL,
+		}
+	}
+	return response
+}
+
+// CurrencyProgressResponse - remaining は目標に届いていれば0
+type CurrencyProgressResponse struct {
+	Currency    string `json:"currency"`
+	Exponent    int    `json:"exponent"`
+	Target      int64  `json:"target"`
+	Contributed int64  `json:"contributed"`
+	Remaining   int64  `json:"remaining"`
+	// Percent - 目標額に対する積立額の割合（目標が無い通貨はnull）
+	Percent *float64 `json:"percent"`
+}
+
+type WishProgressResponse struct {
+	WishID string                     `json:"wish_id"`
+	Title  string                     `json:"title"`
+	Price  *MoneyResponse             `json:"price"`
+	Totals []CurrencyProgressResponse `json:"totals"`
+}
+
+func newCurrencyProgressResponses(totals []domain.CurrencyProgress) []CurrencyProgressResponse {
+	responses := make([]CurrencyProgressResponse, len(totals))
+	for i, total := range totals {
+		responses[i] = CurrencyProgressResponse{
+			Currency:    total.Currency,
+			Exponent:    domain.CurrencyExponent(total.Currency),
+			Target:      total.Target,
+			Contributed: total.Contributed,
+			Remaining:   max(total.Target-total.Contributed, 0),
+		}
+		if total.Target > 0 {
+			percent := float64(total.Contributed) / float64(total.Target) * 100
+			responses[i].Percent = &percent
+		}
+	}
+	return responses
+}
+
+func newWishProgressResponse(progress *domain.WishProgress) WishProgressResponse {
+	return WishProgressResponse{
+		WishID: progress.Wish.ID.String(),
+		Title:  progress.Wish.Title,
+		Price:  newMoneyResponse(progress.Wish.Price),
+		Totals: newCurrencyProgressResponses(progress.Totals),
+	}
+}
+
+// respondContributionError - usecaseのエラーをHTTPステータスに変換して返す
+func respondContributionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrContributionNotFound), errors.Is(err, domain.ErrWishNotFound),
+		errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidMoney), errors.Is(err, domain.ErrInvalidContribution):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrPermissionDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateContribution - Wishのための積立を記録（負の金額は取り崩し）
+func (h *ContributionHandler) CreateContribution(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req CreateContributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	contribution, err := h.contributionSvc.AddContribution(c.Request.Context(), orgExternalID, wishID, user.ID, usecase.ContributionInput{
+		Amount:        *req.MoneyRequest.toDomain(),
+		Note:          req.Note,
+		ContributedAt: req.ContributedAt,
+	})
+	if err != nil {
+		respondContributionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newContributionResponse(contribution))
+}
+
+// GetContributions - Wishの積立の記録を新しい順に取得
+func (h *ContributionHandler) GetContributions(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	contributions, err := h.contributionSvc.ListContributions(c.Request.Context(), orgExternalID, wishID)
+	if err != nil {
+		respondContributionError(c, err)
+		return
+	}
+
+	responses := make([]ContributionResponse, len(contributions))
+	for i, contribution := range contributions {
+		responses[i] = newContributionResponse(contribution)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"contributions": responses})
+}
+
+// DeleteContribution - 積立の記録を削除
+func (h *ContributionHandler) DeleteContribution(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+	contributionID, err := uuid.Parse(c.Param("contribution_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contribution ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	// role は RequirePermission が設定する
+	err = h.contributionSvc.DeleteContribution(c.Request.Context(), orgExternalID, wishID, contributionID, user.ID, c.GetString("role"))
+	if err != nil {
+		respondContributionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Contribution deleted successfully"})
+}
+
+// GetWishProgress - Wishの予算に対する積立の進捗（通貨ごと）
+func (h *ContributionHandler) GetWishProgress(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	progress, err := h.contributionSvc.GetWishProgress(c.Request.Context(), orgExternalID, wishID)
+	if err != nil {
+		respondContributionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newWishProgressResponse(progress))
+}
+
+// GetOrganizationProgress - 組織全体の予算と積立の進捗。価格か積立のあるWishのみ対象
+func (h *ContributionHandler) GetOrganizationProgress(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
+	progress, err := h.contributionSvc.GetOrganizationProgress(c.Request.Context(), orgExternalID)
+	if err != nil {
+		respondContributionError(c, err)
+		return
+	}
+
+	wishes := make([]WishProgressResponse, len(progress.Wishes))
+	for i, p := range progress.Wishes {
+		wishes[i] = newWishProgressResponse(p)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"totals": newCurrencyProgressResponses(progress.Totals),
+		"wishes": wishes,
+	})
+}
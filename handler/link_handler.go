@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LinkHandler struct {
+	linkSvc usecase.LinkSvc
+}
+
+func NewLinkHandler(linkSvc usecase.LinkSvc) *LinkHandler {
+	return &LinkHandler{linkSvc: linkSvc}
+}
+
+type CreateLinkRequest struct {
+	URL string `json:"url" binding:"required"`
+}
+
+// LinkResponse - status が pending の間はプレビューが空（または以前の値）
+type LinkResponse struct {
+	ID          string  `json:"id"`
+	URL         string  `json:"url"`
+	Status      string  `json:"status"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	ImageURL    string  `json:"image_url"`
+	SiteName    string  `json:"site_name"`
+	FetchedAt   *string `json:"fetched_at"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+func newLinkResponse(link *domain.WishLink) LinkResponse {
+	response := LinkResponse{
+		ID:          link.ID.String(),
+		URL:         link.URL,
+		Status:      string(link.Status),
+		Title:       link.Preview.Title,
+		Description: link.Preview.Description,
+		ImageURL:    link.Preview.ImageURL,
+		SiteName:    link.Preview.SiteName,
+		CreatedAt:   link.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if link.FetchedAt != nil {
+		fetchedAtStr := link.FetchedAt.Format("2006-01-02T15:04:05Z")
+		response.FetchedAt = &fetchedAtStr
+	}
+	return response
+}
+
+// respondLinkError - usecaseのエラーをHTTPステータスに変換して返す
+func respondLinkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLinkNotFound), errors.Is(err, domain.ErrWishNotFound),
+		errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidLink), errors.Is(err, domain.ErrTooManyLinks):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseLinkIDs - パスの :id（Wish）と :link_id を解釈する
+func parseLinkIDs(c *gin.Context) (wishID, linkID uuid.UUID, err error) {
+	wishID, err = uuid.Parse(c.Param("id"))
+	if err != nil {
+		return wishID, linkID, errors.New("Invalid wish ID")
+	}
+	linkID, err = uuid.Parse(c.Param("link_id"))
+	if err != nil {
+		return wishID, linkID, errors.New("Invalid link ID")
+	}
+	return wishID, linkID, nil
+}
+
+// CreateLink - WishにURLを追加（プレビューはバックグラウンドで取得されるため 202 を返す）
+func (h *LinkHandler) CreateLink(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req CreateLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	link, err := h.linkSvc.AddLink(c.Request.Context(), orgExternalID, wishID, req.URL)
+	if err != nil {
+		respondLinkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, newLinkResponse(link))
+}
+
+// RefreshLink - プレビューを取得し直す
+func (h *LinkHandler) RefreshLink(c *gin.Context) {
+	wishID, linkID, err := parseLinkIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	link, err := h.linkSvc.RefreshLink(c.Request.Context(), orgExternalID, wishID, linkID)
+	if err != nil {
+		respondLinkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, newLinkResponse(link))
+}
+
+// DeleteLink - WishからURLを外す
+func (h *LinkHandler) DeleteLink(c *gin.Context) {
+	wishID, linkID, err := parseLinkIDs(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	if err := h.linkSvc.DeleteLink(c.Request.Context(), orgExternalID, wishID, linkID); err != nil {
+		respondLinkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Link deleted successfully"})
+}
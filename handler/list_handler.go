@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ListHandler struct {
+	listSvc usecase.ListSvc
+}
+
+func NewListHandler(listSvc usecase.ListSvc) *ListHandler {
+	return &ListHandler{listSvc: listSvc}
+}
+
+type CreateListRequest struct {
+	Name string `json:"name" binding:"required"`
+	Icon string `json:"icon"`
+}
+
+type UpdateListRequest struct {
+	Name string `json:"name" binding:"required"`
+	Icon string `json:"icon"`
+}
+
+// ReorderListsRequest - 組織の全てのリスト（アーカイブ済みを含む）を新しい順番で並べる
+type ReorderListsRequest struct {
+	Order []uuid.UUID `json:"order" binding:"required"`
+}
+
+type ListResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Icon       string  `json:"icon"`
+	Rank       string  `json:"rank"`
+	IsDefault  bool    `json:"is_default"`
+	Archived   bool    `json:"archived"`
+	ArchivedAt *string `json:"archived_at"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+func newListResponse(list *domain.List) ListResponse {
+	response := ListResponse{
+		ID:        list.ID.String(),
+		Name:      list.Name,
+		Icon:      list.Icon,
+		Rank:      list.Rank,
+		IsDefault: list.IsDefault,
+		Archived:  list.Archived(),
+		CreatedAt: list.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: list.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if list.ArchivedAt != nil {
+		archivedAtStr := list.ArchivedAt.Format("2006-01-02T15:04:05Z")
+		response.ArchivedAt = &archivedAtStr
+	}
+	return response
+}
+
+func newListResponses(lists []*domain.List) []ListResponse {
+	responses := make([]ListResponse, len(lists))
+	for i, list := range lists {
+		responses[i] = newListResponse(list)
+	}
+	return responses
+}
+
+// respondListError - usecaseのエラーをHTTPステータスに変換して返す
+func respondListError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrListNotFound), errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidList), errors.Is(err, domain.ErrInvalidReorder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrDefaultList), errors.Is(err, domain.ErrListNotEmpty):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateList - 新しいリストを末尾に作成
+func (h *ListHandler) CreateList(c *gin.Context) {
+	var req CreateListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	list, err := h.listSvc.CreateList(c.Request.Context(), orgExternalID, req.Name, req.Icon)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newListResponse(list))
+}
+
+// GetLists - 組織のリストを並び順に取得（archived=true でアーカイブ済みも含める）
+func (h *ListHandler) GetLists(c *gin.Context) {
+	includeArchived := false
+	if v := c.Query("archived"); v != "" {
+		var err error
+		if includeArchived, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid archived: " + strconv.Quote(v)})
+			return
+		}
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	lists, err := h.listSvc.ListLists(c.Request.Context(), orgExternalID, includeArchived)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"lists": newListResponses(lists)})
+}
+
+// GetList - 特定のリストを取得（Wishは GET /wishes?list=:id）
+func (h *ListHandler) GetList(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	list, err := h.listSvc.GetList(c.Request.Context(), orgExternalID, listID)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(list))
+}
+
+// UpdateList - リストの名前・アイコンを変更
+func (h *ListHandler) UpdateList(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+		return
+	}
+
+	var req UpdateListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	list, err := h.listSvc.UpdateList(c.Request.Context(), orgExternalID, listID, req.Name, req.Icon)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(list))
+}
+
+// ReorderLists - リストの並び順を変更
+func (h *ListHandler) ReorderLists(c *gin.Context) {
+	var req ReorderListsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	lists, err := h.listSvc.ReorderLists(c.Request.Context(), orgExternalID, req.Order)
+	if err != nil {
+		respondListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"lists": newListResponses(lists)})
+}
+
+// SetListArchived - リストをアーカイブ・解除するハンドラーを返す
+func (h *ListHandler) SetListArchived(archived bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		listID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+			return
+		}
+
+		orgExternalID := c.GetString("org_external_id")
+		list, err := h.listSvc.SetListArchived(c.Request.Context(), orgExternalID, listID, archived)
+		if err != nil {
+			respondListError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, newListResponse(list))
+	}
+}
+
+// DeleteList - Wishが残っていないリストを削除（ゴミ箱のWishは既定のリストへ移る）
+func (h *ListHandler) DeleteList(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	if err := h.listSvc.DeleteList(c.Request.Context(), orgExternalID, listID); err != nil {
+		respondListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "List deleted successfully"})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	orgSvc usecase.OrganizationSvc
+}
+
+func NewOrganizationHandler(orgSvc usecase.OrganizationSvc) *OrganizationHandler {
+	return &OrganizationHandler{orgSvc: orgSvc}
+}
+
+// UpdateOrganizationSettingsRequest - 指定した項目だけを更新する（少なくとも1つは必須）
+type UpdateOrganizationSettingsRequest struct {
+	TrashRetentionDays *int    `json:"trash_retention_days"`
+	Timezone           *string `json:"timezone"`
+}
+
+type OrganizationSettingsResponse struct {
+	OrganizationID     string `json:"organization_id"`
+	Name               string `json:"name"`
+	TrashRetentionDays int    `json:"trash_retention_days"`
+	Timezone           string `json:"timezone"`
+}
+
+func newOrganizationSettingsResponse(org *domain.Organization) OrganizationSettingsResponse {
+	return OrganizationSettingsResponse{
+		OrganizationID:     org.ID.String(),
+		Name:               org.Name,
+		TrashRetentionDays: org.TrashRetentionDays,
+		Timezone:           org.Timezone,
+	}
+}
+
+// respondOrganizationError - usecaseのエラーをHTTPステータスに変換して返す
+func respondOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidOrganizationSettings):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetSettings - 現在の組織の設定を取得
+func (h *OrganizationHandler) GetSettings(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
+	org, err := h.orgSvc.GetSettings(c.Request.Context(), orgExternalID)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationSettingsResponse(org))
+}
+
+// UpdateSettings - 現在の組織の設定を更新（ownerのみ）
+func (h *OrganizationHandler) UpdateSettings(c *gin.Context) {
+	var req UpdateOrganizationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.TrashRetentionDays == nil && req.Timezone == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no settings to update"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	org, err := h.orgSvc.UpdateSettings(c.Request.Context(), orgExternalID, usecase.OrganizationSettingsInput{
+		TrashRetentionDays: req.TrashRetentionDays,
+		Timezone:           req.Timezone,
+	})
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newOrganizationSettingsResponse(org))
+}
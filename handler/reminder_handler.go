@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReminderHandler struct {
+	reminderSvc usecase.ReminderSvc
+	userSvc     usecase.UserUsecase
+}
+
+func NewReminderHandler(reminderSvc usecase.ReminderSvc, userSvc usecase.UserUsecase) *ReminderHandler {
+	return &ReminderHandler{
+		reminderSvc: reminderSvc,
+		userSvc:     userSvc,
+	}
+}
+
+// SetRemindersRequest - offsets は期日の何日前に通知するか（"1d", "3d", "1w" など。"0d" は当日）
+type SetRemindersRequest struct {
+	Offsets []string `json:"offsets" binding:"required"`
+}
+
+type ReminderResponse struct {
+	ID      string  `json:"id"`
+	Offset  string  `json:"offset"`
+	SentFor *string `json:"sent_for"`
+}
+
+func newReminderResponse(reminder *domain.WishReminder) ReminderResponse {
+	response := ReminderResponse{
+		ID:     reminder.ID.String(),
+		Offset: reminder.Offset.String(),
+	}
+	if reminder.SentFor != nil {
+		sentForStr := reminder.SentFor.Format(domain.DateLayout)
+		response.SentFor = &sentForStr
+	}
+	return response
+}
+
+// respondReminderError - usecaseのエラーをHTTPステータスに変換して返す
+func respondReminderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWishNotFound), errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidReminder), errors.Is(err, domain.ErrTooManyReminders):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetReminders - 自分が設定したWishのリマインダーを取得
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	reminders, err := h.reminderSvc.GetReminders(c.Request.Context(), orgExternalID, wishID, user.ID)
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reminders": newReminderResponses(reminders)})
+}
+
+// SetReminders - 自分のWishのリマインダーを置き換える（空配列で全て外す）
+func (h *ReminderHandler) SetReminders(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req SetRemindersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	offsets := make([]domain.ReminderOffset, len(req.Offsets))
+	for i, v := range req.Offsets {
+		if offsets[i], err = domain.ParseReminderOffset(v); err != nil {
+			respondReminderError(c, err)
+			return
+		}
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	reminders, err := h.reminderSvc.SetReminders(c.Request.Context(), orgExternalID, wishID, user.ID, offsets)
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reminders": newReminderResponses(reminders)})
+}
+
+func newReminderResponses(reminders []*domain.WishReminder) []ReminderResponse {
+	responses := make([]ReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		responses[i] = newReminderResponse(reminder)
+	}
+	return responses
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SeriesHandler struct {
+	seriesSvc usecase.SeriesSvc
+	userSvc   usecase.UserUsecase
+}
+
+func NewSeriesHandler(seriesSvc usecase.SeriesSvc, userSvc usecase.UserUsecase) *SeriesHandler {
+	return &SeriesHandler{
+		seriesSvc: seriesSvc,
+		userSvc:   userSvc,
+	}
+}
+
+// SeriesTemplateRequest - 各回のWishにコピーする内容
+type SeriesTemplateRequest struct {
+	Title      string        `json:"title" binding:"required"`
+	Note       string        `json:"note"`
+	Price      *MoneyRequest `json:"price"`
+	TagIDs     []uuid.UUID   `json:"tag_ids"`
+	AssigneeID *uuid.UUID    `json:"assignee_id"`
+	// ListID - 各回を作るリスト。省略時は既定のリスト
+	ListID *uuid.UUID `json:"list_id"`
+}
+
+// CreateSeriesRequest - rrule は RFC 5545 の RRULE（例: FREQ=MONTHLY;BYMONTHDAY=1）、start_date はその起点（YYYY-MM-DD）
+type CreateSeriesRequest struct {
+	SeriesTemplateRequest
+	RRule     string `json:"rrule" binding:"required"`
+	StartDate string `json:"start_date" binding:"required"`
+}
+
+type SeriesResponse struct {
+	ID            string         `json:"id"`
+	Title         string         `json:"title"`
+	Note          string         `json:"note"`
+	Price         *MoneyResponse `json:"price"`
+	TagIDs        []string       `json:"tag_ids"`
+	AssigneeID    *string        `json:"assignee_id"`
+	ListID        *string        `json:"list_id"`
+	RRule         string         `json:"rrule"`
+	StartDate     string         `json:"start_date"`
+	Status        string         `json:"status"`
+	NextDate      *string        `json:"next_date"`
+	LastDate      *string        `json:"last_date"`
+	InstanceCount int            `json:"instance_count"`
+	CreatedBy     string         `json:"created_by"`
+	CreatedAt     string         `json:"created_at"`
+	UpdatedAt     string         `json:"updated_at"`
+}
+
+func (r *SeriesTemplateRequest) toInput() usecase.SeriesTemplateInput {
+	return usecase.SeriesTemplateInput{
+		Title:      r.Title,
+		Note:       r.Note,
+		Price:      r.Price.toDomain(),
+		TagIDs:     r.TagIDs,
+		AssigneeID: r.AssigneeID,
+		ListID:     r.ListID,
+	}
+}
+
+func newSeriesResponse(series *domain.WishSeries) SeriesResponse {
+	response := SeriesResponse{
+		ID:            series.ID.String(),
+		Title:         series.Title,
+		Note:          series.Note,
+		Price:         newMoneyResponse(series.Price),
+		TagIDs:        make([]string, len(series.TagIDs)),
+		RRule:         series.Rule.String(),
+		StartDate:     series.StartDate.Format(domain.DateLayout),
+		Status:        string(series.Status),
+		InstanceCount: series.InstanceCount,
+		CreatedBy:     series.CreatedBy.String(),
+		CreatedAt:     series.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:     series.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	for i, id := range series.TagIDs {
+		response.TagIDs[i] = id.String()
+	}
+	if series.AssigneeID != nil {
+		assigneeIDStr := series.AssigneeID.String()
+		response.AssigneeID = &assigneeIDStr
+	}
+	if series.ListID != nil {
+		listIDStr := series.ListID.String()
+		response.ListID = &listIDStr
+	}
+	if series.NextDate != nil {
+		nextDateStr := series.NextDate.Format(domain.DateLayout)
+		response.NextDate = &nextDateStr
+	}
+	if series.LastDate != nil {
+		lastDateStr := series.LastDate.Format(domain.DateLayout)
+		response.LastDate = &lastDateStr
+	}
+	return response
+}
+
+// respondSeriesError - usecaseのエラーをHTTPステータスに変換して返す
+func respondSeriesError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSeriesNotFound), errors.Is(err, domain.ErrOrganizationNotFound),
+		errors.Is(err, domain.ErrTagNotFound), errors.Is(err, domain.ErrListNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidRecurrence), errors.Is(err, domain.ErrWishTitleRequired),
+		errors.Is(err, domain.ErrInvalidMoney), errors.Is(err, domain.ErrInvalidAssignee):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidSeriesTransition), errors.Is(err, domain.ErrListArchived):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateSeries - 繰り返しWishを作成。最初の回が既に始まっていればそのWishも作られる
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	startDate, err := domain.ParseDate(req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date: " + req.StartDate})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	series, err := h.seriesSvc.CreateSeries(c.Request.Context(), c.GetString("org_external_id"), user.ID, usecase.SeriesInput{
+		SeriesTemplateInput: req.toInput(),
+		Rule:                req.RRule,
+		StartDate:           startDate,
+	})
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newSeriesResponse(series))
+}
+
+// GetSeriesList - 組織の繰り返しWish一覧を取得（各回のWishは GET /wishes?series=:id）
+func (h *SeriesHandler) GetSeriesList(c *gin.Context) {
+	list, err := h.seriesSvc.ListSeries(c.Request.Context(), c.GetString("org_external_id"))
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	responses := make([]SeriesResponse, len(list))
+	for i, series := range list {
+		responses[i] = newSeriesResponse(series)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"series": responses})
+}
+
+// GetSeries - 特定の繰り返しWishを取得
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	series, err := h.seriesSvc.GetSeries(c.Request.Context(), c.GetString("org_external_id"), seriesID)
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeriesResponse(series))
+}
+
+// UpdateSeries - これから作られる回の内容を変更。作成済みのWishは変更しない
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	var req SeriesTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := h.seriesSvc.UpdateSeries(c.Request.Context(), c.GetString("org_external_id"), seriesID, req.toInput())
+	if err != nil {
+		respondSeriesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeriesResponse(series))
+}
+
+// TransitionSeries - 繰り返しを to の状態にするハンドラーを返す（pause / resume / stop）
+func (h *SeriesHandler) TransitionSeries(to domain.SeriesStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		seriesID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+			return
+		}
+
+		series, err := h.seriesSvc.TransitionSeries(c.Request.Context(), c.GetString("org_external_id"), seriesID, to)
+		if err != nil {
+			respondSeriesError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, newSeriesResponse(series))
+	}
+}
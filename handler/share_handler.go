@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SharePasswordHeader - パスワード付きの共有リンクを開くときにパスワードを渡すヘッダー
+const SharePasswordHeader = "X-Share-Password"
+
+type ShareHandler struct {
+	shareSvc usecase.ShareSvc
+	userSvc  usecase.UserUsecase
+}
+
+func NewShareHandler(shareSvc usecase.ShareSvc, userSvc usecase.UserUsecase) *ShareHandler {
+	return &ShareHandler{
+		shareSvc: shareSvc,
+		userSvc:  userSvc,
+	}
+}
+
+type CreateShareLinkRequest struct {
+	// ListID - 共有するリスト。省略時は全てのリスト
+	ListID *uuid.UUID `json:"list_id"`
+	// ExpiresAt - 有効期限（RFC3339）。省略時は無期限
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Password - 省略時はパスワード無し
+	Password string `json:"password"`
+}
+
+type ShareLinkResponse struct {
+	ID           string  `json:"id"`
+	ListID       *string `json:"list_id"`
+	TokenPrefix  string  `json:"token_prefix"`
+	HasPassword  bool    `json:"has_password"`
+	Active       bool    `json:"active"`
+	ExpiresAt    *string `json:"expires_at"`
+	RevokedAt    *string `json:"revoked_at"`
+	ViewCount    int64   `json:"view_count"`
+	LastViewedAt *string `json:"last_viewed_at"`
+	CreatedBy    string  `json:"created_by"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+// CreatedShareLinkResponse - 作成時だけトークンとそのパスを返す（後から取得する方法は無い）
+type CreatedShareLinkResponse struct {
+	ShareLinkResponse
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+func newShareLinkResponse(link *domain.ShareLink) ShareLinkResponse {
+	response := ShareLinkResponse{
+		ID:          link.ID.String(),
+		TokenPrefix: link.TokenPrefix,
+		HasPassword: link.HasPassword(),
+		Active:      link.Active(time.Now()),
+		ViewCount:   link.ViewCount,
+		CreatedBy:   link.CreatedBy.String(),
+		CreatedAt:   link.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if link.ListID != nil {
+		listIDStr := link.ListID.String()
+		response.ListID = &listIDStr
+	}
+	if link.ExpiresAt != nil {
+		expiresAtStr := link.ExpiresAt.Format("2006-01-02T15:04:05Z")
+		response.ExpiresAt = &expiresAtStr
+	}
+	if link.RevokedAt != nil {
+		revokedAtStr := link.RevokedAt.Format("2006-01-02T15:04:05Z")
+		response.RevokedAt = &revokedAtStr
+	}
+	if link.LastViewedAt != nil {
+		lastViewedAtStr := link.LastViewedAt.Format("2006-01-02T15:04:05Z")
+		response.LastViewedAt = &lastViewedAtStr
+	}
+	return response
+}
+
+// SharedTagResponse - 共有ページのタグ（IDなどの内部情報は出さない）
+type SharedTagResponse struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// SharedLinkResponse - 共有ページのリンクとプレビュー
+type SharedLinkResponse struct {
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	ImageURL string `json:"image_url"`
+	SiteName string `json:"site_name"`
+}
+
+// SharedWishResponse - 共有ページのWish。担当者・投票・コメントなど組織内の情報は含めない
+type SharedWishResponse struct {
+	Title  string         `json:"title"`
+	Note   string         `json:"note"`
+	Status string         `json:"status"`
+	Price  *MoneyResponse `json:"price"`
+	// TargetDate - 期日（YYYY-MM-DD）
+	TargetDate *string              `json:"target_date"`
+	Tags       []SharedTagResponse  `json:"tags"`
+	Links      []SharedLinkResponse `json:"links"`
+}
+
+type SharedListResponse struct {
+	Name string `json:"name"`
+	Icon string `json:"icon"`
+}
+
+type SharedWishesResponse struct {
+	Organization string `json:"organization"`
+	// List - リストを指定したリンクの場合だけ設定される
+	List   *SharedListResponse  `json:"list"`
+	Wishes []SharedWishResponse `json:"wishes"`
+}
+
+func newSharedWishesResponse(shared *domain.SharedWishes) SharedWishesResponse {
+	response := SharedWishesResponse{
+		Organization: shared.OrganizationName,
+		Wishes:       make([]SharedWishResponse, len(shared.Wishes)),
+	}
+	if shared.List != nil {
+		response.List = &SharedListResponse{Name: shared.List.Name, Icon: shared.List.Icon}
+	}
+	for i, wish := range shared.Wishes {
+		item := SharedWishResponse{
+			Title:  wish.Title,
+			Note:   wish.Note,
+			Status: string(wish.Status),
+			Price:  newMoneyResponse(wish.Price),
+			Tags:   make([]SharedTagResponse, len(wish.Tags)),
+			Links:  make([]SharedLinkResponse, len(wish.Links)),
+		}
+		if wish.TargetDate != nil {
+			targetDateStr := wish.TargetDate.Format(domain.DateLayout)
+			item.TargetDate = &targetDateStr
+		}
+		for j, tag := range wish.Tags {
+			item.Tags[j] = SharedTagResponse{Name: tag.Name, Color: tag.Color}
+		}
+		for j, link := range wish.Links {
+			item.Links[j] = SharedLinkResponse{
+				URL:      link.URL,
+				Title:    link.Preview.Title,
+				ImageURL: link.Preview.ImageURL,
+				SiteName: link.Preview.SiteName,
+			}
+		}
+		response.Wishes[i] = item
+	}
+	return response
+}
+
+// respondShareError - usecaseのエラーをHTTPステータスに変換して返す
+func respondShareError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrShareNotFound), errors.Is(err, domain.ErrListNotFound), errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidShare):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrSharePasswordRequired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrShareExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetShareLinks - 組織の共有リンクを新しい順に取得（取り消し・期限切れも含む）
+func (h *ShareHandler) GetShareLinks(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
+	links, err := h.shareSvc.ListShareLinks(c.Request.Context(), orgExternalID)
+	if err != nil {
+		respondShareError(c, err)
+		return
+	}
+
+	responses := make([]ShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = newShareLinkResponse(link)
+	}
+	c.JSON(http.StatusOK, gin.H{"shares": responses})
+}
+
+// CreateShareLink - 共有リンクを作成
+func (h *ShareHandler) CreateShareLink(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	var req CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	link, token, err := h.shareSvc.CreateShareLink(c.Request.Context(), orgExternalID, user.ID, usecase.ShareLinkInput{
+		ListID:    req.ListID,
+		ExpiresAt: req.ExpiresAt,
+		Password:  req.Password,
+	})
+	if err != nil {
+		respondShareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatedShareLinkResponse{
+		ShareLinkResponse: newShareLinkResponse(link),
+		Token:             token,
+		Path:              "/share/" + token,
+	})
+}
+
+// RevokeShareLink - 共有リンクを取り消す（以後は410を返す）
+func (h *ShareHandler) RevokeShareLink(c *gin.Context) {
+	shareID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid share ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	link, err := h.shareSvc.RevokeShareLink(c.Request.Context(), orgExternalID, shareID)
+	if err != nil {
+		respondShareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newShareLinkResponse(link))
+}
+
+// GetShared - 共有リンクのWishを取得（認証なし。パスワードは X-Share-Password ヘッダーで渡す）
+func (h *ShareHandler) GetShared(c *gin.Context) {
+	shared, err := h.shareSvc.ViewShared(c.Request.Context(), c.Param("token"), c.GetHeader(SharePasswordHeader))
+	if err != nil {
+		respondShareError(c, err)
+		return
+	}
+
+	// 共有ページの内容は途中のキャッシュに残さない
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, newSharedWishesResponse(shared))
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagHandler struct {
+	tagSvc usecase.TagSvc
+}
+
+func NewTagHandler(tagSvc usecase.TagSvc) *TagHandler {
+	return &TagHandler{tagSvc: tagSvc}
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color"`
+}
+
+type UpdateTagRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color"`
+}
+
+type MergeTagRequest struct {
+	IntoID uuid.UUID `json:"into_id" binding:"required"`
+}
+
+type TagResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func newTagResponse(tag *domain.Tag) TagResponse {
+	return TagResponse{
+		ID:        tag.ID.String(),
+		Name:      tag.Name,
+		Color:     tag.Color,
+		CreatedAt: tag.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: tag.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// respondTagError - usecaseのエラーをHTTPステータスに変換して返す
+func respondTagError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTagNotFound), errors.Is(err, domain.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidTag):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrTagNameConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// CreateTag - 新しいタグを作成
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	tag, err := h.tagSvc.CreateTag(c.Request.Context(), orgExternalID, req.Name, req.Color)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newTagResponse(tag))
+}
+
+// GetTags - 組織のタグ一覧を取得
+func (h *TagHandler) GetTags(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
+	tags, err := h.tagSvc.ListTags(c.Request.Context(), orgExternalID)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	responses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = newTagResponse(tag)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": responses})
+}
+
+// UpdateTag - タグの名前・色を変更
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var req UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	tag, err := h.tagSvc.UpdateTag(c.Request.Context(), orgExternalID, tagID, req.Name, req.Color)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTagResponse(tag))
+}
+
+// MergeTag - タグを別のタグに統合（統合元は削除される）
+func (h *TagHandler) MergeTag(c *gin.Context) {
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	var req MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	tag, err := h.tagSvc.MergeTags(c.Request.Context(), orgExternalID, tagID, req.IntoID)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTagResponse(tag))
+}
+
+// DeleteTag - タグを削除（Wishからも外れる）
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	if err := h.tagSvc.DeleteTag(c.Request.Context(), orgExternalID, tagID); err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+)
+
+// BulkWishesRequest - mode は atomic（既定。1件でも失敗すれば全て取り消す）か best_effort
+type BulkWishesRequest struct {
+	Mode       string                     `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Operations []BulkWishOperationRequest `json:"operations" binding:"required,dive"`
+}
+
+// BulkWishOperationRequest - op ごとに必要な項目
+// create: wish（POST /wish と同じ）、update: id と patch（PATCH /wish/:id と同じマージパッチ）、
+// soft_delete・restore・delete: id。version は If-Match と同じく指定時だけバージョンを確認する
+type BulkWishOperationRequest struct {
+	Op      string             `json:"op" binding:"required"`
+	ID      *uuid.UUID         `json:"id"`
+	Version int                `json:"version" binding:"min=0"`
+	Wish    *CreateWishRequest `json:"wish"`
+	Patch   json.RawMessage    `json:"patch"`
+}
+
+type BulkWishResultResponse struct {
+	Index  int           `json:"index"`
+	Op     string        `json:"op"`
+	ID     string        `json:"id,omitempty"`
+	Status int           `json:"status"`
+	Wish   *WishResponse `json:"wish,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type BulkWishesResponse struct {
+	Mode      string                   `json:"mode"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Results   []BulkWishResultResponse `json:"results"`
+}
+
+// toOperation - リクエストの1件を usecase の操作に変換する
+func (r *BulkWishOperationRequest) toOperation() (usecase.BulkWishOperation, error) {
+	operation := usecase.BulkWishOperation{Op: usecase.BulkWishOp(r.Op), ExpectedVersion: r.Version}
+	if operation.Op.Action() == "" {
+		return operation, fmt.Errorf("unknown op %q", r.Op)
+	}
+
+	if operation.Op == usecase.BulkWishCreate {
+		if r.Wish == nil {
+			return operation, fmt.Errorf("wish is required for %s", r.Op)
+		}
+		input, err := r.Wish.toInput()
+		operation.Input = input
+		return operation, err
+	}
+
+	if r.ID == nil {
+		return operation, fmt.Errorf("id is required for %s", r.Op)
+	}
+	operation.ID = *r.ID
+	if operation.Op == usecase.BulkWishUpdate {
+		if len(r.Patch) == 0 {
+			return operation, fmt.Errorf("patch is required for %s", r.Op)
+		}
+		patch, err := parseWishMergePatch(r.Patch)
+		operation.Patch = patch
+		return operation, err
+	}
+	return operation, nil
+}
+
+// BulkWishes - 複数のWishの作成・更新・削除をまとめて実行
+// 権限は操作ごとに単体のルートと同じものを確認する。不正な操作が含まれる場合は何も実行せず400を返す
+// best_effort は常に200で、操作ごとの結果を返す。atomic で失敗した場合は失敗した操作のステータスを返す
+func (h *WishHandler) BulkWishes(c *gin.Context) {
+	var req BulkWishesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = BulkModeAtomic
+	}
+	if len(req.Operations) == 0 || len(req.Operations) > usecase.MaxBulkWishOperations {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("operations must contain 1 to %d items", usecase.MaxBulkWishOperations)})
+		return
+	}
+
+	operations := make([]usecase.BulkWishOperation, len(req.Operations))
+	for i := range req.Operations {
+		operation, err := req.Operations[i].toOperation()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("operations[%d]: %s", i, err.Error())})
+			return
+		}
+		operations[i] = operation
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	// 権限の無い操作は実行しない（atomic の場合は全体を実行しない）
+	allowed, err := h.authorizeBulkOperations(c, operations)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	results := make([]usecase.BulkWishResult, len(operations))
+	indexes := make([]int, 0, len(operations))
+	for i := range operations {
+		if allowed[operations[i].Op] {
+			indexes = append(indexes, i)
+		} else {
+			results[i].Err = domain.ErrPermissionDenied
+		}
+	}
+
+	atomic := req.Mode == BulkModeAtomic
+	switch {
+	case atomic && len(indexes) < len(operations):
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = domain.ErrBulkRolledBack
+			}
+		}
+	case len(indexes) > 0:
+		runnable := make([]usecase.BulkWishOperation, len(indexes))
+		for j, i := range indexes {
+			runnable[j] = operations[i]
+		}
+		ran, err := h.wishSvc.BulkWishes(c.Request.Context(), c.GetString("org_external_id"), user.ID, runnable, atomic)
+		if err != nil {
+			respondWishError(c, err)
+			return
+		}
+		for j, i := range indexes {
+			results[i] = ran[j]
+		}
+	}
+
+	response := BulkWishesResponse{Mode: req.Mode, Results: make([]BulkWishResultResponse, len(results))}
+	status := http.StatusOK
+	for i, result := range results {
+		item := BulkWishResultResponse{Index: i, Op: string(operations[i].Op), Status: http.StatusOK}
+		if operations[i].Op != usecase.BulkWishCreate {
+			item.ID = operations[i].ID.String()
+		}
+		if result.Err != nil {
+			item.Status = wishErrorStatus(result.Err)
+			item.Error = result.Err.Error()
+			response.Failed++
+			// atomic の場合は巻き戻しの原因になった操作のステータスを全体のステータスにする
+			if atomic && item.Status != http.StatusFailedDependency {
+				status = item.Status
+			}
+		} else {
+			if operations[i].Op == usecase.BulkWishCreate {
+				item.Status = http.StatusCreated
+			}
+			response.Succeeded++
+		}
+		if result.Wish != nil {
+			wish := newWishResponse(result.Wish)
+			item.ID = wish.ID
+			item.Wish = &wish
+		}
+		response.Results[i] = item
+	}
+
+	c.JSON(status, response)
+}
+
+// authorizeBulkOperations - 含まれる操作の種類ごとにロールポリシーを評価する
+func (h *WishHandler) authorizeBulkOperations(c *gin.Context, operations []usecase.BulkWishOperation) (map[usecase.BulkWishOp]bool, error) {
+	allowed := make(map[usecase.BulkWishOp]bool)
+	checked := make(map[usecase.BulkWishOp]bool)
+	for _, operation := range operations {
+		if checked[operation.Op] {
+			continue
+		}
+		checked[operation.Op] = true
+
+		decision, err := h.policySvc.Authorize(
+			c.Request.Context(),
+			c.GetString("sub_id"),
+			c.GetString("org_external_id"),
+			c.GetString("org_role"),
+			domain.ResourceWish,
+			operation.Op.Action(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		allowed[operation.Op] = decision.Allowed
+	}
+	return allowed, nil
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ChecklistProgressResponse struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+type ChecklistItemResponse struct {
+	ID          string  `json:"id"`
+	WishID      string  `json:"wish_id"`
+	Title       string  `json:"title"`
+	Rank        string  `json:"rank"`
+	AssigneeID  *string `json:"assignee_id"`
+	Done        bool    `json:"done"`
+	CompletedAt *string `json:"completed_at"`
+	CompletedBy *string `json:"completed_by"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type CreateChecklistItemRequest struct {
+	Title      string     `json:"title" binding:"required"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+}
+
+// UpdateChecklistItemRequest - 省略した項目は変更しない。assignee_id は null で担当を外す
+type UpdateChecklistItemRequest struct {
+	Title      *string         `json:"title"`
+	AssigneeID json.RawMessage `json:"assignee_id"`
+	Done       *bool           `json:"done"`
+	// FulfillWhenComplete - この変更で全ての項目がチェック済みになったらWishを達成（fulfilled）にする
+	FulfillWhenComplete bool `json:"fulfill_when_complete"`
+}
+
+// MoveChecklistItemRequest - after_id の直後、before_id の直前のどちらか。両方省略で先頭
+type MoveChecklistItemRequest struct {
+	AfterID  *uuid.UUID `json:"after_id"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
+
+func newChecklistItemResponse(item *domain.ChecklistItem) ChecklistItemResponse {
+	response := ChecklistItemResponse{
+		ID:        item.ID.String(),
+		WishID:    item.WishID.String(),
+		Title:     item.Title,
+		Rank:      item.Rank,
+		Done:      item.Done(),
+		CreatedAt: item.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: item.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if item.AssigneeID != nil {
+		assigneeIDStr := item.AssigneeID.String()
+		response.AssigneeID = &assigneeIDStr
+	}
+	if item.CompletedAt != nil {
+		completedAtStr := item.CompletedAt.Format("2006-01-02T15:04:05Z")
+		response.CompletedAt = &completedAtStr
+	}
+	if item.CompletedBy != nil {
+		completedByStr := item.CompletedBy.String()
+		response.CompletedBy = &completedByStr
+	}
+	return response
+}
+
+// parseChecklistItemParams - パスのWish IDと項目IDを解釈する。失敗時はレスポンスを書いてfalseを返す
+func parseChecklistItemParams(c *gin.Context) (wishID, itemID uuid.UUID, ok bool) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	itemID, err = uuid.Parse(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return wishID, itemID, true
+}
+
+// GetChecklist - Wishのチェックリストを並び順に取得
+func (h *WishHandler) GetChecklist(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	items, err := h.wishSvc.ListChecklist(c.Request.Context(), c.GetString("org_external_id"), wishID)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	responses := make([]ChecklistItemResponse, len(items))
+	progress := ChecklistProgressResponse{Total: len(items)}
+	for i, item := range items {
+		responses[i] = newChecklistItemResponse(item)
+		if item.Done() {
+			progress.Done++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": responses, "progress": progress})
+}
+
+// CreateChecklistItem - チェックリストの末尾に項目を追加
+func (h *WishHandler) CreateChecklistItem(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req CreateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.wishSvc.AddChecklistItem(c.Request.Context(), c.GetString("org_external_id"), wishID, req.Title, req.AssigneeID)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newChecklistItemResponse(item))
+}
+
+// UpdateChecklistItem - 項目のタイトル・担当・チェック状態を変更。進捗やステータスが変わるため更新後のWishも返す
+func (h *WishHandler) UpdateChecklistItem(c *gin.Context) {
+	wishID, itemID, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	assigneeID, clearAssignee, err := parseAssigneeField(req.AssigneeID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	item, wish, err := h.wishSvc.UpdateChecklistItem(c.Request.Context(), c.GetString("org_external_id"), wishID, itemID, user.ID, usecase.ChecklistItemInput{
+		Title:               req.Title,
+		AssigneeID:          assigneeID,
+		ClearAssignee:       clearAssignee,
+		Done:                req.Done,
+		FulfillWhenComplete: req.FulfillWhenComplete,
+	})
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"item": newChecklistItemResponse(item), "wish": newWishResponse(wish)})
+}
+
+// MoveChecklistItem - 項目を別の項目の前後、または先頭へ移動
+func (h *WishHandler) MoveChecklistItem(c *gin.Context) {
+	wishID, itemID, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	var req MoveChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.wishSvc.MoveChecklistItem(c.Request.Context(), c.GetString("org_external_id"), wishID, itemID, req.AfterID, req.BeforeID)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newChecklistItemResponse(item))
+}
+
+// DeleteChecklistItem - 項目を削除
+func (h *WishHandler) DeleteChecklistItem(c *gin.Context) {
+	wishID, itemID, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.wishSvc.DeleteChecklistItem(c.Request.Context(), c.GetString("org_external_id"), wishID, itemID); err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item deleted successfully"})
+}
@@ -1,50 +1,437 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"taine-api/domain"
 	"taine-api/usecase"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type WishHandler struct {
-	wishSvc usecase.WishSvc
-	userSvc usecase.UserUsecase
+	wishSvc   usecase.WishSvc
+	userSvc   usecase.UserUsecase
+	policySvc usecase.PolicySvc
 }
 
-func NewWishHandler(wishSvc usecase.WishSvc, userSvc usecase.UserUsecase) *WishHandler {
+func NewWishHandler(wishSvc usecase.WishSvc, userSvc usecase.UserUsecase, policySvc usecase.PolicySvc) *WishHandler {
 	return &WishHandler{
-		wishSvc: wishSvc,
-		userSvc: userSvc,
+		wishSvc:   wishSvc,
+		userSvc:   userSvc,
+		policySvc: policySvc,
 	}
 }
 
 type CreateWishRequest struct {
-	Title   string `json:"title" binding:"required"`
-	Note    string `json:"note"`
-	OrderNo int    `json:"order_no"`
+	Title   string        `json:"title" binding:"required"`
+	Note    string        `json:"note"`
+	OrderNo int           `json:"order_no"`
+	TagIDs  []uuid.UUID   `json:"tag_ids"`
+	Price   *MoneyRequest `json:"price"`
+	// TargetDate - 期日（YYYY-MM-DD）
+	TargetDate *string    `json:"target_date"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	// ListID - 追加するリスト。省略時は既定のリスト
+	ListID *uuid.UUID `json:"list_id"`
+	// Place - 場所（レストラン・旅行先など）
+	Place *PlaceRequest `json:"place"`
+}
+
+// PlaceRequest - 場所。lat・lng（WGS 84の度）は両方指定するか両方省略する
+type PlaceRequest struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Lat     *float64 `json:"lat"`
+	Lng     *float64 `json:"lng"`
+}
+
+func (r *PlaceRequest) toDomain() *domain.Place {
+	if r == nil {
+		return nil
+	}
+	return &domain.Place{Name: r.Name, Address: r.Address, Lat: r.Lat, Lng: r.Lng}
+}
+
+type PlaceResponse struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Lat     *float64 `json:"lat"`
+	Lng     *float64 `json:"lng"`
+}
+
+func newPlaceResponse(place *domain.Place) *PlaceResponse {
+	if place == nil {
+		return nil
+	}
+	return &PlaceResponse{Name: place.Name, Address: place.Address, Lat: place.Lat, Lng: place.Lng}
+}
+
+func (r *CreateWishRequest) toInput() (usecase.WishInput, error) {
+	input := usecase.WishInput{
+		Title:      r.Title,
+		Note:       r.Note,
+		OrderNo:    r.OrderNo,
+		TagIDs:     r.TagIDs,
+		Price:      r.Price.toDomain(),
+		AssigneeID: r.AssigneeID,
+		ListID:     r.ListID,
+		Place:      r.Place.toDomain(),
+	}
+	if r.TargetDate != nil {
+		targetDate, err := parseTargetDate(*r.TargetDate)
+		if err != nil {
+			return input, err
+		}
+		input.TargetDate = targetDate
+	}
+	return input, nil
 }
 
 type UpdateWishRequest struct {
 	Title   string `json:"title" binding:"required"`
 	Note    string `json:"note"`
 	OrderNo int    `json:"order_no"`
+	// TagIDs - 省略時はタグを変更しない。空配列で全て外す
+	TagIDs []uuid.UUID `json:"tag_ids"`
+	// Price - 省略時は価格を変更しない。null で価格を外す
+	Price json.RawMessage `json:"price"`
+	// TargetDate - 期日（YYYY-MM-DD）。省略時は変更しない。null で外す
+	TargetDate json.RawMessage `json:"target_date"`
+	// AssigneeID - 省略時は変更しない。null で外す
+	AssigneeID json.RawMessage `json:"assignee_id"`
+	// Place - 省略時は変更しない。null で外す
+	Place json.RawMessage `json:"place"`
 }
 
+// UpdateWishOrderRequest - order_no は大きいほど先頭に並ぶ優先度（0や負の値も指定できる）
 type UpdateWishOrderRequest struct {
-	OrderNo int `json:"order_no" binding:"required"`
+	OrderNo *int `json:"order_no" binding:"required"`
+}
+
+// ReorderWishesRequest - order（1つのリストの全てのWishの並び）か moves（個別の移動）のどちらか一方を指定する
+type ReorderWishesRequest struct {
+	Order []uuid.UUID       `json:"order"`
+	Moves []WishMoveRequest `json:"moves"`
+}
+
+type VoteWishRequest struct {
+	Value int `json:"value" binding:"required,oneof=1 -1"`
+}
+
+// WishMoveRequest - list_id を指定すると別のリストへ移す（after_id / before_id はそのリストのWish）
+type WishMoveRequest struct {
+	ID       uuid.UUID  `json:"id" binding:"required"`
+	ListID   *uuid.UUID `json:"list_id"`
+	AfterID  *uuid.UUID `json:"after_id"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
+
+// MoveWishRequest - 1件のWishを移動する。list_id を省略すると同じリストの中で並べ替える
+// organization_id（別の組織のexternal ID）を指定すると、その組織の list_id のリスト（省略時は既定のリスト）の先頭へ移す
+type MoveWishRequest struct {
+	OrganizationID string     `json:"organization_id"`
+	ListID         *uuid.UUID `json:"list_id"`
+	AfterID        *uuid.UUID `json:"after_id"`
+	BeforeID       *uuid.UUID `json:"before_id"`
+}
+
+// CopyWishRequest - Wishを組織（別の組織も可）の list_id のリスト（省略時は既定のリスト）の先頭へコピーする
+type CopyWishRequest struct {
+	OrganizationID string     `json:"organization_id" binding:"required"`
+	ListID         *uuid.UUID `json:"list_id"`
+}
+
+// WishOriginResponse - コピー・移動元のWish。元のWish・組織が削除された場合はnull
+type WishOriginResponse struct {
+	WishID         *string `json:"wish_id"`
+	OrganizationID *string `json:"organization_id"`
+	// Kind - copy | move
+	Kind string `json:"kind"`
 }
 
 type WishResponse struct {
-	ID             string  `json:"id"`
-	OrganizationID string  `json:"organization_id"`
-	Title          string  `json:"title"`
-	Note           string  `json:"note"`
-	OrderNo        int     `json:"order_no"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
-	DeletedAt      *string `json:"deleted_at,omitempty"`
+	ID             string `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	ListID         string `json:"list_id"`
+	Title          string `json:"title"`
+	Note           string `json:"note"`
+	OrderNo        int    `json:"order_no"`
+	Rank           string `json:"rank"`
+	Status         string `json:"status"`
+	// Version - 更新時に If-Match で渡す値（ETagヘッダーと同じバージョン）
+	Version      int           `json:"version"`
+	FulfilledAt  *string       `json:"fulfilled_at,omitempty"`
+	FulfilledBy  *string       `json:"fulfilled_by,omitempty"`
+	CreatedAt    string        `json:"created_at"`
+	UpdatedAt    string        `json:"updated_at"`
+	DeletedAt    *string       `json:"deleted_at,omitempty"`
+	DeletedBy    *string       `json:"deleted_by,omitempty"`
+	Tags         []TagResponse `json:"tags"`
+	CommentCount int           `json:"comment_count"`
+	Score        int           `json:"score"`
+	// MyVote - 呼び出し元の投票（1 | -1 | 0=未投票）。一覧・投票・合意一覧でのみ設定される
+	MyVote int            `json:"my_vote"`
+	Links  []LinkResponse `json:"links"`
+	Price  *MoneyResponse `json:"price"`
+	// TargetDate - 期日（YYYY-MM-DD）
+	TargetDate *string `json:"target_date"`
+	AssigneeID *string `json:"assignee_id"`
+	// SeriesID - 繰り返しから作られたWishの場合、元の繰り返し
+	SeriesID *string `json:"series_id"`
+	// Checklist - チェックリストの進捗（項目が無い場合は 0/0）
+	Checklist ChecklistProgressResponse `json:"checklist"`
+	// Origin - 別の組織からコピー・移動されたWishの場合、元のWish
+	Origin *WishOriginResponse `json:"origin"`
+	Place  *PlaceResponse      `json:"place"`
+}
+
+// TrashedWishResponse - ゴミ箱一覧の1件。purge_atがnullの場合は無期限保持
+type TrashedWishResponse struct {
+	WishResponse
+	PurgeAt *string `json:"purge_at"`
+}
+
+// newWishResponse - domain.WishをAPIレスポンスに変換
+func newWishResponse(wish *domain.Wish) WishResponse {
+	response := WishResponse{
+		ID:             wish.ID.String(),
+		OrganizationID: wish.OrganizationID.String(),
+		ListID:         wish.ListID.String(),
+		Title:          wish.Title,
+		Note:           wish.Note,
+		OrderNo:        wish.OrderNo,
+		Rank:           wish.Rank,
+		Status:         string(wish.Status),
+		Version:        wish.Version,
+		CreatedAt:      wish.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      wish.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if wish.FulfilledAt != nil {
+		fulfilledAtStr := wish.FulfilledAt.Format("2006-01-02T15:04:05Z")
+		response.FulfilledAt = &fulfilledAtStr
+	}
+	if wish.FulfilledBy != nil {
+		fulfilledByStr := wish.FulfilledBy.String()
+		response.FulfilledBy = &fulfilledByStr
+	}
+	if wish.DeletedAt != nil {
+		deletedAtStr := wish.DeletedAt.Format("2006-01-02T15:04:05Z")
+		response.DeletedAt = &deletedAtStr
+	}
+	if wish.DeletedBy != nil {
+		deletedByStr := wish.DeletedBy.String()
+		response.DeletedBy = &deletedByStr
+	}
+	if wish.TargetDate != nil {
+		targetDateStr := wish.TargetDate.Format(domain.DateLayout)
+		response.TargetDate = &targetDateStr
+	}
+	if wish.AssigneeID != nil {
+		assigneeIDStr := wish.AssigneeID.String()
+		response.AssigneeID = &assigneeIDStr
+	}
+	if wish.SeriesID != nil {
+		seriesIDStr := wish.SeriesID.String()
+		response.SeriesID = &seriesIDStr
+	}
+	if wish.Origin != nil {
+		response.Origin = &WishOriginResponse{Kind: string(wish.Origin.Kind)}
+		if wish.Origin.WishID != nil {
+			originWishIDStr := wish.Origin.WishID.String()
+			response.Origin.WishID = &originWishIDStr
+		}
+		if wish.Origin.OrganizationID != nil {
+			originOrgIDStr := wish.Origin.OrganizationID.String()
+			response.Origin.OrganizationID = &originOrgIDStr
+		}
+	}
+	response.CommentCount = wish.CommentCount
+	response.Checklist = ChecklistProgressResponse{Done: wish.Checklist.Done, Total: wish.Checklist.Total}
+	response.Price = newMoneyResponse(wish.Price)
+	response.Place = newPlaceResponse(wish.Place)
+	response.Score = wish.Score
+	response.MyVote = int(wish.MyVote)
+	response.Links = make([]LinkResponse, len(wish.Links))
+	for i, link := range wish.Links {
+		response.Links[i] = newLinkResponse(link)
+	}
+	response.Tags = make([]TagResponse, len(wish.Tags))
+	for i, tag := range wish.Tags {
+		response.Tags[i] = newTagResponse(tag)
+	}
+	return response
+}
+
+type WishSearchResultResponse struct {
+	Wish         WishResponse `json:"wish"`
+	Rank         float64      `json:"rank"`
+	TitleSnippet string       `json:"title_snippet,omitempty"`
+	NoteSnippet  string       `json:"note_snippet,omitempty"`
+}
+
+// NearbyWishResponse - 近くのWishと、検索した地点からの距離（メートル）
+type NearbyWishResponse struct {
+	Wish           WishResponse `json:"wish"`
+	DistanceMeters float64      `json:"distance_meters"`
+}
+
+// parsePriceField - 更新時の price を解釈する。省略時は変更なし、null は価格を外す
+func parsePriceField(raw json.RawMessage) (price *domain.Money, clear bool, err error) {
+	if len(raw) == 0 {
+		return nil, false, nil
+	}
+	if string(raw) == "null" {
+		return nil, true, nil
+	}
+	var req MoneyRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return nil, false, fmt.Errorf("invalid price: %w", err)
+	}
+	if req.Amount == nil || req.Currency == "" {
+		return nil, false, errors.New("invalid price: amount and currency are required")
+	}
+	return req.toDomain(), false, nil
+}
+
+// parsePlaceField - 更新時の place を解釈する。省略時は変更なし、null は場所を外す
+func parsePlaceField(raw json.RawMessage) (place *domain.Place, clear bool, err error) {
+	if len(raw) == 0 {
+		return nil, false, nil
+	}
+	if string(raw) == "null" {
+		return nil, true, nil
+	}
+	var req PlaceRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return nil, false, fmt.Errorf("invalid place: %w", err)
+	}
+	return req.toDomain(), false, nil
+}
+
+// parseTargetDate - 期日（YYYY-MM-DD）を解釈する
+func parseTargetDate(v string) (*time.Time, error) {
+	date, err := domain.ParseDate(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target_date: %q", v)
+	}
+	return &date, nil
+}
+
+// parseTargetDateField - 更新時の target_date を解釈する。省略時は変更なし、null は期日を外す
+func parseTargetDateField(raw json.RawMessage) (date *time.Time, clear bool, err error) {
+	if len(raw) == 0 {
+		return nil, false, nil
+	}
+	if string(raw) == "null" {
+		return nil, true, nil
+	}
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false, fmt.Errorf("invalid target_date: %w", err)
+	}
+	date, err = parseTargetDate(v)
+	return date, false, err
+}
+
+// parseAssigneeField - 更新時の assignee_id を解釈する。省略時は変更なし、null は担当者を外す
+func parseAssigneeField(raw json.RawMessage) (id *uuid.UUID, clear bool, err error) {
+	if len(raw) == 0 {
+		return nil, false, nil
+	}
+	if string(raw) == "null" {
+		return nil, true, nil
+	}
+	var v uuid.UUID
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false, fmt.Errorf("invalid assignee_id: %w", err)
+	}
+	return &v, false, nil
+}
+
+// wishETag - WishのバージョンをETagの形式にする
+func wishETag(wish *domain.Wish) string {
+	return strconv.Quote(strconv.Itoa(wish.Version))
+}
+
+// parseIfMatch - If-Matchヘッダーから期待するバージョンを取り出す
+// ヘッダーが無い、または "*" の場合は0（無条件で書き込む）。弱いETag（W/"3"）も受け付ける
+func parseIfMatch(c *gin.Context) (int, error) {
+	v := strings.TrimSpace(c.GetHeader("If-Match"))
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+	tag, err := strconv.Unquote(strings.TrimPrefix(v, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid If-Match: %q", v)
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid If-Match: %q", v)
+	}
+	return version, nil
+}
+
+// respondWishWriteError - If-Matchのバージョンが古い場合は412と現在のWish（ETag付き）を返す。それ以外は respondWishError
+func (h *WishHandler) respondWishWriteError(c *gin.Context, wishID uuid.UUID, err error) {
+	if !errors.Is(err, domain.ErrWishVersionMismatch) {
+		respondWishError(c, err)
+		return
+	}
+
+	user, getErr := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if getErr != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+	current, getErr := h.wishSvc.GetWish(c.Request.Context(), c.GetString("org_external_id"), wishID, user.ID)
+	if getErr != nil {
+		respondWishError(c, getErr)
+		return
+	}
+	c.Header("ETag", wishETag(current))
+	c.JSON(http.StatusPreconditionFailed, newWishResponse(current))
+}
+
+// respondWishError - usecaseのエラーをHTTPステータスに変換して返す
+func respondWishError(c *gin.Context, err error) {
+	c.JSON(wishErrorStatus(err), gin.H{"error": err.Error()})
+}
+
+// wishErrorStatus - usecaseのエラーに対応するHTTPステータス
+// 他組織のWishは存在しないものとして404を返す
+func wishErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrWishNotFound), errors.Is(err, domain.ErrOrganizationNotFound),
+		errors.Is(err, domain.ErrTagNotFound), errors.Is(err, domain.ErrChecklistItemNotFound),
+		errors.Is(err, domain.ErrListNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidCursor), errors.Is(err, domain.ErrInvalidWishQuery), errors.Is(err, domain.ErrInvalidWishStatus),
+		errors.Is(err, domain.ErrInvalidReorder), errors.Is(err, domain.ErrInvalidRank),
+		errors.Is(err, domain.ErrInvalidVote), errors.Is(err, domain.ErrInvalidMoney), errors.Is(err, domain.ErrInvalidAssignee),
+		errors.Is(err, domain.ErrWishTitleRequired), errors.Is(err, domain.ErrInvalidBulkOperation),
+		errors.Is(err, domain.ErrInvalidChecklistItem), errors.Is(err, domain.ErrInvalidChecklistMove),
+		errors.Is(err, domain.ErrInvalidTransfer), errors.Is(err, domain.ErrInvalidPlace):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrInvalidStatusTransition), errors.Is(err, domain.ErrWishAlreadyDeleted),
+		errors.Is(err, domain.ErrWishNotDeleted), errors.Is(err, domain.ErrTooManyChecklistItems),
+		errors.Is(err, domain.ErrListArchived):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrWishVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, domain.ErrBulkRolledBack):
+		return http.StatusFailedDependency
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 // CreateWish - 新しいWishを作成
@@ -58,23 +445,27 @@ func (h *WishHandler) CreateWish(c *gin.Context) {
 	// 組織IDをパース
 	orgID := c.GetString("org_external_id")
 
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	input, err := req.toInput()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Wishを作成
-	wish, err := h.wishSvc.CreateWishByOrganizationExternalID(c.Request.Context(), orgID, req.Title, req.Note, req.OrderNo)
+	wish, err := h.wishSvc.CreateWishByOrganizationExternalID(c.Request.Context(), orgID, user.ID, input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWishError(c, err)
 		return
 	}
 
 	// レスポンスを作成
-	response := WishResponse{
-		ID:             wish.ID.String(),
-		OrganizationID: wish.OrganizationID.String(),
-		Title:          wish.Title,
-		Note:           wish.Note,
-		OrderNo:        wish.OrderNo,
-		CreatedAt:      wish.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:      wish.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-	}
+	response := newWishResponse(wish)
 
 	c.JSON(http.StatusCreated, response)
 }
@@ -88,101 +479,295 @@ func (h *WishHandler) GetWish(c *gin.Context) {
 		return
 	}
 
-	wish, err := h.wishSvc.GetWish(c.Request.Context(), wishID)
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wish, err := h.wishSvc.GetWish(c.Request.Context(), orgExternalID, wishID, user.ID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondWishError(c, err)
 		return
 	}
 
 	// レスポンスを作成
-	response := WishResponse{
-		ID:             wish.ID.String(),
-		OrganizationID: wish.OrganizationID.String(),
-		Title:          wish.Title,
-		Note:           wish.Note,
-		OrderNo:        wish.OrderNo,
-		CreatedAt:      wish.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:      wish.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-	}
-	if wish.DeletedAt != nil {
-		deletedAtStr := wish.DeletedAt.Format("2006-01-02T15:04:05Z")
-		response.DeletedAt = &deletedAtStr
-	}
+	response := newWishResponse(wish)
 
+	c.Header("ETag", wishETag(wish))
 	c.JSON(http.StatusOK, response)
 }
 
-// GetWishesByOrganization - 組織のWish一覧を取得
-func (h *WishHandler) GetWishesByOrganization(c *gin.Context) {
-	orgIDStr := c.Param("org_id")
+// GetWishesForCurrentOrg - 現在のユーザーの組織のWish一覧を取得（JWTのorg_idを使用）
+// クエリ: limit, cursor, sort(priority|created_at|updated_at|title|score), order(asc|desc),
+// created_from, created_to, updated_from, updated_to (RFC3339), title_prefix, include_deleted,
+// status（カンマ区切りで複数指定可）, tag（タグID。複数指定可）, tag_mode(and|or, 既定はor),
+// view(upcoming|overdue。組織のタイムゾーンの今日を基準に期日で絞り込む), assignee（担当者のユーザーID）,
+// series（繰り返しのID）, list（リストのID。省略時は既定のリスト、all で全てのリスト）
+// sortには target_date も指定できる（期日未設定は昇順で最後）
+func (h *WishHandler) GetWishesForCurrentOrg(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
 
-	// UUIDとして解析を試みる
-	orgID, err := uuid.Parse(orgIDStr)
+	if orgExternalID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID not found in token"})
+		return
+	}
+
+	query, err := parseWishListQuery(c)
 	if err != nil {
-		// UUID形式でない場合は、external_idとして扱う
-		// external_idから実際の組織IDを取得する必要がある
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID format - UUID required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 自分の投票（my_vote）を返すため呼び出し元を解決
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
 		return
 	}
+	query.ViewerID = &user.ID
 
-	wishes, err := h.wishSvc.GetWishesByOrganization(c.Request.Context(), orgID)
+	// external_idから実際の組織を取得してWishを取得
+	page, err := h.wishSvc.ListWishes(c.Request.Context(), orgExternalID, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWishError(c, err)
 		return
 	}
 
 	// レスポンスを作成
-	responses := make([]WishResponse, len(wishes))
-	for i, wish := range wishes {
-		responses[i] = WishResponse{
-			ID:             wish.ID.String(),
-			OrganizationID: wish.OrganizationID.String(),
-			Title:          wish.Title,
-			Note:           wish.Note,
-			OrderNo:        wish.OrderNo,
-			CreatedAt:      wish.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt:      wish.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	responses := make([]WishResponse, len(page.Wishes))
+	for i, wish := range page.Wishes {
+		responses[i] = newWishResponse(wish)
+	}
+
+	var nextCursor *string
+	if page.NextCursor != "" {
+		nextCursor = &page.NextCursor
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wishes": responses, "next_cursor": nextCursor})
+}
+
+// parseWishListQuery - 一覧取得のクエリパラメータを解釈する
+func parseWishListQuery(c *gin.Context) (domain.WishListQuery, error) {
+	var query domain.WishListQuery
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return query, fmt.Errorf("invalid limit: %q", v)
 		}
-		if wish.DeletedAt != nil {
-			deletedAtStr := wish.DeletedAt.Format("2006-01-02T15:04:05Z")
-			responses[i].DeletedAt = &deletedAtStr
+		query.Limit = limit
+	}
+
+	if v := c.Query("sort"); v != "" {
+		query.Sort = domain.WishSortKey(v)
+		if !query.Sort.Valid() {
+			return query, fmt.Errorf("invalid sort: %q", v)
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"wishes": responses})
+	switch v := domain.SortOrder(c.Query("order")); v {
+	case "", domain.SortAsc, domain.SortDesc:
+		query.Order = v
+	default:
+		return query, fmt.Errorf("invalid order: %q", v)
+	}
+
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := domain.DecodeWishCursor(v)
+		if err != nil {
+			return query, err
+		}
+		query.After = cursor
+		// sort/order省略時はカーソル発行時の並び順を引き継ぐ
+		if query.Sort == "" {
+			query.Sort = cursor.Sort
+		}
+		if query.Order == "" {
+			query.Order = cursor.Order
+		}
+	}
+
+	for name, dst := range map[string]**time.Time{
+		"created_from": &query.CreatedFrom,
+		"created_to":   &query.CreatedTo,
+		"updated_from": &query.UpdatedFrom,
+		"updated_to":   &query.UpdatedTo,
+	} {
+		v := c.Query(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return query, fmt.Errorf("invalid %s: %q", name, v)
+		}
+		*dst = &t
+	}
+
+	query.TitlePrefix = c.Query("title_prefix")
+
+	for _, v := range c.QueryArray("status") {
+		for _, raw := range strings.Split(v, ",") {
+			status := domain.WishStatus(strings.TrimSpace(raw))
+			if !status.Valid() {
+				return query, fmt.Errorf("invalid status: %q", status)
+			}
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
+
+	for _, v := range c.QueryArray("tag") {
+		for _, raw := range strings.Split(v, ",") {
+			tagID, err := uuid.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				return query, fmt.Errorf("invalid tag: %q", raw)
+			}
+			query.TagIDs = append(query.TagIDs, tagID)
+		}
+	}
+
+	switch v := c.Query("tag_mode"); v {
+	case "", "or":
+	case "and":
+		query.TagMatchAll = true
+	default:
+		return query, fmt.Errorf("invalid tag_mode: %q", v)
+	}
+
+	if v := c.Query("view"); v != "" {
+		query.View = domain.WishView(v)
+		if !query.View.Valid() {
+			return query, fmt.Errorf("invalid view: %q", v)
+		}
+	}
+
+	if v := c.Query("assignee"); v != "" {
+		assigneeID, err := uuid.Parse(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid assignee: %q", v)
+		}
+		query.AssigneeID = &assigneeID
+	}
+
+	if v := c.Query("series"); v != "" {
+		seriesID, err := uuid.Parse(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid series: %q", v)
+		}
+		query.SeriesID = &seriesID
+	}
+
+	if v := c.Query("list"); v == "all" {
+		query.AllLists = true
+	} else if v != "" {
+		listID, err := uuid.Parse(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid list: %q", v)
+		}
+		query.ListID = &listID
+	}
+
+	if v := c.Query("include_deleted"); v != "" {
+		includeDeleted, err := strconv.ParseBool(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid include_deleted: %q", v)
+		}
+		query.IncludeDeleted = includeDeleted
+	}
+
+	return query, nil
 }
 
-// GetWishesForCurrentOrg - 現在のユーザーの組織のWish一覧を取得（JWTのorg_idを使用）
-func (h *WishHandler) GetWishesForCurrentOrg(c *gin.Context) {
+// SearchWishes - 組織内のWishをタイトル・メモで全文検索
+// クエリ: q（必須）, limit
+func (h *WishHandler) SearchWishes(c *gin.Context) {
 	orgExternalID := c.GetString("org_external_id")
 
-	if orgExternalID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization ID not found in token"})
+	q := c.Query("q")
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
 		return
 	}
 
-	// external_idから実際の組織を取得してWishを取得
-	wishes, err := h.wishSvc.GetWishesByOrganizationExternalID(c.Request.Context(), orgExternalID)
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid limit: %q", v)})
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.wishSvc.SearchWishes(c.Request.Context(), orgExternalID, q, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWishError(c, err)
 		return
 	}
 
-	// レスポンスを作成
-	responses := make([]WishResponse, len(wishes))
-	for i, wish := range wishes {
-		responses[i] = WishResponse{
-			ID:             wish.ID.String(),
-			OrganizationID: wish.OrganizationID.String(),
-			Title:          wish.Title,
-			Note:           wish.Note,
-			OrderNo:        wish.OrderNo,
-			CreatedAt:      wish.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt:      wish.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	responses := make([]WishSearchResultResponse, len(results))
+	for i, result := range results {
+		responses[i] = WishSearchResultResponse{
+			Wish:         newWishResponse(result.Wish),
+			Rank:         result.Rank,
+			TitleSnippet: result.TitleSnippet,
+			NoteSnippet:  result.NoteSnippet,
 		}
-		if wish.DeletedAt != nil {
-			deletedAtStr := wish.DeletedAt.Format("2006-01-02T15:04:05Z")
-			responses[i].DeletedAt = &deletedAtStr
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": responses})
+}
+
+// GetNearbyWishes - 場所の座標が指定した地点から radius メートル以内のWishを近い順に取得（ゴミ箱を除く全てのリスト）
+// クエリ: lat, lng（必須, WGS 84の度）, radius（メートル, 省略時は5000）, limit
+func (h *WishHandler) GetNearbyWishes(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
+
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid lat: %q", c.Query("lat"))})
+		return
+	}
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid lng: %q", c.Query("lng"))})
+		return
+	}
+
+	radius := 0.0
+	if v := c.Query("radius"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid radius: %q", v)})
+			return
+		}
+		radius = n
+	}
+
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid limit: %q", v)})
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.wishSvc.NearbyWishes(c.Request.Context(), orgExternalID, domain.GeoPoint{Lat: lat, Lng: lng}, radius, limit)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	responses := make([]NearbyWishResponse, len(results))
+	for i, result := range results {
+		responses[i] = NearbyWishResponse{
+			Wish:           newWishResponse(result.Wish),
+			DistanceMeters: result.DistanceMeters,
 		}
 	}
 
@@ -204,23 +789,52 @@ func (h *WishHandler) UpdateWish(c *gin.Context) {
 		return
 	}
 
-	wish, err := h.wishSvc.UpdateWish(c.Request.Context(), wishID, req.Title, req.Note, req.OrderNo)
+	orgExternalID := c.GetString("org_external_id")
+	input := usecase.WishInput{
+		Title:   req.Title,
+		Note:    req.Note,
+		OrderNo: req.OrderNo,
+		TagIDs:  req.TagIDs,
+	}
+	if input.Price, input.ClearPrice, err = parsePriceField(req.Price); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.TargetDate, input.ClearTargetDate, err = parseTargetDateField(req.TargetDate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.AssigneeID, input.ClearAssignee, err = parseAssigneeField(req.AssigneeID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Place, input.ClearPlace, err = parsePlaceField(req.Place); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// レスポンスを作成
-	response := WishResponse{
-		ID:             wish.ID.String(),
-		OrganizationID: wish.OrganizationID.String(),
-		Title:          wish.Title,
-		Note:           wish.Note,
-		OrderNo:        wish.OrderNo,
-		CreatedAt:      wish.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:      wish.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	wish, err := h.wishSvc.UpdateWish(c.Request.Context(), orgExternalID, wishID, user.ID, expectedVersion, input)
+	if err != nil {
+		h.respondWishWriteError(c, wishID, err)
+		return
 	}
 
+	// レスポンスを作成
+	response := newWishResponse(wish)
+
+	c.Header("ETag", wishETag(wish))
 	c.JSON(http.StatusOK, response)
 }
 
@@ -233,17 +847,213 @@ func (h *WishHandler) DeleteWish(c *gin.Context) {
 		return
 	}
 
-	err = h.wishSvc.DeleteWish(c.Request.Context(), wishID)
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	err = h.wishSvc.DeleteWish(c.Request.Context(), orgExternalID, wishID, expectedVersion)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.respondWishWriteError(c, wishID, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Wish deleted successfully"})
 }
 
+// GetTrash - ゴミ箱（ソフトデリート済み）のWish一覧を取得
+func (h *WishHandler) GetTrash(c *gin.Context) {
+	orgExternalID := c.GetString("org_external_id")
+	trashed, err := h.wishSvc.ListTrash(c.Request.Context(), orgExternalID)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	responses := make([]TrashedWishResponse, len(trashed))
+	for i, t := range trashed {
+		responses[i] = TrashedWishResponse{WishResponse: newWishResponse(t.Wish)}
+		if t.PurgeAt != nil {
+			purgeAtStr := t.PurgeAt.Format("2006-01-02T15:04:05Z")
+			responses[i].PurgeAt = &purgeAtStr
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wishes": responses})
+}
+
+// MoveWish - 1件のWishを別のリストへ移す、または同じリストの中で移動する
+// organization_id が別の組織の場合はその組織へ移し（元のWishはゴミ箱に入る）、作られたWishを201で返す
+func (h *WishHandler) MoveWish(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req MoveWishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	if req.OrganizationID != "" && req.OrganizationID != orgExternalID {
+		if req.AfterID != nil || req.BeforeID != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "after_id and before_id cannot be used with organization_id"})
+			return
+		}
+		h.transferWish(c, wishID, domain.WishTransfer{
+			Kind:                   domain.TransferMove,
+			OrganizationExternalID: req.OrganizationID,
+			ListID:                 req.ListID,
+		})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishes, err := h.wishSvc.ReorderWishes(c.Request.Context(), orgExternalID, user.ID, domain.WishReorder{
+		Moves: []domain.WishMove{{ID: wishID, ListID: req.ListID, AfterID: req.AfterID, BeforeID: req.BeforeID}},
+	})
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+	if len(wishes) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": domain.ErrWishNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newWishResponse(wishes[0]))
+}
+
+// CopyWish - Wishを組織（別の組織も可）へコピーする。タイトル・メモ・価格・期日・タグを引き継ぐ
+func (h *WishHandler) CopyWish(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	var req CopyWishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.transferWish(c, wishID, domain.WishTransfer{
+		Kind:                   domain.TransferCopy,
+		OrganizationExternalID: req.OrganizationID,
+		ListID:                 req.ListID,
+	})
+}
+
+// transferWish - コピー・組織間の移動を行い、作られたWishを返す
+func (h *WishHandler) transferWish(c *gin.Context, wishID uuid.UUID, transfer domain.WishTransfer) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wish, err := h.wishSvc.TransferWish(c.Request.Context(), orgExternalID, wishID, user.ID, transfer)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	c.Header("ETag", wishETag(wish))
+	c.JSON(http.StatusCreated, newWishResponse(wish))
+}
+
+// GetConsensus - 組織の全メンバーが賛成票を入れたWishをスコア順に取得
+// クエリ: limit
+func (h *WishHandler) GetConsensus(c *gin.Context) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid limit: %q", v)})
+			return
+		}
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wishes, err := h.wishSvc.ListConsensus(c.Request.Context(), orgExternalID, user.ID, limit)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	responses := make([]WishResponse, len(wishes))
+	for i, wish := range wishes {
+		responses[i] = newWishResponse(wish)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wishes": responses})
+}
+
+// VoteWish - Wishに投票（賛成 1 / 反対 -1）。既に投票済みの場合は上書き
+func (h *WishHandler) VoteWish(c *gin.Context) {
+	var req VoteWishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.setVote(c, domain.VoteValue(req.Value))
+}
+
+// UnvoteWish - 自分の投票を取り消す
+func (h *WishHandler) UnvoteWish(c *gin.Context) {
+	h.setVote(c, domain.VoteNone)
+}
+
+func (h *WishHandler) setVote(c *gin.Context, value domain.VoteValue) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wish, err := h.wishSvc.VoteWish(c.Request.Context(), orgExternalID, wishID, user.ID, value)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newWishResponse(wish))
+}
+
 // SoftDeleteWish - Wishをソフトデリート
 func (h *WishHandler) SoftDeleteWish(c *gin.Context) {
+	subID := c.GetString("sub_id")
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
 	wishIDStr := c.Param("id")
 	wishID, err := uuid.Parse(wishIDStr)
 	if err != nil {
@@ -251,9 +1061,16 @@ func (h *WishHandler) SoftDeleteWish(c *gin.Context) {
 		return
 	}
 
-	err = h.wishSvc.SoftDeleteWish(c.Request.Context(), wishID)
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	err = h.wishSvc.SoftDeleteWish(c.Request.Context(), orgExternalID, wishID, user.ID, expectedVersion)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.respondWishWriteError(c, wishID, err)
 		return
 	}
 
@@ -262,6 +1079,12 @@ func (h *WishHandler) SoftDeleteWish(c *gin.Context) {
 
 // RestoreWish - ソフトデリートされたWishを復元
 func (h *WishHandler) RestoreWish(c *gin.Context) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
 	wishIDStr := c.Param("id")
 	wishID, err := uuid.Parse(wishIDStr)
 	if err != nil {
@@ -269,16 +1092,18 @@ func (h *WishHandler) RestoreWish(c *gin.Context) {
 		return
 	}
 
-	err = h.wishSvc.RestoreWish(c.Request.Context(), wishID)
+	orgExternalID := c.GetString("org_external_id")
+	err = h.wishSvc.RestoreWish(c.Request.Context(), orgExternalID, wishID, user.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondWishError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Wish restored successfully"})
 }
 
-// UpdateWishOrder - Wishの並び順を更新
+// UpdateWishOrder - Wishの order_no を更新し、order_no の大きい順の位置へ移動する
+// Deprecated: 旧API。order_no をrankの移動に置き換えて反映する。新しいクライアントは ReorderWishes を使うこと
 func (h *WishHandler) UpdateWishOrder(c *gin.Context) {
 	wishIDStr := c.Param("id")
 	wishID, err := uuid.Parse(wishIDStr)
@@ -293,11 +1118,93 @@ func (h *WishHandler) UpdateWishOrder(c *gin.Context) {
 		return
 	}
 
-	err = h.wishSvc.UpdateWishOrder(c.Request.Context(), wishID, req.OrderNo)
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	orgExternalID := c.GetString("org_external_id")
+	wish, err := h.wishSvc.UpdateWishOrder(c.Request.Context(), orgExternalID, wishID, user.ID, expectedVersion, *req.OrderNo)
+	if err != nil {
+		h.respondWishWriteError(c, wishID, err)
+		return
+	}
+
+	c.Header("ETag", wishETag(wish))
 	c.JSON(http.StatusOK, gin.H{"message": "Wish order updated successfully"})
 }
+
+// TransitionWish - Wishのstatusを指定した状態へ遷移させるハンドラーを返す
+// 例: POST /wish/:id/fulfill → fulfilled
+func (h *WishHandler) TransitionWish(to domain.WishStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subID := c.GetString("sub_id")
+		user, err := h.userSvc.GetUserBySubID(c.Request.Context(), subID)
+		if err != nil || user == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+			return
+		}
+
+		wishIDStr := c.Param("id")
+		wishID, err := uuid.Parse(wishIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+			return
+		}
+
+		orgExternalID := c.GetString("org_external_id")
+		wish, err := h.wishSvc.TransitionWishStatus(c.Request.Context(), orgExternalID, wishID, user.ID, to)
+		if err != nil {
+			respondWishError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, newWishResponse(wish))
+	}
+}
+
+// ReorderWishes - Wishの並び順を1トランザクションで変更
+func (h *WishHandler) ReorderWishes(c *gin.Context) {
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	var req ReorderWishesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reorder := domain.WishReorder{Order: req.Order}
+	for _, move := range req.Moves {
+		reorder.Moves = append(reorder.Moves, domain.WishMove{
+			ID:       move.ID,
+			ListID:   move.ListID,
+			AfterID:  move.AfterID,
+			BeforeID: move.BeforeID,
+		})
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wishes, err := h.wishSvc.ReorderWishes(c.Request.Context(), orgExternalID, user.ID, reorder)
+	if err != nil {
+		respondWishError(c, err)
+		return
+	}
+
+	responses := make([]WishResponse, len(wishes))
+	for i, wish := range wishes {
+		responses[i] = newWishResponse(wish)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wishes": responses})
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// fakeOrganizationRepository - external_id で組織を引くだけの OrganizationRepository
+type fakeOrganizationRepository struct {
+	domain.OrganizationRepository
+	orgs map[string]*domain.Organization
+}
+
+func (r *fakeOrganizationRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Organization, error) {
+	return r.orgs[externalID], nil
+}
+
+// fakeWishRepository - postgres の実装と同じく、組織が違うWishは見つからないものとして扱う WishRepository
+// 書き込みは writes に記録する
+type fakeWishRepository struct {
+	domain.WishRepository
+	wishes map[uuid.UUID]*domain.Wish
+	writes []string
+}
+
+func (r *fakeWishRepository) find(organizationID, id uuid.UUID) *domain.Wish {
+	wish, ok := r.wishes[id]
+	if !ok || wish.OrganizationID != organizationID {
+		return nil
+	}
+	copied := *wish
+	return &copied
+}
+
+func (r *fakeWishRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	return r.find(organizationID, id), nil
+}
+
+func (r *fakeWishRepository) FindByIDForUpdate(ctx context.Context, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	return r.find(organizationID, id), nil
+}
+
+func (r *fakeWishRepository) FindTagsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*domain.Tag, error) {
+	return map[uuid.UUID][]*domain.Tag{}, nil
+}
+
+func (r *fakeWishRepository) Transaction(ctx context.Context, fn func(repo domain.WishRepository) error) error {
+	return fn(r)
+}
+
+func (r *fakeWishRepository) LockRanks(ctx context.Context, organizationID uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeWishRepository) Update(ctx context.Context, wish *domain.Wish, expectedVersion int) (*domain.Wish, error) {
+	r.writes = append(r.writes, "Update")
+	return wish, nil
+}
+
+func (r *fakeWishRepository) Delete(ctx context.Context, organizationID, id uuid.UUID, expectedVersion int) error {
+	r.writes = append(r.writes, "Delete")
+	return nil
+}
+
+func (r *fakeWishRepository) SoftDelete(ctx context.Context, organizationID, id, deletedBy uuid.UUID, expectedVersion int) error {
+	r.writes = append(r.writes, "SoftDelete")
+	return nil
+}
+
+func (r *fakeWishRepository) Restore(ctx context.Context, organizationID, id uuid.UUID) error {
+	r.writes = append(r.writes, "Restore")
+	return nil
+}
+
+func (r *fakeWishRepository) UpdateRank(ctx context.Context, organizationID, id uuid.UUID, rank string, expectedVersion int) error {
+	r.writes = append(r.writes, "UpdateRank")
+	return nil
+}
+
+func (r *fakeWishRepository) CreateRevision(ctx context.Context, revision *domain.WishRevision) (*domain.WishRevision, error) {
+	r.writes = append(r.writes, "CreateRevision")
+	return revision, nil
+}
+
+type fakeUserUsecase struct {
+	usecase.UserUsecase
+	user *domain.User
+}
+
+func (u *fakeUserUsecase) GetUserBySubID(ctx context.Context, subID string) (*domain.User, error) {
+	return u.user, nil
+}
+
+// newOrgScopeRouter - 組織Bにだけ Wish がある状態で、X-Org ヘッダーの組織のメンバーとしてリクエストするルーター
+func newOrgScopeRouter() (router *gin.Engine, wishes *fakeWishRepository, live, trashed *domain.Wish) {
+	orgA := &domain.Organization{ID: uuid.New(), ExternalID: "org_a"}
+	orgB := &domain.Organization{ID: uuid.New(), ExternalID: "org_b"}
+	deletedAt := time.Now().Add(-time.Hour)
+	live = &domain.Wish{ID: uuid.New(), OrganizationID: orgB.ID, ListID: uuid.New(), Title: "Onsen trip", Rank: "m", Version: 1}
+	trashed = &domain.Wish{ID: uuid.New(), OrganizationID: orgB.ID, ListID: live.ListID, Title: "Old idea", Rank: "t", Version: 1, DeletedAt: &deletedAt}
+
+	wishes = &fakeWishRepository{wishes: map[uuid.UUID]*domain.Wish{live.ID: live, trashed.ID: trashed}}
+	orgs := &fakeOrganizationRepository{orgs: map[string]*domain.Organization{"org_a": orgA, "org_b": orgB}}
+	wishSvc := usecase.NewWishSvc(wishes, orgs, nil, nil, nil, nil, nil, nil, nil)
+	h := NewWishHandler(wishSvc, &fakeUserUsecase{user: &domain.User{ID: uuid.New(), SubID: "user_1"}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router = gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("sub_id", "user_1")
+		c.Set("org_external_id", c.GetHeader("X-Org"))
+	})
+	router.GET("/wish/:id", h.GetWish)
+	router.PUT("/wish/:id", h.UpdateWish)
+	router.DELETE("/wish/:id", h.DeleteWish)
+	router.POST("/wish/:id/soft-delete", h.SoftDeleteWish)
+	router.POST("/wish/:id/restore", h.RestoreWish)
+	router.PATCH("/wish/:id/order", h.UpdateWishOrder)
+	return router, wishes, live, trashed
+}
+
+func serveAs(router *gin.Engine, org, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Org", org)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWishHandlerRespondsNotFoundForOtherOrganizationsWish(t *testing.T) {
+	for _, tt := range []struct {
+		method  string
+		trashed bool // ゴミ箱のWishに対する操作
+		suffix  string
+		body    string
+	}{
+		{http.MethodGet, false, "", ""},
+		{http.MethodPut, false, "", `{"title":"Renamed"}`},
+		{http.MethodDelete, false, "", ""},
+		{http.MethodPost, false, "/soft-delete", ""},
+		{http.MethodPost, true, "/restore", ""},
+		{http.MethodPatch, false, "/order", `{"order_no":1}`},
+	} {
+		router, wishes, live, trashed := newOrgScopeRouter()
+		target := live
+		if tt.trashed {
+			target = trashed
+		}
+		path := "/wish/" + target.ID.String() + tt.suffix
+
+		w := serveAs(router, "org_a", tt.method, path, tt.body)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s %s status = %d, want %d (body %s)", tt.method, path, w.Code, http.StatusNotFound, w.Body.String())
+		}
+		if len(wishes.writes) != 0 {
+			t.Errorf("%s %s wrote %v to another organization's wish", tt.method, path, wishes.writes)
+		}
+	}
+}
+
+func TestWishHandlerSoftDeletesWishOfOwnOrganization(t *testing.T) {
+	// 同じ組織からは見つかること（上のテストが組織の違い以外の理由で404になっていないことの確認）
+	router, wishes, live, _ := newOrgScopeRouter()
+
+	w := serveAs(router, "org_b", http.MethodPost, "/wish/"+live.ID.String()+"/soft-delete", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if len(wishes.writes) == 0 || wishes.writes[0] != "SoftDelete" {
+		t.Errorf("writes = %v, want SoftDelete first", wishes.writes)
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MergePatchContentType - RFC 7396 JSON Merge Patch のメディアタイプ
+const MergePatchContentType = "application/merge-patch+json"
+
+// PatchWish - JSON Merge Patch（RFC 7396）でWishを部分更新
+// 指定したフィールドだけを変更し、null はその値を外す（title・note は空文字、order_no は0になる）
+// price はオブジェクトとして再帰的にマージする（{"price": {"amount": 500}} は通貨を変えずに金額だけ変更）
+// place も同様にマージする（null で場所を外す。全ての項目が空になった場合も外す）
+func (h *WishHandler) PatchWish(c *gin.Context) {
+	wishIDStr := c.Param("id")
+	wishID, err := uuid.Parse(wishIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil || (mediaType != MergePatchContentType && mediaType != "application/json") {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + MergePatchContentType})
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patch, err := parseWishMergePatch(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wish, err := h.wishSvc.PatchWish(c.Request.Context(), orgExternalID, wishID, user.ID, expectedVersion, patch)
+	if err != nil {
+		h.respondWishWriteError(c, wishID, err)
+		return
+	}
+
+	c.Header("ETag", wishETag(wish))
+	c.JSON(http.StatusOK, newWishResponse(wish))
+}
+
+// parseWishMergePatch - マージパッチのJSONオブジェクトを usecase.WishPatch に変換する
+// 変更できないフィールドや未知のフィールドが含まれる場合はエラー
+func parseWishMergePatch(body []byte) (usecase.WishPatch, error) {
+	patch := usecase.WishPatch{Mask: map[usecase.WishField]bool{}}
+
+	fields, err := decodeMergePatchObject(body)
+	if err != nil {
+		return patch, err
+	}
+
+	for name, raw := range fields {
+		switch name {
+		case "title":
+			err = unmarshalNullable(raw, &patch.Title)
+			patch.Mask[usecase.WishFieldTitle] = true
+		case "note":
+			err = unmarshalNullable(raw, &patch.Note)
+			patch.Mask[usecase.WishFieldNote] = true
+		case "order_no":
+			err = unmarshalNullable(raw, &patch.OrderNo)
+			patch.Mask[usecase.WishFieldOrderNo] = true
+		case "tag_ids":
+			// null は空配列と同じく全てのタグを外す
+			patch.TagIDs = []uuid.UUID{}
+			err = unmarshalNullable(raw, &patch.TagIDs)
+			patch.Mask[usecase.WishFieldTagIDs] = true
+		case "price":
+			err = parsePricePatch(raw, &patch)
+		case "place":
+			err = parsePlacePatch(raw, &patch)
+		case "target_date":
+			// null の場合は nil が返り、期日を外す
+			if patch.TargetDate, _, err = parseTargetDateField(raw); err != nil {
+				return patch, err
+			}
+			patch.Mask[usecase.WishFieldTargetDate] = true
+		case "assignee_id":
+			err = unmarshalNullable(raw, &patch.AssigneeID)
+			patch.Mask[usecase.WishFieldAssigneeID] = true
+		default:
+			return patch, fmt.Errorf("field %q cannot be patched", name)
+		}
+		if err != nil {
+			return patch, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return patch, nil
+}
+
+// parsePricePatch - price をマージする。null は価格を外し、オブジェクトは amount・currency を個別に上書きする
+func parsePricePatch(raw json.RawMessage, patch *usecase.WishPatch) error {
+	if string(raw) == "null" {
+		patch.Mask[usecase.WishFieldPriceAmount] = true
+		patch.Mask[usecase.WishFieldPriceCurrency] = true
+		return nil
+	}
+
+	fields, err := decodeMergePatchObject(raw)
+	if err != nil {
+		return err
+	}
+	for name, value := range fields {
+		switch name {
+		case "amount":
+			err = unmarshalNullable(value, &patch.PriceAmount)
+			patch.Mask[usecase.WishFieldPriceAmount] = true
+		case "currency":
+			err = unmarshalNullable(value, &patch.PriceCurrency)
+			patch.Mask[usecase.WishFieldPriceCurrency] = true
+		default:
+			return fmt.Errorf("unknown field %q", name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePlacePatch - place をマージする。null は場所を外し、オブジェクトは項目を個別に上書きする
+func parsePlacePatch(raw json.RawMessage, patch *usecase.WishPatch) error {
+	if string(raw) == "null" {
+		patch.Mask[usecase.WishFieldPlaceName] = true
+		patch.Mask[usecase.WishFieldPlaceAddress] = true
+		patch.Mask[usecase.WishFieldPlaceLat] = true
+		patch.Mask[usecase.WishFieldPlaceLng] = true
+		return nil
+	}
+
+	fields, err := decodeMergePatchObject(raw)
+	if err != nil {
+		return err
+	}
+	for name, value := range fields {
+		switch name {
+		case "name":
+			err = unmarshalNullable(value, &patch.PlaceName)
+			patch.Mask[usecase.WishFieldPlaceName] = true
+		case "address":
+			err = unmarshalNullable(value, &patch.PlaceAddress)
+			patch.Mask[usecase.WishFieldPlaceAddress] = true
+		case "lat":
+			err = unmarshalNullable(value, &patch.PlaceLat)
+			patch.Mask[usecase.WishFieldPlaceLat] = true
+		case "lng":
+			err = unmarshalNullable(value, &patch.PlaceLng)
+			patch.Mask[usecase.WishFieldPlaceLng] = true
+		default:
+			return fmt.Errorf("unknown field %q", name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMergePatchObject - JSONオブジェクトをフィールドごとの生のJSONに分解する
+func decodeMergePatchObject(data []byte) (map[string]json.RawMessage, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, errors.New("merge patch must be a JSON object")
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// unmarshalNullable - null の場合は v をゼロ値のままにする
+func unmarshalNullable(raw json.RawMessage, v interface{}) error {
+	if string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"taine-api/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RevisionActorResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// FieldChangeResponse - old / new は項目の値そのまま（文字列・数値・オブジェクト・null）
+type FieldChangeResponse struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+type WishRevisionResponse struct {
+	Number int `json:"number"`
+	// Actor - 履歴導入前の起点、または退会済みのユーザーの場合はnull
+	Actor        *RevisionActorResponse `json:"actor"`
+	Changes      []FieldChangeResponse  `json:"changes"`
+	RevertedFrom *int                   `json:"reverted_from"`
+	CreatedAt    string                 `json:"created_at"`
+}
+
+func newFieldChangeResponses(changes []domain.FieldChange) []FieldChangeResponse {
+	responses := make([]FieldChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = FieldChangeResponse{Field: change.Field, Old: change.Old, New: change.New}
+	}
+	return responses
+}
+
+func newWishRevisionResponse(revision *domain.WishRevision) WishRevisionResponse {
+	response := WishRevisionResponse{
+		Number:       revision.Number,
+		Changes:      newFieldChangeResponses(revision.Changes),
+		RevertedFrom: revision.RevertedFrom,
+		CreatedAt:    revision.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if revision.Actor != nil {
+		response.Actor = &RevisionActorResponse{
+			ID:        revision.Actor.ID.String(),
+			Name:      revision.Actor.Name,
+			AvatarURL: revision.Actor.AvatarURL,
+		}
+	}
+	return response
+}
+
+// respondRevisionError - 履歴が見つからない場合は404、それ以外はWishと同じ扱い
+func respondRevisionError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrRevisionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	respondWishError(c, err)
+}
+
+// parsePositiveQuery - 正の整数のクエリパラメータを解釈する。省略時は0
+func parsePositiveQuery(c *gin.Context, name string) (int, error) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return n, nil
+}
+
+// GetRevisions - Wishの変更履歴を新しい順に取得
+// クエリ: limit, before（この番号より前の履歴を返す。next_before をそのまま渡す）
+func (h *WishHandler) GetRevisions(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	limit, err := parsePositiveQuery(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	before, err := parsePositiveQuery(c, "before")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	page, err := h.wishSvc.ListRevisions(c.Request.Context(), orgExternalID, wishID, before, limit)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	responses := make([]WishRevisionResponse, len(page.Revisions))
+	for i, revision := range page.Revisions {
+		responses[i] = newWishRevisionResponse(revision)
+	}
+
+	var nextBefore *int
+	if page.NextBefore > 0 {
+		nextBefore = &page.NextBefore
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": responses, "next_before": nextBefore})
+}
+
+// GetRevisionDiff - 2つの履歴の間の項目ごとの差分を取得
+// クエリ: from, to（履歴番号。どちらも必須）
+func (h *WishHandler) GetRevisionDiff(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+
+	from, err := parsePositiveQuery(c, "from")
+	if err != nil || from == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from is required"})
+		return
+	}
+	to, err := parsePositiveQuery(c, "to")
+	if err != nil || to == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to is required"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	changes, err := h.wishSvc.DiffRevisions(c.Request.Context(), orgExternalID, wishID, from, to)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"from": from, "to": to, "changes": newFieldChangeResponses(changes)})
+}
+
+// RevertWish - Wishを指定した履歴の状態に戻す（戻した操作も新しい履歴になる）
+func (h *WishHandler) RevertWish(c *gin.Context) {
+	wishID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wish ID"})
+		return
+	}
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || number <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number"})
+		return
+	}
+
+	user, err := h.userSvc.GetUserBySubID(c.Request.Context(), c.GetString("sub_id"))
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+
+	orgExternalID := c.GetString("org_external_id")
+	wish, err := h.wishSvc.RevertWish(c.Request.Context(), orgExternalID, wishID, user.ID, number)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newWishResponse(wish))
+}
@@ -0,0 +1,61 @@
+package blob
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"taine-api/domain"
+)
+
+// NewFromEnv - 環境変数から BlobStore を作成する
+//
+//	BLOB_STORE=local (既定): BLOB_LOCAL_DIR, BLOB_PUBLIC_URL, BLOB_SIGNING_KEY
+//	BLOB_STORE=s3: S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY, S3_PATH_STYLE
+func NewFromEnv() (domain.BlobStore, error) {
+	switch kind := os.Getenv("BLOB_STORE"); kind {
+	case "", "local":
+		dir := os.Getenv("BLOB_LOCAL_DIR")
+		if dir == "" {
+			dir = "./data/blobs"
+		}
+		publicURL := os.Getenv("BLOB_PUBLIC_URL")
+		if publicURL == "" {
+			publicURL = "http://localhost:8080" + LocalFilesPath
+		}
+		signingKey := []byte(os.Getenv("BLOB_SIGNING_KEY"))
+		if len(signingKey) == 0 {
+			// 未設定の場合は起動ごとに生成する（再起動で発行済みのURLは無効になる）
+			log.Println("BLOB_SIGNING_KEY is not set; using a random key for this process")
+			signingKey = make([]byte, 32)
+			if _, err := rand.Read(signingKey); err != nil {
+				return nil, err
+			}
+		}
+		return NewLocalStore(dir, publicURL, signingKey)
+	case "s3":
+		pathStyle := true // MinIOを想定して既定はパス形式
+		if v := os.Getenv("S3_PATH_STYLE"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("blob: invalid S3_PATH_STYLE %q", v)
+			}
+			pathStyle = b
+		}
+		return NewS3Store(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       pathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("blob: unknown BLOB_STORE %q", kind)
+	}
+}
+
+// LocalFilesPath - LocalStore の署名付きURLを配信するパス
+const LocalFilesPath = "/files"
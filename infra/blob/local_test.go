@@ -0,0 +1,122 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"taine-api/domain"
+)
+
+func newLocalTestStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:8080/files/", []byte("test-signing-key"))
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	if err := store.Put(context.Background(), "org/a.txt", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	return store
+}
+
+// serve - 署名付きURLのパスとクエリで Handler にリクエストする
+func serve(store *LocalStore, rawURL string) *httptest.ResponseRecorder {
+	u, _ := url.Parse(rawURL)
+	w := httptest.NewRecorder()
+	store.Handler("/files").ServeHTTP(w, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	return w
+}
+
+func TestLocalStoreServesSignedURL(t *testing.T) {
+	store := newLocalTestStore(t)
+
+	signed, err := store.SignedURL(context.Background(), "org/a.txt", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(signed, "http://localhost:8080/files/org/a.txt?") {
+		t.Errorf("SignedURL() = %q", signed)
+	}
+
+	w := serve(store, signed)
+	if w.Code != http.StatusOK || w.Body.String() != "hello" {
+		t.Errorf("status = %d, body = %q, want %d, %q", w.Code, w.Body.String(), http.StatusOK, "hello")
+	}
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+	}
+}
+
+func TestLocalStoreRejectsInvalidSignatures(t *testing.T) {
+	store := newLocalTestStore(t)
+	signed, err := store.SignedURL(context.Background(), "org/a.txt", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(signed)
+	query := u.Query()
+
+	withQuery := func(path string, change func(q url.Values)) string {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		change(q)
+		return "http://localhost:8080/files/" + path + "?" + q.Encode()
+	}
+	past := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	for _, tt := range []struct {
+		name string
+		url  string
+	}{
+		{"tampered signature", withQuery("org/a.txt", func(q url.Values) { q.Set("sig", strings.Repeat("0", 64)) })},
+		{"missing signature", withQuery("org/a.txt", func(q url.Values) { q.Del("sig") })},
+		{"extended expiry", withQuery("org/a.txt", func(q url.Values) {
+			q.Set("expires", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		})},
+		{"other key", withQuery("org/b.txt", func(q url.Values) {})},
+		{"expired", withQuery("org/a.txt", func(q url.Values) {
+			q.Set("expires", past)
+			q.Set("sig", store.sign("org/a.txt", past))
+		})},
+		{"malformed expiry", withQuery("org/a.txt", func(q url.Values) { q.Set("expires", "soon") })},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(store, tt.url); w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestLocalStoreRejectsKeysOutsideDir(t *testing.T) {
+	store := newLocalTestStore(t)
+	for _, key := range []string{"", "/etc/passwd", "../secret", "org/../../secret", "org//a.txt", "org/./a.txt"} {
+		if _, err := store.SignedURL(context.Background(), key, time.Minute); err == nil {
+			t.Errorf("SignedURL(%q) error = nil, want an invalid key error", key)
+		}
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("Put(%q) error = nil, want an invalid key error", key)
+		}
+	}
+}
+
+func TestLocalStoreDelete(t *testing.T) {
+	store := newLocalTestStore(t)
+	ctx := context.Background()
+
+	if err := store.Delete(ctx, "org/a.txt"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "org/a.txt"); !errors.Is(err, domain.ErrBlobNotFound) {
+		t.Errorf("second Delete() error = %v, want %v", err, domain.ErrBlobNotFound)
+	}
+}
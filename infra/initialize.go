@@ -16,6 +16,8 @@ func NewDB(dsn string) (*DB, error) {
 	logger := logger.Default.LogMode(logger.Warn)
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger,
+		// 一意制約違反などを gorm.ErrDuplicatedKey 等に変換する
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"context"
+	"log"
+
+	"taine-api/domain"
+)
+
+// LogNotifier - リマインダーをログに出力するだけの Notifier
+// メールやプッシュ通知の実装が入るまでの既定値として使う
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// NotifyReminder - リマインダーを1行のログとして出力する
+func (n *LogNotifier) NotifyReminder(ctx context.Context, notification *domain.ReminderNotification) error {
+	log.Printf("reminder: user=%s org=%s wish=%s title=%q target_date=%s offset=%s",
+		notification.UserID,
+		notification.Wish.OrganizationID,
+		notification.Wish.ID,
+		notification.Wish.Title,
+		notification.TargetDate.Format(domain.DateLayout),
+		notification.Offset,
+	)
+	return nil
+}
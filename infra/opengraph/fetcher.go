@@ -0,0 +1,275 @@
+package opengraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"taine-api/domain"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// Options - 取得時の制限
+type Options struct {
+	// Timeout - リダイレクトを含めた1回の取得全体の制限時間
+	Timeout time.Duration
+	// MaxBodyBytes - 読み込むHTMLの上限。<head>は通常先頭にあるため超えた分は読まない
+	MaxBodyBytes int64
+	MaxRedirects int
+	UserAgent    string
+	// AllowPrivateNetworks - プライベートIPへの接続を許可する（httptestを使うテスト用。本番では false）
+	AllowPrivateNetworks bool
+}
+
+const (
+	defaultTimeout      = 5 * time.Second
+	defaultMaxBodyBytes = 1 << 20
+	defaultMaxRedirects = 5
+	defaultUserAgent    = "taine-api-link-preview/1.0"
+
+	maxTitleLen       = 300
+	maxDescriptionLen = 1000
+	maxSiteNameLen    = 200
+	maxImageURLLen    = 2048
+)
+
+// Fetcher - OpenGraph / Twitter card のメタデータを取得する domain.LinkPreviewFetcher
+// 接続先IPは名前解決後のダイアル時に検査するため、DNSリバインディングやリダイレクトでもプライベートIPに到達しない
+type Fetcher struct {
+	client *http.Client
+	opts   Options
+}
+
+func NewFetcher(opts Options) *Fetcher {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if opts.MaxRedirects <= 0 {
+		opts.MaxRedirects = defaultMaxRedirects
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaultUserAgent
+	}
+
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	if !opts.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddr(addrPort.Addr()) {
+				return domain.ErrUnsafeURL
+			}
+			return nil
+		}
+	}
+
+	transport := &http.Transport{
+		// 環境変数のプロキシを経由すると接続先IPの検査が効かないため使わない
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   opts.Timeout,
+		ResponseHeaderTimeout: opts.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   opts.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= opts.MaxRedirects {
+				return errors.New("opengraph: too many redirects")
+			}
+			return checkScheme(req.URL)
+		},
+	}
+
+	return &Fetcher{client: client, opts: opts}
+}
+
+// reservedPrefixes - netip の判定メソッドで拾えない、外部から到達すべきでない範囲
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // CGNAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"), // ベンチマーク用
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64（IPv4を内包する）
+}
+
+// isPublicAddr - グローバルに到達可能なユニキャストアドレスか
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("opengraph: unsupported scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return errors.New("opengraph: missing host")
+	}
+	return nil
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*domain.LinkPreview, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheme(pageURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.opts.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9")
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnsafeURL) {
+			return nil, domain.ErrUnsafeURL
+		}
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("opengraph: unexpected status %s", res.Status)
+	}
+	contentType := res.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("opengraph: unsupported content type %q", contentType)
+	}
+
+	// Shift_JIS などUTF-8以外のページもあるため、ヘッダー・<meta charset>に従って変換する
+	body, err := charset.NewReader(io.LimitReader(res.Body, f.opts.MaxBodyBytes), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// リダイレクト後のURLを基準に相対パスの画像URLを解決する
+	preview := parseHead(body, res.Request.URL)
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return nil, errors.New("opengraph: no metadata found")
+	}
+	return preview, nil
+}
+
+// parseHead - <head> 内の og:* / twitter:* / <title> を読み取る
+func parseHead(r io.Reader, base *url.URL) *domain.LinkPreview {
+	meta := map[string]string{}
+	var title string
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return buildPreview(meta, title, base)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return buildPreview(meta, title, base)
+			case "title":
+				if title == "" && z.Next() == html.TextToken {
+					title = string(z.Text())
+				}
+			case "meta":
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						key = strings.ToLower(strings.TrimSpace(string(v)))
+					case "content":
+						content = string(v)
+					}
+				}
+				// 同じキーは最初の値を使う
+				if key != "" && content != "" {
+					if _, ok := meta[key]; !ok {
+						meta[key] = content
+					}
+				}
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return buildPreview(meta, title, base)
+			}
+		}
+	}
+}
+
+func buildPreview(meta map[string]string, title string, base *url.URL) *domain.LinkPreview {
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if v := strings.TrimSpace(meta[key]); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	preview := &domain.LinkPreview{
+		Title:       truncate(first("og:title", "twitter:title"), maxTitleLen),
+		Description: truncate(first("og:description", "twitter:description", "description"), maxDescriptionLen),
+		SiteName:    truncate(first("og:site_name", "application-name"), maxSiteNameLen),
+	}
+	if preview.Title == "" {
+		preview.Title = truncate(strings.TrimSpace(title), maxTitleLen)
+	}
+	if preview.SiteName == "" && base != nil {
+		preview.SiteName = base.Hostname()
+	}
+
+	if image := first("og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src"); image != "" {
+		if u, err := base.Parse(image); err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.String()) <= maxImageURLLen {
+			preview.ImageURL = u.String()
+		}
+	}
+	return preview
+}
+
+// truncate - 空白を詰めて最大文字数に切り詰める
+func truncate(s string, maxLen int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	return string([]rune(s)[:maxLen]) + "…"
+}
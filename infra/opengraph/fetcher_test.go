@@ -0,0 +1,179 @@
+package opengraph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"taine-api/domain"
+)
+
+const testPage = `<!doctype html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Sushi Dai">
+<meta property="og:site_name" content="Tabelog">
+<meta property="og:image" content="/images/sushi.jpg">
+</head><body><p>body</p></body></html>`
+
+// newTestServer - /page でテスト用のページを返し、それ以外は handlers に任せる
+func newTestServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(testPage))
+	})
+	for path, handler := range handlers {
+		mux.HandleFunc(path, handler)
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchReadsOpenGraph(t *testing.T) {
+	server := newTestServer(t, nil)
+	fetcher := NewFetcher(Options{AllowPrivateNetworks: true})
+
+	preview, err := fetcher.Fetch(context.Background(), server.URL+"/page")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if preview.Title != "Sushi Dai" {
+		t.Errorf("Title = %q, want %q", preview.Title, "Sushi Dai")
+	}
+	if preview.SiteName != "Tabelog" {
+		t.Errorf("SiteName = %q, want %q", preview.SiteName, "Tabelog")
+	}
+	if want := server.URL + "/images/sushi.jpg"; preview.ImageURL != want {
+		t.Errorf("ImageURL = %q, want %q", preview.ImageURL, want)
+	}
+}
+
+func TestFetchRefusesPrivateNetworks(t *testing.T) {
+	// httptest のサーバーはループバックで待ち受けるため、既定の設定では接続を拒否する
+	server := newTestServer(t, nil)
+	fetcher := NewFetcher(Options{})
+
+	_, err := fetcher.Fetch(context.Background(), server.URL+"/page")
+	if !errors.Is(err, domain.ErrUnsafeURL) {
+		t.Fatalf("Fetch() error = %v, want %v", err, domain.ErrUnsafeURL)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for _, tt := range []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::7f00:1", false},
+	} {
+		if got := isPublicAddr(mustParseAddr(t, tt.addr)); got != tt.want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestFetchFollowsRedirects(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/short": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/page", http.StatusFound)
+		},
+	})
+	fetcher := NewFetcher(Options{AllowPrivateNetworks: true})
+
+	preview, err := fetcher.Fetch(context.Background(), server.URL+"/short")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	// 相対パスの画像はリダイレクト後のURLを基準に解決する
+	if want := server.URL + "/images/sushi.jpg"; preview.ImageURL != want {
+		t.Errorf("ImageURL = %q, want %q", preview.ImageURL, want)
+	}
+}
+
+func TestFetchStopsAfterMaxRedirects(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/loop": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		},
+	})
+	fetcher := NewFetcher(Options{AllowPrivateNetworks: true, MaxRedirects: 3})
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/loop"); err == nil {
+		t.Fatal("Fetch() error = nil, want too many redirects")
+	}
+}
+
+func TestFetchRefusesRedirectToUnsupportedScheme(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/file": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		},
+	})
+	fetcher := NewFetcher(Options{AllowPrivateNetworks: true})
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/file"); err == nil {
+		t.Fatal("Fetch() error = nil, want unsupported scheme")
+	}
+}
+
+func TestFetchReadsAtMostMaxBodyBytes(t *testing.T) {
+	// メタデータが上限より後ろにあるページは読まない
+	padding := "<!-- " + strings.Repeat("x", 4096) + " -->"
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/large": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html><head>" + padding + `<meta property="og:title" content="Too far"></head></html>`))
+		},
+	})
+	fetcher := NewFetcher(Options{AllowPrivateNetworks: true, MaxBodyBytes: 1024})
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/large"); err == nil {
+		t.Fatal("Fetch() error = nil, want no metadata found")
+	}
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/page"); err != nil {
+		t.Fatalf("Fetch() of a page within the limit error = %v", err)
+	}
+}
+
+func TestFetchRejectsNonHTML(t *testing.T) {
+	server := newTestServer(t, map[string]http.HandlerFunc{
+		"/image": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("\x89PNG"))
+		},
+	})
+	fetcher := NewFetcher(Options{AllowPrivateNetworks: true})
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/image"); err == nil {
+		t.Fatal("Fetch() error = nil, want unsupported content type")
+	}
+}
+
+func mustParseAddr(t *testing.T, s string) netip.Addr {
+	t.Helper()
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		t.Fatalf("ParseAddr(%q) error = %v", s, err)
+	}
+	return addr
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) domain.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) (*domain.Attachment, error) {
+	row := &models.WishAttachment{
+		ID:             attachment.ID,
+		OrganizationID: attachment.OrganizationID,
+		WishID:         attachment.WishID,
+		UploadedBy:     attachment.UploadedBy,
+		FileName:       attachment.FileName,
+		ContentType:    attachment.ContentType,
+		Size:           attachment.Size,
+		Width:          attachment.Width,
+		Height:         attachment.Height,
+		BlobKey:        attachment.BlobKey,
+		ThumbnailKey:   attachment.ThumbnailKey,
+	}
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainAttachment(row), nil
+}
+
+func (r *attachmentRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Attachment, error) {
+	var row models.WishAttachment
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainAttachment(&row), nil
+}
+
+func (r *attachmentRepository) FindByWishID(ctx context.Context, organizationID, wishID uuid.UUID) ([]*domain.Attachment, error) {
+	var rows []models.WishAttachment
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND wish_id = ?", organizationID, wishID).
+		Order("created_at, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*domain.Attachment, len(rows))
+	for i, row := range rows {
+		attachments[i] = toDomainAttachment(&row)
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) FindByTrashedBefore(ctx context.Context, organizationID uuid.UUID, before time.Time) ([]*domain.Attachment, error) {
+	var rows []models.WishAttachment
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Where(`wish_id IN (
+			SELECT id FROM wishes
+			WHERE organization_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?)`, organizationID, before).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*domain.Attachment, len(rows))
+	for i, row := range rows {
+		attachments[i] = toDomainAttachment(&row)
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.WishAttachment{}, "id = ? AND organization_id = ?", id, organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func toDomainAttachment(row *models.WishAttachment) *domain.Attachment {
+	return &domain.Attachment{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		UploadedBy:     row.UploadedBy,
+		FileName:       row.FileName,
+		ContentType:    row.ContentType,
+		Size:           row.Size,
+		Width:          row.Width,
+		Height:         row.Height,
+		BlobKey:        row.BlobKey,
+		ThumbnailKey:   row.ThumbnailKey,
+		CreatedAt:      row.CreatedAt,
+	}
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) domain.CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+func (r *calendarFeedRepository) FindByUserAndOrg(ctx context.Context, userID, organizationID uuid.UUID) (*domain.CalendarFeed, error) {
+	var row models.CalendarFeed
+	if err := r.db.WithContext(ctx).First(&row, "user_id = ? AND organization_id = ?", userID, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainCalendarFeed(&row), nil
+}
+
+func (r *calendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	var row models.CalendarFeed
+	if err := r.db.WithContext(ctx).First(&row, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainCalendarFeed(&row), nil
+}
+
+func (r *calendarFeedRepository) Rotate(ctx context.Context, userID, organizationID uuid.UUID, tokenHash, tokenPrefix string) (*domain.CalendarFeed, error) {
+	row := &models.CalendarFeed{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		TokenHash:      tokenHash,
+		TokenPrefix:    tokenPrefix,
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"token_hash":   tokenHash,
+					"token_prefix": tokenPrefix,
+					"rotated_at":   gorm.Expr("now()"),
+				}),
+			},
+			clause.Returning{}, // Postgres: RETURNING *
+		).
+		Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainCalendarFeed(row), nil
+}
+
+func (r *calendarFeedRepository) DeleteByUserAndOrg(ctx context.Context, userID, organizationID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.CalendarFeed{}, "user_id = ? AND organization_id = ?", userID, organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCalendarFeedNotFound
+	}
+	return nil
+}
+
+func (r *calendarFeedRepository) RecordFetch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.CalendarFeed{}).
+		Where("id = ?", id).
+		Update("last_fetched_at", at).Error
+}
+
+func toDomainCalendarFeed(row *models.CalendarFeed) *domain.CalendarFeed {
+	return &domain.CalendarFeed{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		UserID:         row.UserID,
+		TokenHash:      row.TokenHash,
+		TokenPrefix:    row.TokenPrefix,
+		CreatedAt:      row.CreatedAt,
+		RotatedAt:      row.RotatedAt,
+		LastFetchedAt:  row.LastFetchedAt,
+	}
+}
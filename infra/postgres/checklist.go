@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *wishRepository) FindChecklistItems(ctx context.Context, wishID uuid.UUID) ([]*domain.ChecklistItem, error) {
+	var rows []models.WishChecklistItem
+	if err := r.db.WithContext(ctx).
+		Where("wish_id = ?", wishID).
+		Order("rank, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.ChecklistItem, len(rows))
+	for i, row := range rows {
+		items[i] = toDomainChecklistItem(&row)
+	}
+	return items, nil
+}
+
+func (r *wishRepository) FindChecklistItem(ctx context.Context, wishID, id uuid.UUID) (*domain.ChecklistItem, error) {
+	var row models.WishChecklistItem
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND wish_id = ?", id, wishID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainChecklistItem(&row), nil
+}
+
+func (r *wishRepository) CreateChecklistItem(ctx context.Context, item *domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	row := &models.WishChecklistItem{
+		OrganizationID: item.OrganizationID,
+		WishID:         item.WishID,
+		Title:          item.Title,
+		Rank:           item.Rank,
+		AssigneeID:     item.AssigneeID,
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+	return toDomainChecklistItem(row), nil
+}
+
+func (r *wishRepository) UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) (*domain.ChecklistItem, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.WishChecklistItem{}).
+		Where("id = ? AND wish_id = ?", item.ID, item.WishID).
+		Updates(map[string]interface{}{
+			"title":        item.Title,
+			"rank":         item.Rank,
+			"assignee_id":  item.AssigneeID,
+			"completed_at": item.CompletedAt,
+			"completed_by": item.CompletedBy,
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+	return r.FindChecklistItem(ctx, item.WishID, item.ID)
+}
+
+func (r *wishRepository) DeleteChecklistItem(ctx context.Context, wishID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.WishChecklistItem{}, "id = ? AND wish_id = ?", id, wishID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+	return nil
+}
+
+func (r *wishRepository) CountChecklistByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID]domain.ChecklistProgress, error) {
+	progress := make(map[uuid.UUID]domain.ChecklistProgress, len(wishIDs))
+	if len(wishIDs) == 0 {
+		return progress, nil
+	}
+
+	var rows []struct {
+		WishID uuid.UUID
+		Done   int
+		Total  int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.WishChecklistItem{}).
+		Select("wish_id, COUNT(completed_at) AS done, COUNT(*) AS total").
+		Where("wish_id IN ?", wishIDs).
+		Group("wish_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		progress[row.WishID] = domain.ChecklistProgress{Done: row.Done, Total: row.Total}
+	}
+	return progress, nil
+}
+
+func toDomainChecklistItem(row *models.WishChecklistItem) *domain.ChecklistItem {
+	return &domain.ChecklistItem{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		Title:          row.Title,
+		Rank:           row.Rank,
+		AssigneeID:     row.AssigneeID,
+		CompletedAt:    row.CompletedAt,
+		CompletedBy:    row.CompletedBy,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
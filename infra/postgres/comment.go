@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) domain.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// commentRow - 一覧取得時に返信数も合わせて読み込むための行
+type commentRow struct {
+	models.WishComment `gorm:"embedded"`
+	ReplyCount         int `gorm:"column:reply_count"`
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	row := &models.WishComment{
+		OrganizationID: comment.OrganizationID,
+		WishID:         comment.WishID,
+		ParentID:       comment.ParentID,
+		AuthorID:       comment.AuthorID,
+		Body:           comment.Body,
+	}
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainComment(row, 0), nil
+}
+
+func (r *commentRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Comment, error) {
+	var row commentRow
+	if err := r.db.WithContext(ctx).
+		Model(&models.WishComment{}).
+		Select("wish_comments.*, (SELECT COUNT(*) FROM wish_comments AS replies WHERE replies.parent_id = wish_comments.id) AS reply_count").
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		Take(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainComment(&row.WishComment, row.ReplyCount), nil
+}
+
+func (r *commentRepository) List(ctx context.Context, organizationID, wishID uuid.UUID, query domain.CommentListQuery) ([]*domain.Comment, error) {
+	tx := r.db.WithContext(ctx).
+		Model(&models.WishComment{}).
+		Select("wish_comments.*, (SELECT COUNT(*) FROM wish_comments AS replies WHERE replies.parent_id = wish_comments.id) AS reply_count").
+		Where("organization_id = ? AND wish_id = ?", organizationID, wishID)
+
+	if query.ParentID != nil {
+		tx = tx.Where("parent_id = ?", *query.ParentID)
+	} else {
+		tx = tx.Where("parent_id IS NULL")
+	}
+
+	// keyset: 古い順
+	if query.After != nil {
+		tx = tx.Where("(created_at, id) > (?, ?)", query.After.CreatedAt, query.After.ID)
+	}
+
+	var rows []commentRow
+	if err := tx.Order("created_at ASC, id ASC").Limit(query.Limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	comments := make([]*domain.Comment, len(rows))
+	for i, row := range rows {
+		comments[i] = toDomainComment(&row.WishComment, row.ReplyCount)
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) UpdateBody(ctx context.Context, organizationID, id uuid.UUID, body string) (*domain.Comment, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.WishComment{}).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		Updates(map[string]interface{}{
+			"body":       body,
+			"edited_at":  now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	return r.FindByID(ctx, organizationID, id)
+}
+
+func (r *commentRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	// 返信は parent_id の ON DELETE CASCADE で削除される
+	result := r.db.WithContext(ctx).Delete(&models.WishComment{}, "id = ? AND organization_id = ?", id, organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+func toDomainComment(row *models.WishComment, replyCount int) *domain.Comment {
+	return &domain.Comment{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		ParentID:       row.ParentID,
+		AuthorID:       row.AuthorID,
+		Body:           row.Body,
+		ReplyCount:     replyCount,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+		EditedAt:       row.EditedAt,
+	}
+}
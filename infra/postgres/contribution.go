@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type contributionRepository struct {
+	db *gorm.DB
+}
+
+func NewContributionRepository(db *gorm.DB) domain.ContributionRepository {
+	return &contributionRepository{db: db}
+}
+
+func (r *contributionRepository) Create(ctx context.Context, contribution *domain.Contribution) (*domain.Contribution, error) {
+	row := &models.WishContribution{
+		OrganizationID: contribution.OrganizationID,
+		WishID:         contribution.WishID,
+		MemberID:       contribution.MemberID,
+		Amount:         contribution.Amount.Amount,
+		Currency:       contribution.Amount.Currency,
+		Note:           contribution.Note,
+		ContributedAt:  contribution.ContributedAt,
+	}
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainContribution(row), nil
+}
+
+func (r *contributionRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Contribution, error) {
+	var row models.WishContribution
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainContribution(&row), nil
+}
+
+func (r *contributionRepository) FindByWishID(ctx context.Context, organizationID, wishID uuid.UUID) ([]*domain.Contribution, error) {
+	var rows []models.WishContribution
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND wish_id = ?", organizationID, wishID).
+		Order("contributed_at DESC, id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	contributions := make([]*domain.Contribution, len(rows))
+	for i, row := range rows {
+		contributions[i] = toDomainContribution(&row)
+	}
+	return contributions, nil
+}
+
+func (r *contributionRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.WishContribution{}, "id = ? AND organization_id = ?", id, organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrContributionNotFound
+	}
+	return nil
+}
+
+func (r *contributionRepository) SumByWishIDs(ctx context.Context, organizationID uuid.UUID, wishIDs []uuid.UUID) ([]*domain.ContributionTotal, error) {
+	if len(wishIDs) == 0 {
+		return []*domain.ContributionTotal{}, nil
+	}
+
+	var rows []struct {
+		WishID   uuid.UUID
+		Currency string
+		Amount   int64
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.WishContribution{}).
+		Select("wish_id, currency, SUM(amount)::bigint AS amount").
+		Where("organization_id = ? AND wish_id IN ?", organizationID, wishIDs).
+		Group("wish_id, currency").
+		Order("currency").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.ContributionTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.ContributionTotal{WishID: row.WishID, Currency: row.Currency, Amount: row.Amount}
+	}
+	return totals, nil
+}
+
+func toDomainContribution(row *models.WishContribution) *domain.Contribution {
+	return &domain.Contribution{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		MemberID:       row.MemberID,
+		Amount:         domain.Money{Amount: row.Amount, Currency: row.Currency},
+		Note:           row.Note,
+		ContributedAt:  row.ContributedAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
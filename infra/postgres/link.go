@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type linkRepository struct {
+	db *gorm.DB
+}
+
+func NewLinkRepository(db *gorm.DB) domain.LinkRepository {
+	return &linkRepository{db: db}
+}
+
+func (r *linkRepository) Create(ctx context.Context, link *domain.WishLink) (*domain.WishLink, error) {
+	row := &models.WishLink{
+		OrganizationID: link.OrganizationID,
+		WishID:         link.WishID,
+		URL:            link.URL,
+		Status:         string(domain.LinkStatusPending),
+	}
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainLink(row), nil
+}
+
+func (r *linkRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.WishLink, error) {
+	var row models.WishLink
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainLink(&row), nil
+}
+
+func (r *linkRepository) CountByWishID(ctx context.Context, wishID uuid.UUID) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.WishLink{}).Where("wish_id = ?", wishID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *linkRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.WishLink{}, "id = ? AND organization_id = ?", id, organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *linkRepository) MarkPending(ctx context.Context, organizationID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.WishLink{}).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		Updates(map[string]interface{}{
+			"status":      string(domain.LinkStatusPending),
+			"fetch_error": "",
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *linkRepository) FindPending(ctx context.Context, limit int) ([]*domain.WishLink, error) {
+	var rows []models.WishLink
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", string(domain.LinkStatusPending)).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	links := make([]*domain.WishLink, len(rows))
+	for i, row := range rows {
+		links[i] = toDomainLink(&row)
+	}
+	return links, nil
+}
+
+func (r *linkRepository) SaveResult(ctx context.Context, id uuid.UUID, preview *domain.LinkPreview, fetchErr error) error {
+	updates := map[string]interface{}{
+		"fetched_at": time.Now(),
+	}
+	if preview != nil {
+		updates["status"] = string(domain.LinkStatusReady)
+		updates["title"] = preview.Title
+		updates["description"] = preview.Description
+		updates["image_url"] = preview.ImageURL
+		updates["site_name"] = preview.SiteName
+		updates["fetch_error"] = ""
+	} else {
+		// 失敗時は以前取得できたプレビューを残す
+		updates["status"] = string(domain.LinkStatusFailed)
+		if fetchErr != nil {
+			updates["fetch_error"] = fetchErr.Error()
+		}
+	}
+
+	return r.db.WithContext(ctx).Model(&models.WishLink{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func toDomainLink(row *models.WishLink) *domain.WishLink {
+	return &domain.WishLink{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		URL:            row.URL,
+		Status:         domain.LinkStatus(row.Status),
+		Preview: domain.LinkPreview{
+			Title:       row.Title,
+			Description: row.Description,
+			ImageURL:    row.ImageURL,
+			SiteName:    row.SiteName,
+		},
+		FetchError: row.FetchError,
+		FetchedAt:  row.FetchedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
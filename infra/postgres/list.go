@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type listRepository struct {
+	db *gorm.DB
+}
+
+func NewListRepository(db *gorm.DB) domain.ListRepository {
+	return &listRepository{db: db}
+}
+
+func (r *listRepository) Create(ctx context.Context, list *domain.List) (*domain.List, error) {
+	row := &models.List{
+		OrganizationID: list.OrganizationID,
+		Name:           list.Name,
+		Icon:           list.Icon,
+		Rank:           list.Rank,
+	}
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainList(row), nil
+}
+
+func (r *listRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.List, error) {
+	var row models.List
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainList(&row), nil
+}
+
+func (r *listRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID, includeArchived bool) ([]*domain.List, error) {
+	tx := r.db.WithContext(ctx).Where("organization_id = ?", organizationID)
+	if !includeArchived {
+		tx = tx.Where("archived_at IS NULL")
+	}
+
+	var rows []models.List
+	if err := tx.Order("rank, id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	lists := make([]*domain.List, len(rows))
+	for i, row := range rows {
+		lists[i] = toDomainList(&row)
+	}
+	return lists, nil
+}
+
+func (r *listRepository) EnsureDefault(ctx context.Context, organizationID uuid.UUID) (*domain.List, error) {
+	var row models.List
+	err := r.db.WithContext(ctx).First(&row, "organization_id = ? AND is_default", organizationID).Error
+	if err == nil {
+		return toDomainList(&row), nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	// 既にあるリストより前に置く
+	var ranks []string
+	if err := r.db.WithContext(ctx).
+		Model(&models.List{}).
+		Where("organization_id = ?", organizationID).
+		Order("rank").
+		Limit(1).
+		Pluck("rank", &ranks).Error; err != nil {
+		return nil, err
+	}
+	var first string
+	if len(ranks) > 0 {
+		first = ranks[0]
+	}
+	rank, err := domain.RankBetween("", first)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同時に作られた場合は uq_wish_lists_default により片方だけが残る
+	row = models.List{
+		OrganizationID: organizationID,
+		Name:           domain.DefaultListName,
+		Rank:           rank,
+		IsDefault:      true,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).First(&row, "organization_id = ? AND is_default", organizationID).Error; err != nil {
+		return nil, err
+	}
+	return toDomainList(&row), nil
+}
+
+func (r *listRepository) Update(ctx context.Context, list *domain.List) (*domain.List, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.List{}).
+		Where("id = ? AND organization_id = ?", list.ID, list.OrganizationID).
+		Updates(map[string]interface{}{
+			"name":        list.Name,
+			"icon":        list.Icon,
+			"archived_at": list.ArchivedAt,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrListNotFound
+	}
+	return r.FindByID(ctx, list.OrganizationID, list.ID)
+}
+
+func (r *listRepository) UpdateRanks(ctx context.Context, organizationID uuid.UUID, ids []uuid.UUID, ranks []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for i, id := range ids {
+			result := tx.Model(&models.List{}).
+				Where("id = ? AND organization_id = ?", id, organizationID).
+				Updates(map[string]interface{}{"rank": ranks[i], "updated_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return domain.ErrListNotFound
+			}
+		}
+		return nil
+	})
+}
+
+func (r *listRepository) Delete(ctx context.Context, organizationID, id, moveTrashTo uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 確認から削除までの間にWishが追加されないよう、リストの行をロックする
+		var row models.List
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return domain.ErrListNotFound
+			}
+			return err
+		}
+
+		var live int64
+		if err := tx.Model(&models.Wish{}).
+			Where("list_id = ? AND deleted_at IS NULL", id).
+			Count(&live).Error; err != nil {
+			return err
+		}
+		if live > 0 {
+			return domain.ErrListNotEmpty
+		}
+
+		if err := tx.Model(&models.Wish{}).
+			Where("list_id = ?", id).
+			Update("list_id", moveTrashTo).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.List{}, "id = ?", id).Error
+	})
+}
+
+func toDomainList(row *models.List) *domain.List {
+	return &domain.List{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Name:           row.Name,
+		Icon:           row.Icon,
+		Rank:           row.Rank,
+		IsDefault:      row.IsDefault,
+		ArchivedAt:     row.ArchivedAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
@@ -58,3 +58,19 @@ func (r *membershipRepository) SoftDeleteByUserAndOrg(ctx context.Context, userI
 	}
 	return nil
 }
+
+func (r *membershipRepository) FindByUserAndOrg(ctx context.Context, userID, orgID uuid.UUID) (*domain.OrganizationMember, error) {
+	var row models.OrganizationMember
+	if err := r.db.WithContext(ctx).First(&row, "user_id = ? AND organization_id = ?", userID, orgID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.OrganizationMember{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		OrganizationID: row.OrganizationID,
+		Role:           row.Role,
+	}, nil
+}
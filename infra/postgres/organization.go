@@ -6,6 +6,7 @@ import (
 	"taine-api/domain"
 	"taine-api/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -38,11 +39,7 @@ func (r *organizationRepository) UpsertByExternalID(ctx context.Context, externa
 		return nil, err
 	}
 
-	return &domain.Organization{
-		ID:         row.ID,
-		ExternalID: row.ExternalID,
-		Name:       row.Name,
-	}, nil
+	return r.toDomain(row), nil
 }
 
 func (r *organizationRepository) SoftDeleteByExternalID(ctx context.Context, externalID string) error {
@@ -65,9 +62,83 @@ func (r *organizationRepository) FindByExternalID(ctx context.Context, externalI
 		}
 		return nil, err
 	}
+	return r.toDomain(&row), nil
+}
+
+func (r *organizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var row models.Organization
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.toDomain(&row), nil
+}
+
+func (r *organizationRepository) FindAll(ctx context.Context) ([]*domain.Organization, error) {
+	var rows []models.Organization
+	if err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	orgs := make([]*domain.Organization, len(rows))
+	for i, row := range rows {
+		orgs[i] = r.toDomain(&row)
+	}
+	return orgs, nil
+}
+
+func (r *organizationRepository) UpdateTrashRetentionDays(ctx context.Context, id uuid.UUID, days int) (*domain.Organization, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Organization{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"trash_retention_days": days,
+			"updated_at":           gorm.Expr("now()"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	var row models.Organization
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomain(&row), nil
+}
+
+func (r *organizationRepository) UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) (*domain.Organization, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Organization{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"timezone":   timezone,
+			"updated_at": gorm.Expr("now()"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	var row models.Organization
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomain(&row), nil
+}
+
+func (r *organizationRepository) toDomain(row *models.Organization) *domain.Organization {
 	return &domain.Organization{
-		ID:         row.ID,
-		ExternalID: row.ExternalID,
-		Name:       row.Name,
-	}, nil
+		ID:                 row.ID,
+		ExternalID:         row.ExternalID,
+		Name:               row.Name,
+		TrashRetentionDays: row.TrashRetentionDays,
+		Timezone:           row.Timezone,
+	}
 }
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) domain.ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+func (r *reminderRepository) FindByWishAndUser(ctx context.Context, wishID, userID uuid.UUID) ([]*domain.WishReminder, error) {
+	var rows []models.WishReminder
+	if err := r.db.WithContext(ctx).
+		Where("wish_id = ? AND user_id = ?", wishID, userID).
+		Order("offset_days DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*domain.WishReminder, len(rows))
+	for i, row := range rows {
+		reminders[i] = toDomainReminder(&row)
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepository) ReplaceForUser(ctx context.Context, organizationID, wishID, userID uuid.UUID, offsets []domain.ReminderOffset) ([]*domain.WishReminder, error) {
+	days := make([]int, len(offsets))
+	for i, offset := range offsets {
+		days[i] = offset.Days()
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 残すオフセットは送信済みの状態を保つため、消すものだけ削除して足りないものを追加する
+		del := tx.Where("wish_id = ? AND user_id = ?", wishID, userID)
+		if len(days) > 0 {
+			del = del.Where("offset_days NOT IN ?", days)
+		}
+		if err := del.Delete(&models.WishReminder{}).Error; err != nil {
+			return err
+		}
+
+		var existing []int
+		if err := tx.Model(&models.WishReminder{}).
+			Where("wish_id = ? AND user_id = ?", wishID, userID).
+			Pluck("offset_days", &existing).Error; err != nil {
+			return err
+		}
+		have := make(map[int]bool, len(existing))
+		for _, d := range existing {
+			have[d] = true
+		}
+
+		for _, d := range days {
+			if have[d] {
+				continue
+			}
+			row := &models.WishReminder{
+				OrganizationID: organizationID,
+				WishID:         wishID,
+				UserID:         userID,
+				OffsetDays:     d,
+			}
+			if err := tx.Create(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByWishAndUser(ctx, wishID, userID)
+}
+
+func (r *reminderRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*domain.WishReminder, error) {
+	statuses := make([]string, len(domain.OpenWishStatuses))
+	for i, status := range domain.OpenWishStatuses {
+		statuses[i] = string(status)
+	}
+
+	// 「今日」は組織のタイムゾーンで判定する。組織を抜けたメンバーのリマインダーは送らない
+	var rows []struct {
+		models.WishReminder `gorm:"embedded"`
+		DueFor              time.Time `gorm:"column:due_for"`
+	}
+	if err := r.db.WithContext(ctx).
+		Table("wish_reminders AS r").
+		Select("r.*, w.target_date AS due_for").
+		Joins("JOIN wishes w ON w.id = r.wish_id").
+		Joins("JOIN organizations o ON o.id = w.organization_id").
+		Joins("JOIN organization_members m ON m.organization_id = w.organization_id AND m.user_id = r.user_id").
+		Where("w.deleted_at IS NULL AND o.deleted_at IS NULL").
+		Where("w.status IN ?", statuses).
+		Where("w.target_date IS NOT NULL").
+		Where("r.sent_for IS DISTINCT FROM w.target_date").
+		Where("w.target_date >= (?::timestamptz AT TIME ZONE o.timezone)::date", now).
+		Where("w.target_date - r.offset_days <= (?::timestamptz AT TIME ZONE o.timezone)::date", now).
+		Order("w.target_date, r.id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*domain.WishReminder, len(rows))
+	for i, row := range rows {
+		reminders[i] = toDomainReminder(&row.WishReminder)
+		dueFor := row.DueFor
+		reminders[i].DueFor = &dueFor
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepository) MarkSent(ctx context.Context, id uuid.UUID, targetDate time.Time) (bool, error) {
+	// 他のワーカーが先に送った場合は0件になる
+	result := r.db.WithContext(ctx).
+		Model(&models.WishReminder{}).
+		Where("id = ? AND sent_for IS DISTINCT FROM ?", id, targetDate).
+		Update("sent_for", targetDate)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *reminderRepository) UnmarkSent(ctx context.Context, id uuid.UUID, targetDate time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.WishReminder{}).
+		Where("id = ? AND sent_for = ?", id, targetDate).
+		Update("sent_for", nil).Error
+}
+
+func toDomainReminder(row *models.WishReminder) *domain.WishReminder {
+	return &domain.WishReminder{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		UserID:         row.UserID,
+		Offset:         domain.ReminderOffset(row.OffsetDays),
+		SentFor:        row.SentFor,
+		CreatedAt:      row.CreatedAt,
+	}
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type wishSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewWishSeriesRepository(db *gorm.DB) domain.WishSeriesRepository {
+	return &wishSeriesRepository{db: db}
+}
+
+func (r *wishSeriesRepository) Create(ctx context.Context, series *domain.WishSeries) (*domain.WishSeries, error) {
+	tagIDs, err := json.Marshal(series.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &models.WishSeries{
+		OrganizationID: series.OrganizationID,
+		Title:          series.Title,
+		Note:           series.Note,
+		TagIDs:         string(tagIDs),
+		AssigneeID:     series.AssigneeID,
+		ListID:         series.ListID,
+		Rule:           series.Rule.String(),
+		StartDate:      series.StartDate,
+		Status:         string(series.Status),
+		NextDate:       series.NextDate,
+		CreatedBy:      series.CreatedBy,
+	}
+	row.PriceAmount, row.PriceCurrency = priceColumns(series.Price)
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+	return toDomainSeries(row)
+}
+
+func (r *wishSeriesRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.WishSeries, error) {
+	var row models.WishSeries
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainSeries(&row)
+}
+
+func (r *wishSeriesRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.WishSeries, error) {
+	var rows []models.WishSeries
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toDomainSeriesList(rows)
+}
+
+func (r *wishSeriesRepository) UpdateTemplate(ctx context.Context, series *domain.WishSeries) (*domain.WishSeries, error) {
+	tagIDs, err := json.Marshal(series.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	priceAmount, priceCurrency := priceColumns(series.Price)
+
+	result := r.db.WithContext(ctx).
+		Model(&models.WishSeries{}).
+		Where("id = ? AND organization_id = ?", series.ID, series.OrganizationID).
+		Updates(map[string]interface{}{
+			"title":          series.Title,
+			"note":           series.Note,
+			"price_amount":   priceAmount,
+			"price_currency": priceCurrency,
+			"tag_ids":        string(tagIDs),
+			"assignee_id":    series.AssigneeID,
+			"list_id":        series.ListID,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrSeriesNotFound
+	}
+	return r.FindByID(ctx, series.OrganizationID, series.ID)
+}
+
+func (r *wishSeriesRepository) UpdateStatus(ctx context.Context, series *domain.WishSeries, from domain.SeriesStatus) (*domain.WishSeries, error) {
+	// 現在のstatusが変わっていない場合のみ更新（同時操作の競合を防ぐ）
+	result := r.db.WithContext(ctx).
+		Model(&models.WishSeries{}).
+		Where("id = ? AND organization_id = ? AND status = ?", series.ID, series.OrganizationID, string(from)).
+		Updates(map[string]interface{}{
+			"status":     string(series.Status),
+			"next_date":  series.NextDate,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrInvalidSeriesTransition
+	}
+	return r.FindByID(ctx, series.OrganizationID, series.ID)
+}
+
+func (r *wishSeriesRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*domain.WishSeries, error) {
+	// 「今日」は組織のタイムゾーンで判定する。最後に作った回が完了していれば期間の開始を待たずに次を作る
+	var rows []struct {
+		models.WishSeries `gorm:"embedded"`
+		Today             time.Time `gorm:"column:today"`
+	}
+	if err := r.db.WithContext(ctx).
+		Table("wish_series AS s").
+		Select("s.*, (?::timestamptz AT TIME ZONE o.timezone)::date AS today", now).
+		Joins("JOIN organizations o ON o.id = s.organization_id").
+		Where("o.deleted_at IS NULL").
+		Where("s.status = ? AND s.next_date IS NOT NULL", string(domain.SeriesStatusActive)).
+		Where(`(s.next_date <= (?::timestamptz AT TIME ZONE o.timezone)::date OR (
+			SELECT w.status FROM wishes w
+			WHERE w.series_id = s.id
+			ORDER BY w.created_at DESC, w.id DESC
+			LIMIT 1
+		) = ?)`, now, string(domain.WishStatusFulfilled)).
+		Order("s.next_date, s.id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]*domain.WishSeries, len(rows))
+	for i := range rows {
+		series, err := toDomainSeries(&rows[i].WishSeries)
+		if err != nil {
+			return nil, err
+		}
+		today := rows[i].Today
+		series.Today = &today
+		list[i] = series
+	}
+	return list, nil
+}
+
+func (r *wishSeriesRepository) Advance(ctx context.Context, id uuid.UUID, advance domain.SeriesAdvance) (bool, error) {
+	updates := map[string]interface{}{
+		"next_date":      advance.Next,
+		"last_date":      advance.Created,
+		"instance_count": gorm.Expr("instance_count + 1"),
+		"updated_at":     time.Now(),
+	}
+	if advance.Next == nil {
+		updates["status"] = string(domain.SeriesStatusCompleted)
+	}
+
+	// 他のワーカーが先に進めた場合は0件になる
+	result := r.db.WithContext(ctx).
+		Model(&models.WishSeries{}).
+		Where("id = ? AND status = ? AND next_date = ?", id, string(domain.SeriesStatusActive), advance.From).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *wishSeriesRepository) Transaction(ctx context.Context, fn func(series domain.WishSeriesRepository, wishes domain.WishRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&wishSeriesRepository{db: tx}, &wishRepository{db: tx})
+	})
+}
+
+func toDomainSeriesList(rows []models.WishSeries) ([]*domain.WishSeries, error) {
+	list := make([]*domain.WishSeries, len(rows))
+	for i := range rows {
+		series, err := toDomainSeries(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		list[i] = series
+	}
+	return list, nil
+}
+
+func toDomainSeries(row *models.WishSeries) (*domain.WishSeries, error) {
+	rule, err := domain.ParseRecurrenceRule(row.Rule)
+	if err != nil {
+		return nil, err
+	}
+	var tagIDs []uuid.UUID
+	if err := json.Unmarshal([]byte(row.TagIDs), &tagIDs); err != nil {
+		return nil, err
+	}
+	var price *domain.Money
+	if row.PriceAmount != nil && row.PriceCurrency != nil {
+		price = &domain.Money{Amount: *row.PriceAmount, Currency: *row.PriceCurrency}
+	}
+
+	return &domain.WishSeries{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Title:          row.Title,
+		Note:           row.Note,
+		Price:          price,
+		TagIDs:         tagIDs,
+		AssigneeID:     row.AssigneeID,
+		ListID:         row.ListID,
+		Rule:           rule,
+		StartDate:      row.StartDate,
+		Status:         domain.SeriesStatus(row.Status),
+		NextDate:       row.NextDate,
+		LastDate:       row.LastDate,
+		InstanceCount:  row.InstanceCount,
+		CreatedBy:      row.CreatedBy,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewShareLinkRepository(db *gorm.DB) domain.ShareLinkRepository {
+	return &shareLinkRepository{db: db}
+}
+
+func (r *shareLinkRepository) Create(ctx context.Context, link *domain.ShareLink) (*domain.ShareLink, error) {
+	row := &models.ShareLink{
+		OrganizationID: link.OrganizationID,
+		ListID:         link.ListID,
+		TokenHash:      link.TokenHash,
+		TokenPrefix:    link.TokenPrefix,
+		PasswordHash:   link.PasswordHash,
+		ExpiresAt:      link.ExpiresAt,
+		CreatedBy:      link.CreatedBy,
+	}
+
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainShareLink(row), nil
+}
+
+func (r *shareLinkRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.ShareLink, error) {
+	var row models.ShareLink
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainShareLink(&row), nil
+}
+
+func (r *shareLinkRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.ShareLink, error) {
+	var rows []models.ShareLink
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	links := make([]*domain.ShareLink, len(rows))
+	for i, row := range rows {
+		links[i] = toDomainShareLink(&row)
+	}
+	return links, nil
+}
+
+func (r *shareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.ShareLink, error) {
+	var row models.ShareLink
+	if err := r.db.WithContext(ctx).First(&row, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainShareLink(&row), nil
+}
+
+func (r *shareLinkRepository) Revoke(ctx context.Context, organizationID, id uuid.UUID, at time.Time) (*domain.ShareLink, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ShareLink{}).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", at))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrShareNotFound
+	}
+	return r.FindByID(ctx, organizationID, id)
+}
+
+func (r *shareLinkRepository) RecordView(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ShareLink{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"view_count":     gorm.Expr("view_count + 1"),
+			"last_viewed_at": at,
+		}).Error
+}
+
+func toDomainShareLink(row *models.ShareLink) *domain.ShareLink {
+	return &domain.ShareLink{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		ListID:         row.ListID,
+		TokenHash:      row.TokenHash,
+		TokenPrefix:    row.TokenPrefix,
+		PasswordHash:   row.PasswordHash,
+		ExpiresAt:      row.ExpiresAt,
+		RevokedAt:      row.RevokedAt,
+		ViewCount:      row.ViewCount,
+		LastViewedAt:   row.LastViewedAt,
+		CreatedBy:      row.CreatedBy,
+		CreatedAt:      row.CreatedAt,
+	}
+}
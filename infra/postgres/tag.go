@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) domain.TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
+	row := &models.Tag{
+		OrganizationID: tag.OrganizationID,
+		Name:           tag.Name,
+		Color:          tag.Color,
+	}
+
+	// トランザクションの中ではセーブポイントになるため、名前の重複で失敗してもトランザクションを続けられる
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(row).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrTagNameConflict
+		}
+		return nil, err
+	}
+
+	return toDomainTag(row), nil
+}
+
+func (r *tagRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Tag, error) {
+	var row models.Tag
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainTag(&row), nil
+}
+
+func (r *tagRepository) FindByIDs(ctx context.Context, organizationID uuid.UUID, ids []uuid.UUID) ([]*domain.Tag, error) {
+	if len(ids) == 0 {
+		return []*domain.Tag{}, nil
+	}
+
+	var rows []models.Tag
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND id IN ?", organizationID, ids).
+		Order("name").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	tags := make([]*domain.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = toDomainTag(&row)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.Tag, error) {
+	var rows []models.Tag
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("name").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	tags := make([]*domain.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = toDomainTag(&row)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Tag{}).
+		Where("id = ? AND organization_id = ?", tag.ID, tag.OrganizationID).
+		Updates(map[string]interface{}{
+			"name":       tag.Name,
+			"color":      tag.Color,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrTagNameConflict
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrTagNotFound
+	}
+
+	var row models.Tag
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", tag.ID).Error; err != nil {
+		return nil, err
+	}
+	return toDomainTag(&row), nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	// wish_tags は ON DELETE CASCADE で削除される
+	result := r.db.WithContext(ctx).Delete(&models.Tag{}, "id = ? AND organization_id = ?", id, organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
+
+func (r *tagRepository) Merge(ctx context.Context, organizationID, sourceID, targetID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 統合元のWishを統合先に付け替える（既に両方付いているWishは重複させない）
+		if err := tx.Exec(`
+			INSERT INTO wish_tags (wish_id, tag_id)
+			SELECT wish_id, ? FROM wish_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.Tag{}, "id = ? AND organization_id = ?", sourceID, organizationID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrTagNotFound
+		}
+		return nil
+	})
+}
+
+func (r *tagRepository) Transaction(ctx context.Context, fn func(tags domain.TagRepository, wishes domain.WishRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&tagRepository{db: tx}, &wishRepository{db: tx})
+	})
+}
+
+func toDomainTag(row *models.Tag) *domain.Tag {
+	return &domain.Tag{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Name:           row.Name,
+		Color:          row.Color,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
@@ -81,6 +81,23 @@ func (r *userRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain
 	return &domain.User{ID: row.ID, SubID: row.SubID, Name: row.Name, AvatarURL: row.AvatarURL}, nil
 }
 
+func (r *userRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.User, error) {
+	if len(ids) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	var rows []User
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, len(rows))
+	for i, row := range rows {
+		users[i] = &domain.User{ID: row.ID, SubID: row.SubID, Name: row.Name, AvatarURL: row.AvatarURL}
+	}
+	return users, nil
+}
+
 func (r *userRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	// gorm.DeletedAt なので Delete でソフトデリートになる
 	res := r.db.WithContext(ctx).Delete(&User{}, "id = ?", id)
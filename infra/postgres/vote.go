@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type voteRepository struct {
+	db *gorm.DB
+}
+
+func NewVoteRepository(db *gorm.DB) domain.VoteRepository {
+	return &voteRepository{db: db}
+}
+
+func (r *voteRepository) SetVote(ctx context.Context, wishID, userID uuid.UUID, value domain.VoteValue) (int, error) {
+	var score int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同じWishへの同時投票を直列化し、scoreの集計が後勝ちで欠けないようにする
+		if err := tx.Exec("SELECT 1 FROM wishes WHERE id = ? FOR UPDATE", wishID).Error; err != nil {
+			return err
+		}
+
+		if value == domain.VoteNone {
+			if err := tx.Delete(&models.WishVote{}, "wish_id = ? AND user_id = ?", wishID, userID).Error; err != nil {
+				return err
+			}
+		} else {
+			vote := &models.WishVote{WishID: wishID, UserID: userID, Value: int(value)}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "wish_id"}, {Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"value":      int(value),
+					"updated_at": gorm.Expr("now()"),
+				}),
+			}).Create(vote).Error; err != nil {
+				return err
+			}
+		}
+
+		// 差分ではなく集計し直した値を書き込む
+		if err := tx.Raw(`
+			UPDATE wishes
+			SET score = (SELECT COALESCE(SUM(value), 0) FROM wish_votes WHERE wish_id = ?)
+			WHERE id = ?
+			RETURNING score`, wishID, wishID).Scan(&score).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return score, nil
+}
+
+func (r *voteRepository) FindByUser(ctx context.Context, userID uuid.UUID, wishIDs []uuid.UUID) (map[uuid.UUID]domain.VoteValue, error) {
+	votes := make(map[uuid.UUID]domain.VoteValue, len(wishIDs))
+	if len(wishIDs) == 0 {
+		return votes, nil
+	}
+
+	var rows []models.WishVote
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND wish_id IN ?", userID, wishIDs).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		votes[row.WishID] = domain.VoteValue(row.Value)
+	}
+	return votes, nil
+}
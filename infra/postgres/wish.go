@@ -2,6 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"taine-api/domain"
@@ -9,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type wishRepository struct {
@@ -22,9 +26,21 @@ func NewWishRepository(db *gorm.DB) domain.WishRepository {
 func (r *wishRepository) Create(ctx context.Context, wish *domain.Wish) (*domain.Wish, error) {
 	row := &models.Wish{
 		OrganizationID: wish.OrganizationID,
+		ListID:         wish.ListID,
 		Title:          wish.Title,
 		Note:           wish.Note,
 		OrderNo:        wish.OrderNo,
+		Rank:           wish.Rank,
+		Status:         string(wish.Status),
+		TargetDate:     wish.TargetDate,
+		AssigneeID:     wish.AssigneeID,
+		SeriesID:       wish.SeriesID,
+	}
+	row.PriceAmount, row.PriceCurrency = priceColumns(wish.Price)
+	row.PlaceName, row.PlaceAddress, row.PlaceLat, row.PlaceLng = placeColumns(wish.Place)
+	if wish.Origin != nil {
+		kind := string(wish.Origin.Kind)
+		row.OriginWishID, row.OriginOrganizationID, row.OriginKind = wish.Origin.WishID, wish.Origin.OrganizationID, &kind
 	}
 
 	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
@@ -34,9 +50,9 @@ func (r *wishRepository) Create(ctx context.Context, wish *domain.Wish) (*domain
 	return r.toDomain(row), nil
 }
 
-func (r *wishRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Wish, error) {
+func (r *wishRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Wish, error) {
 	var row models.Wish
-	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -45,61 +61,318 @@ func (r *wishRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Wi
 	return r.toDomain(&row), nil
 }
 
-func (r *wishRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.Wish, error) {
+func (r *wishRepository) FindByIDForUpdate(ctx context.Context, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	var row models.Wish
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&row, "id = ? AND organization_id = ?", id, organizationID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.toDomain(&row), nil
+}
+
+// wishSortColumns - ソートキーごとのkeyset列（最後は必ずidでタイブレーク）
+var wishSortColumns = map[domain.WishSortKey][]string{
+	domain.WishSortPriority:  {"rank", "id"},
+	domain.WishSortCreatedAt: {"created_at", "id"},
+	domain.WishSortUpdatedAt: {"updated_at", "id"},
+	domain.WishSortTitle:     {"title", "id"},
+	domain.WishSortScore:     {"score", "id"},
+	// 期日未設定は最後（昇順時）に並べるため番兵の日付に置き換える
+	domain.WishSortTargetDate: {"COALESCE(target_date, DATE '9999-12-31')", "id"},
+}
+
+// noTargetDate - 期日未設定のWishをkeysetで扱うための番兵（wishSortColumnsのCOALESCEと同じ値）
+var noTargetDate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// wishCursorValues - カーソルからkeyset列に対応する値を取り出す
+func wishCursorValues(sort domain.WishSortKey, c *domain.WishCursor) []interface{} {
+	switch sort {
+	case domain.WishSortPriority:
+		return []interface{}{c.Rank, c.ID}
+	case domain.WishSortCreatedAt:
+		return []interface{}{c.CreatedAt, c.ID}
+	case domain.WishSortUpdatedAt:
+		return []interface{}{c.UpdatedAt, c.ID}
+	case domain.WishSortScore:
+		return []interface{}{c.Score, c.ID}
+	case domain.WishSortTargetDate:
+		if c.TargetDate == nil {
+			return []interface{}{noTargetDate, c.ID}
+		}
+		return []interface{}{*c.TargetDate, c.ID}
+	default:
+		return []interface{}{c.Title, c.ID}
+	}
+}
+
+// escapeLike - LIKEのワイルドカードをエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (r *wishRepository) List(ctx context.Context, organizationID uuid.UUID, query domain.WishListQuery) ([]*domain.Wish, error) {
+	tx := r.db.WithContext(ctx).Where("organization_id = ?", organizationID)
+
+	if !query.IncludeDeleted {
+		tx = tx.Where("deleted_at IS NULL")
+	}
+	if query.CreatedFrom != nil {
+		tx = tx.Where("created_at >= ?", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		tx = tx.Where("created_at < ?", *query.CreatedTo)
+	}
+	if query.UpdatedFrom != nil {
+		tx = tx.Where("updated_at >= ?", *query.UpdatedFrom)
+	}
+	if query.UpdatedTo != nil {
+		tx = tx.Where("updated_at < ?", *query.UpdatedTo)
+	}
+	if len(query.Statuses) > 0 {
+		statuses := make([]string, len(query.Statuses))
+		for i, status := range query.Statuses {
+			statuses[i] = string(status)
+		}
+		tx = tx.Where("status IN ?", statuses)
+	}
+	if len(query.TagIDs) > 0 {
+		// 同じタグが重複して指定されても、ANDの件数の比較がずれないよう重複を除く
+		seen := make(map[uuid.UUID]bool, len(query.TagIDs))
+		tagIDs := make([]uuid.UUID, 0, len(query.TagIDs))
+		for _, tagID := range query.TagIDs {
+			if !seen[tagID] {
+				seen[tagID] = true
+				tagIDs = append(tagIDs, tagID)
+			}
+		}
+		if query.TagMatchAll {
+			tx = tx.Where(`id IN (
+				SELECT wish_id FROM wish_tags WHERE tag_id IN ?
+				GROUP BY wish_id HAVING COUNT(DISTINCT tag_id) = ?)`, tagIDs, len(tagIDs))
+		} else {
+			tx = tx.Where("id IN (SELECT wish_id FROM wish_tags WHERE tag_id IN ?)", tagIDs)
+		}
+	}
+	if query.TitlePrefix != "" {
+		tx = tx.Where(`title LIKE ? ESCAPE '\'`, escapeLike(query.TitlePrefix)+"%")
+	}
+	if query.TargetDateFrom != nil {
+		tx = tx.Where("target_date >= ?", *query.TargetDateFrom)
+	}
+	if query.TargetDateBefore != nil {
+		tx = tx.Where("target_date < ?", *query.TargetDateBefore)
+	}
+	if query.AssigneeID != nil {
+		tx = tx.Where("assignee_id = ?", *query.AssigneeID)
+	}
+	if query.SeriesID != nil {
+		tx = tx.Where("series_id = ?", *query.SeriesID)
+	}
+	if query.ListID != nil {
+		tx = tx.Where("list_id = ?", *query.ListID)
+	}
+
+	sort := query.Sort
+	if !sort.Valid() {
+		sort = domain.WishSortPriority
+	}
+	columns := wishSortColumns[sort]
+	direction, op := "DESC", "<"
+	if query.Order == domain.SortAsc {
+		direction, op = "ASC", ">"
+	}
+
+	// keyset: (col1, col2, ...) < (v1, v2, ...)
+	if query.After != nil {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+		tx = tx.Where(
+			fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, placeholders),
+			wishCursorValues(sort, query.After)...,
+		)
+	}
+
+	orders := make([]string, len(columns))
+	for i, col := range columns {
+		orders[i] = col + " " + direction
+	}
+
 	var rows []models.Wish
+	if err := tx.Order(strings.Join(orders, ", ")).Limit(query.Limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(rows))
+	for i, row := range rows {
+		wishes[i] = r.toDomain(&row)
+	}
+	return wishes, nil
+}
+
+// Search - タイトル・メモの部分一致検索（pg_trgmのGINインデックスを利用）
+// 空白で分かち書きしない日本語でも一致するよう、単語ではなく文字列の部分一致で判定する
+func (r *wishRepository) Search(ctx context.Context, organizationID uuid.UUID, q string, limit int) ([]*domain.WishSearchResult, error) {
+	pattern := "%" + escapeLike(q) + "%"
+
+	var rows []struct {
+		models.Wish `gorm:"embedded"`
+		SearchRank  float64 `gorm:"column:search_rank"`
+	}
 	if err := r.db.WithContext(ctx).
+		Table("wishes").
+		Select(`wishes.*,
+			(CASE WHEN title ILIKE @pattern THEN 1.0 ELSE 0.0 END)
+			+ (CASE WHEN note ILIKE @pattern THEN 0.5 ELSE 0.0 END)
+			+ similarity(title, @q)
+			+ word_similarity(@q, note) * 0.5 AS search_rank`,
+			map[string]interface{}{"pattern": pattern, "q": q}).
 		Where("organization_id = ?", organizationID).
 		Where("deleted_at IS NULL").
-		Order("order_no DESC, created_at DESC").
+		Where(`(title ILIKE ? OR note ILIKE ?)`, pattern, pattern).
+		Order("search_rank DESC, rank, id").
+		Limit(limit).
 		Find(&rows).Error; err != nil {
 		return nil, err
 	}
 
-	wishes := make([]*domain.Wish, len(rows))
+	results := make([]*domain.WishSearchResult, len(rows))
 	for i, row := range rows {
-		wishes[i] = r.toDomain(&row)
+		results[i] = &domain.WishSearchResult{
+			Wish: r.toDomain(&row.Wish),
+			Rank: row.SearchRank,
+		}
 	}
-	return wishes, nil
+	return results, nil
+}
+
+func (r *wishRepository) FindNearby(ctx context.Context, organizationID uuid.UUID, query domain.NearbyQuery) ([]*domain.NearbyWish, error) {
+	// 緯度・経度の範囲（idx_wishes_place）で候補を絞り、その中だけでハバーサイン距離を計算する
+	box := domain.BoundingBox(query.Center, query.RadiusMeters)
+	candidates := r.db.
+		Table("wishes").
+		Select(`wishes.*,
+			2 * ? * asin(LEAST(1, sqrt(
+				power(sin(radians(place_lat - ?) / 2), 2)
+				+ cos(radians(?)) * cos(radians(place_lat)) * power(sin(radians(place_lng - ?) / 2), 2)
+			))) AS distance`,
+			domain.EarthRadiusMeters, query.Center.Lat, query.Center.Lat, query.Center.Lng).
+		Where("organization_id = ?", organizationID).
+		Where("deleted_at IS NULL").
+		Where("place_lat IS NOT NULL").
+		Where("place_lat BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if len(box.LngRanges) > 0 {
+		conds := make([]string, len(box.LngRanges))
+		args := make([]interface{}, 0, 2*len(box.LngRanges))
+		for i, lngRange := range box.LngRanges {
+			conds[i] = "place_lng BETWEEN ? AND ?"
+			args = append(args, lngRange[0], lngRange[1])
+		}
+		candidates = candidates.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+
+	var rows []struct {
+		models.Wish `gorm:"embedded"`
+		Distance    float64 `gorm:"column:distance"`
+	}
+	if err := r.db.WithContext(ctx).
+		Table("(?) AS nearby", candidates).
+		Where("distance <= ?", query.RadiusMeters).
+		Order("distance, id").
+		Limit(query.Limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.NearbyWish, len(rows))
+	for i, row := range rows {
+		results[i] = &domain.NearbyWish{
+			Wish:           r.toDomain(&row.Wish),
+			DistanceMeters: row.Distance,
+		}
+	}
+	return results, nil
 }
 
-func (r *wishRepository) Update(ctx context.Context, wish *domain.Wish) (*domain.Wish, error) {
+func (r *wishRepository) Update(ctx context.Context, wish *domain.Wish, expectedVersion int) (*domain.Wish, error) {
 	updates := map[string]interface{}{
-		"title":      wish.Title,
-		"note":       wish.Note,
-		"order_no":   wish.OrderNo,
-		"updated_at": time.Now(),
+		"title":       wish.Title,
+		"note":        wish.Note,
+		"order_no":    wish.OrderNo,
+		"target_date": wish.TargetDate,
+		"assignee_id": wish.AssigneeID,
+		"version":     gorm.Expr("version + 1"),
+		"updated_at":  time.Now(),
+	}
+	updates["price_amount"], updates["price_currency"] = priceColumns(wish.Price)
+	updates["place_name"], updates["place_address"], updates["place_lat"], updates["place_lng"] = placeColumns(wish.Place)
+
+	result := r.versioned(ctx, wish.OrganizationID, wish.ID, expectedVersion).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, r.missedWrite(ctx, wish.OrganizationID, wish.ID, expectedVersion)
 	}
 
 	var row models.Wish
-	if err := r.db.WithContext(ctx).
-		Model(&row).
-		Where("id = ?", wish.ID).
-		Updates(updates).
-		First(&row).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", wish.ID).Error; err != nil {
 		return nil, err
 	}
 
 	return r.toDomain(&row), nil
 }
 
-func (r *wishRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&models.Wish{}, "id = ?", id)
+// versioned - 対象のWishに絞ったクエリ。expectedVersion > 0 ならバージョンの一致も条件にする（compare-and-set）
+func (r *wishRepository) versioned(ctx context.Context, organizationID, id uuid.UUID, expectedVersion int) *gorm.DB {
+	tx := r.db.WithContext(ctx).
+		Model(&models.Wish{}).
+		Where("id = ? AND organization_id = ?", id, organizationID)
+	if expectedVersion > 0 {
+		tx = tx.Where("version = ?", expectedVersion)
+	}
+	return tx
+}
+
+// missedWrite - 条件付き更新が0件だった理由を判定する（Wishが無いか、バージョンが古いか）
+func (r *wishRepository) missedWrite(ctx context.Context, organizationID, id uuid.UUID, expectedVersion int) error {
+	if expectedVersion <= 0 {
+		return domain.ErrWishNotFound
+	}
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Wish{}).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrWishNotFound
+	}
+	return domain.ErrWishVersionMismatch
+}
+
+func (r *wishRepository) Delete(ctx context.Context, organizationID, id uuid.UUID, expectedVersion int) error {
+	result := r.versioned(ctx, organizationID, id, expectedVersion).Delete(&models.Wish{})
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return r.missedWrite(ctx, organizationID, id, expectedVersion)
 	}
 	return nil
 }
 
-func (r *wishRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+func (r *wishRepository) SoftDelete(ctx context.Context, organizationID, id, deletedBy uuid.UUID, expectedVersion int) error {
 	now := time.Now()
-	result := r.db.WithContext(ctx).
-		Model(&models.Wish{}).
-		Where("id = ?", id).
+	result := r.versioned(ctx, organizationID, id, expectedVersion).
 		Updates(map[string]interface{}{
 			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"version":    gorm.Expr("version + 1"),
 			"updated_at": now,
 		})
 
@@ -107,17 +380,19 @@ func (r *wishRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return r.missedWrite(ctx, organizationID, id, expectedVersion)
 	}
 	return nil
 }
 
-func (r *wishRepository) Restore(ctx context.Context, id uuid.UUID) error {
+func (r *wishRepository) Restore(ctx context.Context, organizationID, id uuid.UUID) error {
 	result := r.db.WithContext(ctx).
 		Model(&models.Wish{}).
-		Where("id = ?", id).
+		Where("id = ? AND organization_id = ?", id, organizationID).
 		Updates(map[string]interface{}{
 			"deleted_at": nil,
+			"deleted_by": nil,
+			"version":    gorm.Expr("version + 1"),
 			"updated_at": time.Now(),
 		})
 
@@ -125,17 +400,83 @@ func (r *wishRepository) Restore(ctx context.Context, id uuid.UUID) error {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return domain.ErrWishNotFound
 	}
 	return nil
 }
 
-func (r *wishRepository) UpdateOrder(ctx context.Context, id uuid.UUID, orderNo int) error {
-	result := r.db.WithContext(ctx).
-		Model(&models.Wish{}).
-		Where("id = ?", id).
+func (r *wishRepository) FindTrash(ctx context.Context, organizationID uuid.UUID) ([]*domain.Wish, error) {
+	var rows []models.Wish
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(rows))
+	for i, row := range rows {
+		wishes[i] = r.toDomain(&row)
+	}
+	return wishes, nil
+}
+
+func (r *wishRepository) PurgeDeleted(ctx context.Context, organizationID uuid.UUID, before time.Time) ([]*domain.Wish, error) {
+	var rows []models.Wish
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("organization_id = ?", organizationID).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(rows))
+	for i, row := range rows {
+		wishes[i] = r.toDomain(&row)
+	}
+	return wishes, nil
+}
+
+func (r *wishRepository) FindByList(ctx context.Context, organizationID, listID uuid.UUID) ([]*domain.Wish, error) {
+	var rows []models.Wish
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND list_id = ?", organizationID, listID).
+		Where("deleted_at IS NULL").
+		Order("rank, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(rows))
+	for i, row := range rows {
+		wishes[i] = r.toDomain(&row)
+	}
+	return wishes, nil
+}
+
+func (r *wishRepository) UpdateRank(ctx context.Context, organizationID, id uuid.UUID, rank string, expectedVersion int) error {
+	result := r.versioned(ctx, organizationID, id, expectedVersion).
+		Updates(map[string]interface{}{
+			"rank":       rank,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.missedWrite(ctx, organizationID, id, expectedVersion)
+	}
+	return nil
+}
+
+func (r *wishRepository) UpdateList(ctx context.Context, organizationID, id, listID uuid.UUID, rank string, expectedVersion int) error {
+	result := r.versioned(ctx, organizationID, id, expectedVersion).
 		Updates(map[string]interface{}{
-			"order_no":   orderNo,
+			"list_id":    listID,
+			"rank":       rank,
 			"updated_at": time.Now(),
 		})
 
@@ -143,20 +484,353 @@ func (r *wishRepository) UpdateOrder(ctx context.Context, id uuid.UUID, orderNo
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return r.missedWrite(ctx, organizationID, id, expectedVersion)
 	}
 	return nil
 }
 
+func (r *wishRepository) AdjacentRank(ctx context.Context, organizationID, listID uuid.UUID, rank string, after bool, excludeID uuid.UUID) (string, error) {
+	tx := r.db.WithContext(ctx).
+		Model(&models.Wish{}).
+		Where("organization_id = ? AND list_id = ?", organizationID, listID).
+		Where("deleted_at IS NULL").
+		Where("id <> ?", excludeID)
+
+	if after {
+		if rank != "" {
+			tx = tx.Where("rank > ?", rank)
+		}
+		tx = tx.Order("rank ASC")
+	} else {
+		tx = tx.Where("rank < ?", rank).Order("rank DESC")
+	}
+
+	var ranks []string
+	if err := tx.Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+func (r *wishRepository) LockRanks(ctx context.Context, organizationID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "wish_rank:"+organizationID.String()).
+		Error
+}
+
+func (r *wishRepository) ReplaceTags(ctx context.Context, wishID uuid.UUID, tagIDs []uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&models.WishTag{}, "wish_id = ?", wishID).Error; err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]models.WishTag, len(tagIDs))
+	for i, tagID := range tagIDs {
+		rows[i] = models.WishTag{WishID: wishID, TagID: tagID}
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (r *wishRepository) FindTagsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*domain.Tag, error) {
+	tagsByWish := make(map[uuid.UUID][]*domain.Tag, len(wishIDs))
+	if len(wishIDs) == 0 {
+		return tagsByWish, nil
+	}
+
+	// 一覧のWishのタグを1クエリでまとめて取得する（N+1を避ける）
+	var rows []struct {
+		models.Tag `gorm:"embedded"`
+		WishID     uuid.UUID `gorm:"column:wish_id"`
+	}
+	if err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.*, wish_tags.wish_id").
+		Joins("JOIN wish_tags ON wish_tags.tag_id = tags.id").
+		Where("wish_tags.wish_id IN ?", wishIDs).
+		Order("tags.name").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		tagsByWish[row.WishID] = append(tagsByWish[row.WishID], toDomainTag(&row.Tag))
+	}
+	return tagsByWish, nil
+}
+
+func (r *wishRepository) FindBudgeted(ctx context.Context, organizationID uuid.UUID) ([]*domain.Wish, error) {
+	var rows []models.Wish
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND deleted_at IS NULL", organizationID).
+		Where("price_amount IS NOT NULL OR EXISTS (SELECT 1 FROM wish_contributions c WHERE c.wish_id = wishes.id)").
+		Order("rank, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(rows))
+	for i, row := range rows {
+		wishes[i] = r.toDomain(&row)
+	}
+	return wishes, nil
+}
+
+func (r *wishRepository) ListConsensus(ctx context.Context, organizationID uuid.UUID, limit int) ([]*domain.Wish, error) {
+	// 組織の全メンバーが賛成票を入れているWish（賛成していないメンバーが存在しない）
+	var rows []models.Wish
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND deleted_at IS NULL", organizationID).
+		Where("EXISTS (SELECT 1 FROM organization_members m WHERE m.organization_id = wishes.organization_id)").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM organization_members m
+			WHERE m.organization_id = wishes.organization_id
+			  AND NOT EXISTS (
+				SELECT 1 FROM wish_votes v
+				WHERE v.wish_id = wishes.id AND v.user_id = m.user_id AND v.value = 1))`).
+		Order("score DESC, rank, id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(rows))
+	for i, row := range rows {
+		wishes[i] = r.toDomain(&row)
+	}
+	return wishes, nil
+}
+
+func (r *wishRepository) FindLinksByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*domain.WishLink, error) {
+	linksByWish := make(map[uuid.UUID][]*domain.WishLink, len(wishIDs))
+	if len(wishIDs) == 0 {
+		return linksByWish, nil
+	}
+
+	var rows []models.WishLink
+	if err := r.db.WithContext(ctx).
+		Where("wish_id IN ?", wishIDs).
+		Order("created_at, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		linksByWish[row.WishID] = append(linksByWish[row.WishID], toDomainLink(&row))
+	}
+	return linksByWish, nil
+}
+
+func (r *wishRepository) CountCommentsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(wishIDs))
+	if len(wishIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		WishID uuid.UUID
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.WishComment{}).
+		Select("wish_id, COUNT(*) AS count").
+		Where("wish_id IN ?", wishIDs).
+		Group("wish_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.WishID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *wishRepository) Transaction(ctx context.Context, fn func(repo domain.WishRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&wishRepository{db: tx})
+	})
+}
+
+func (r *wishRepository) UpdateStatus(ctx context.Context, wish *domain.Wish, from domain.WishStatus) (*domain.Wish, error) {
+	// 現在のstatusが変わっていない場合のみ更新（同時遷移の競合を防ぐ）
+	result := r.db.WithContext(ctx).
+		Model(&models.Wish{}).
+		Where("id = ? AND organization_id = ? AND status = ?", wish.ID, wish.OrganizationID, string(from)).
+		Updates(map[string]interface{}{
+			"status":       string(wish.Status),
+			"fulfilled_at": wish.FulfilledAt,
+			"fulfilled_by": wish.FulfilledBy,
+			"version":      gorm.Expr("version + 1"),
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrInvalidStatusTransition
+	}
+
+	var row models.Wish
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", wish.ID).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomain(&row), nil
+}
+
+// priceColumns - 価格をprice_amount, price_currency列の値に変換する（未設定はNULL）
+func priceColumns(price *domain.Money) (*int64, *string) {
+	if price == nil {
+		return nil, nil
+	}
+	return &price.Amount, &price.Currency
+}
+
+func placeColumns(place *domain.Place) (*string, *string, *float64, *float64) {
+	if place == nil {
+		return nil, nil, nil, nil
+	}
+	return &place.Name, &place.Address, place.Lat, place.Lng
+}
+
 func (r *wishRepository) toDomain(row *models.Wish) *domain.Wish {
+	var price *domain.Money
+	if row.PriceAmount != nil && row.PriceCurrency != nil {
+		price = &domain.Money{Amount: *row.PriceAmount, Currency: *row.PriceCurrency}
+	}
+
+	var place *domain.Place
+	if row.PlaceName != nil || row.PlaceAddress != nil || row.PlaceLat != nil {
+		place = &domain.Place{Lat: row.PlaceLat, Lng: row.PlaceLng}
+		if row.PlaceName != nil {
+			place.Name = *row.PlaceName
+		}
+		if row.PlaceAddress != nil {
+			place.Address = *row.PlaceAddress
+		}
+	}
+
+	var origin *domain.WishOrigin
+	if row.OriginKind != nil {
+		origin = &domain.WishOrigin{
+			WishID:         row.OriginWishID,
+			OrganizationID: row.OriginOrganizationID,
+			Kind:           domain.TransferKind(*row.OriginKind),
+		}
+	}
+
 	return &domain.Wish{
 		ID:             row.ID,
 		OrganizationID: row.OrganizationID,
+		ListID:         row.ListID,
 		Title:          row.Title,
 		Note:           row.Note,
 		OrderNo:        row.OrderNo,
+		Rank:           row.Rank,
+		Status:         domain.WishStatus(row.Status),
+		Score:          row.Score,
+		Price:          price,
+		TargetDate:     row.TargetDate,
+		AssigneeID:     row.AssigneeID,
+		Version:        row.Version,
+		SeriesID:       row.SeriesID,
+		Place:          place,
+		FulfilledAt:    row.FulfilledAt,
+		FulfilledBy:    row.FulfilledBy,
 		CreatedAt:      row.CreatedAt,
 		UpdatedAt:      row.UpdatedAt,
 		DeletedAt:      row.DeletedAt,
+		DeletedBy:      row.DeletedBy,
+		Origin:         origin,
+	}
+}
+
+func (r *wishRepository) CreateRevision(ctx context.Context, revision *domain.WishRevision) (*domain.WishRevision, error) {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest int
+	if err := r.db.WithContext(ctx).
+		Model(&models.WishRevision{}).
+		Where("wish_id = ?", revision.WishID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&latest).Error; err != nil {
+		return nil, err
+	}
+
+	row := &models.WishRevision{
+		OrganizationID: revision.OrganizationID,
+		WishID:         revision.WishID,
+		Number:         latest + 1,
+		ActorID:        revision.ActorID,
+		Snapshot:       string(snapshot),
+		Changes:        string(changes),
+		RevertedFrom:   revision.RevertedFrom,
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return nil, err
+	}
+	return toDomainRevision(row)
+}
+
+func (r *wishRepository) FindRevisions(ctx context.Context, wishID uuid.UUID, before, limit int) ([]*domain.WishRevision, error) {
+	tx := r.db.WithContext(ctx).Where("wish_id = ?", wishID)
+	if before > 0 {
+		tx = tx.Where("number < ?", before)
+	}
+
+	var rows []models.WishRevision
+	if err := tx.Order("number DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*domain.WishRevision, len(rows))
+	for i, row := range rows {
+		revision, err := toDomainRevision(&row)
+		if err != nil {
+			return nil, err
+		}
+		revisions[i] = revision
+	}
+	return revisions, nil
+}
+
+func (r *wishRepository) FindRevision(ctx context.Context, wishID uuid.UUID, number int) (*domain.WishRevision, error) {
+	var row models.WishRevision
+	if err := r.db.WithContext(ctx).First(&row, "wish_id = ? AND number = ?", wishID, number).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainRevision(&row)
+}
+
+func toDomainRevision(row *models.WishRevision) (*domain.WishRevision, error) {
+	revision := &domain.WishRevision{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		WishID:         row.WishID,
+		Number:         row.Number,
+		ActorID:        row.ActorID,
+		RevertedFrom:   row.RevertedFrom,
+		CreatedAt:      row.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(row.Snapshot), &revision.Snapshot); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(row.Changes), &revision.Changes); err != nil {
+		return nil, err
 	}
+	return revision, nil
 }
@@ -0,0 +1,152 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType - iCalendar（RFC 5545）のメディアタイプ
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets - 折り返し前の1行の上限（改行を除く）
+const maxLineOctets = 75
+
+// Calendar - 購読用のカレンダー（VCALENDAR）
+type Calendar struct {
+	ProdID string
+	// Name - カレンダーアプリに表示する名前（X-WR-CALNAME）
+	Name string
+	// RefreshInterval - カレンダーアプリに勧める再取得の間隔（0は指定しない）
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Event - 終日の予定（VEVENT）
+// 同じ予定は常に同じUIDで出力し、内容が変わるたびに Sequence を増やすとカレンダーアプリが更新として扱う
+type Event struct {
+	UID          string
+	Date         time.Time // 年月日だけを使う
+	Summary      string
+	Description  string
+	Location     string
+	Categories   []string
+	Sequence     int
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Write - カレンダーをiCalendar形式で書き出す。now は DTSTAMP に使う
+func Write(w io.Writer, cal Calendar, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", escapeText(cal.ProdID))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", escapeText(cal.Name))
+	}
+	if cal.RefreshInterval > 0 {
+		// RFC 7986 と、それに対応していないアプリ向けの X-PUBLISHED-TTL
+		line("REFRESH-INTERVAL;VALUE=DURATION", formatDuration(cal.RefreshInterval))
+		line("X-PUBLISHED-TTL", formatDuration(cal.RefreshInterval))
+	}
+
+	stamp := formatUTC(now)
+	for _, event := range cal.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escapeText(event.UID))
+		line("DTSTAMP", stamp)
+		line("DTSTART;VALUE=DATE", formatDate(event.Date))
+		line("DTEND;VALUE=DATE", formatDate(event.Date.AddDate(0, 0, 1)))
+		line("SUMMARY", escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escapeText(event.Location))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escapeText(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		line("SEQUENCE", fmt.Sprint(event.Sequence))
+		if !event.Created.IsZero() {
+			line("CREATED", formatUTC(event.Created))
+		}
+		if !event.LastModified.IsZero() {
+			line("LAST-MODIFIED", formatUTC(event.LastModified))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeText - TEXT型の値のエスケープ（\ ; , と改行）
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeFolded - 75オクテットを超える行を、UTF-8の文字の途中で切らないように折り返して書く
+func writeFolded(w *bufio.Writer, content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// 続きの行は先頭の空白の分だけ短くする
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatDuration - 秒単位に丸めた期間（PT1H30M など）
+func formatDuration(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := seconds / 3600; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m := seconds % 3600 / 60; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if s := seconds % 60; s > 0 || seconds == 0 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+	return b.String()
+}
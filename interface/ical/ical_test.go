@@ -0,0 +1,153 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{`C:\path`, `C:\\path`},
+		{"a;b,c", `a\;b\,c`},
+		{"line1\nline2", `line1\nline2`},
+		{"line1\r\nline2", `line1\nline2`},
+		{"line1\rline2", `line1\nline2`},
+		// コロンと二重引用符は TEXT 型ではエスケープしない
+		{`time: "12:00"`, `time: "12:00"`},
+		// エスケープ済みに見える文字列も、そのまま \ を二重にする
+		{`\n`, `\\n`},
+		{"温泉, 旅行;", `温泉\, 旅行\;`},
+	} {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// fold - writeFolded の出力
+func fold(content string) string {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeFolded(w, content)
+	w.Flush()
+	return buf.String()
+}
+
+// unfold - RFC 5545 3.1 の折り返しを戻す
+func unfold(s string) string {
+	return strings.TrimSuffix(strings.ReplaceAll(s, "\r\n ", ""), "\r\n")
+}
+
+func TestWriteFolded(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		content string
+		lines   int
+	}{
+		{"empty", "", 1},
+		{"short", "SUMMARY:Onsen", 1},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67), 1},
+		{"76 octets", "SUMMARY:" + strings.Repeat("a", 68), 2},
+		{"long ascii", "DESCRIPTION:" + strings.Repeat("0123456789", 30), 5},
+		// 3オクテットの文字が境界をまたぐ位置に来るよう、先頭をずらす
+		{"japanese", "SUMMARY:" + strings.Repeat("温泉旅行", 20), 4},
+		{"japanese shifted by one", "SUMMARY:x" + strings.Repeat("温泉旅行", 20), 4},
+		{"japanese shifted by two", "SUMMARY:xy" + strings.Repeat("温泉旅行", 20), 4},
+		{"four octet characters", "SUMMARY:" + strings.Repeat("🎉", 40), 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fold(tt.content)
+			if !strings.HasSuffix(got, "\r\n") {
+				t.Fatalf("output %q does not end with CRLF", got)
+			}
+			if unfolded := unfold(got); unfolded != tt.content {
+				t.Errorf("unfolded = %q, want %q", unfolded, tt.content)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("got %d lines, want %d", len(lines), tt.lines)
+			}
+			for i, line := range lines {
+				// 続きの行は先頭の空白を含めて75オクテット以内
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d is %d octets: %q", i, len(line), line)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 character: %q", i, line)
+				}
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	modified := time.Date(2025, 2, 1, 9, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	var buf bytes.Buffer
+	err := Write(&buf, Calendar{
+		ProdID:          "-//taine//wishes//JA",
+		Name:            "Family, wishes",
+		RefreshInterval: 90 * time.Minute,
+		Events: []Event{{
+			UID:          "wish-1@taine",
+			Date:         date,
+			Summary:      "Onsen; trip",
+			Categories:   []string{"travel", "a,b"},
+			Sequence:     3,
+			LastModified: modified,
+		}},
+	}, time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Family\\, wishes\r\n",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H30M\r\n",
+		"DTSTAMP:20250202T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20250301\r\nDTEND;VALUE=DATE:20250302\r\n",
+		"SUMMARY:Onsen\\; trip\r\n",
+		"CATEGORIES:travel,a\\,b\r\n",
+		"SEQUENCE:3\r\n",
+		"LAST-MODIFIED:20250201T003000Z\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "CREATED:") || strings.Contains(got, "DESCRIPTION:") {
+		t.Errorf("output contains empty optional properties:\n%s", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0S"},
+		{time.Hour, "PT1H"},
+		{90 * time.Minute, "PT1H30M"},
+		{time.Hour + 5*time.Second + 500*time.Millisecond, "PT1H5S"},
+	} {
+		if got := formatDuration(tt.d); got != tt.want {
+			t.Errorf("formatDuration(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
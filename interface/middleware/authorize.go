@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"taine-api/domain"
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission - org_roleクレーム（無ければorganization_members.role）でロールポリシーを評価する
+// ClerkSessionAuth の後に置くこと
+func RequirePermission(policySvc usecase.PolicySvc, resource domain.Resource, action domain.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		decision, err := policySvc.Authorize(
+			c.Request.Context(),
+			c.GetString("sub_id"),
+			c.GetString("org_external_id"),
+			c.GetString("org_role"),
+			resource,
+			action,
+		)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !decision.Allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":  domain.ErrPermissionDenied.Error(),
+				"reason": decision,
+			})
+			return
+		}
+
+		// 後続のハンドラーで使えるように解決済みのロールを保存
+		c.Set("role", decision.Role)
+		c.Next()
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"container/list"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"taine-api/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitMaxKeys - 保持するカウンタの上限
+const rateLimitMaxKeys = 10000
+
+type rateWindow struct {
+	key   string
+	start time.Time
+	count int
+}
+
+// RateLimit - key ごとに window あたり limit 回までに制限する。超えた場合は429とRetry-Afterを返す
+// カウンタはプロセス内に持つため、複数台で動かす場合は台ごとの上限になる。
+// カウンタは rateLimitMaxKeys 個までで、期間が終わったものだけを捨てる。期間中のカウンタで埋まっている間は、
+// 新しい key を数えられないため429を返す（大量の key を送ってカウンタを捨てさせ、制限を逃れることはできない）
+func RateLimit(limit int, window time.Duration, key func(c *gin.Context) string) gin.HandlerFunc {
+	var mu sync.Mutex
+	windows := make(map[string]*list.Element)
+	started := list.New() // 期間の始まりが新しい順
+
+	return func(c *gin.Context) {
+		k := key(c)
+		now := time.Now()
+
+		mu.Lock()
+		var w *rateWindow
+		if e, ok := windows[k]; ok {
+			w = e.Value.(*rateWindow)
+			if now.Sub(w.start) >= window {
+				w.start, w.count = now, 0
+				started.MoveToFront(e)
+			}
+		} else {
+			if started.Len() >= rateLimitMaxKeys {
+				oldest := started.Back()
+				ow := oldest.Value.(*rateWindow)
+				if now.Sub(ow.start) < window {
+					retryAfter := ow.start.Add(window).Sub(now)
+					mu.Unlock()
+					tooManyRequests(c, retryAfter)
+					return
+				}
+				started.Remove(oldest)
+				delete(windows, ow.key)
+			}
+			w = &rateWindow{key: k, start: now}
+			windows[k] = started.PushFront(w)
+		}
+		w.count++
+		exceeded := w.count > limit
+		retryAfter := w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if exceeded {
+			tooManyRequests(c, retryAfter)
+			return
+		}
+		c.Next()
+	}
+}
+
+func tooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+}
+
+// ClientNetwork - 呼び出し元ごとの RateLimit の key。IPv6 は1つの利用者が持つ /64 をまとめて1つに数える
+func ClientNetwork(c *gin.Context) string {
+	addr, err := netip.ParseAddr(c.ClientIP())
+	if err != nil {
+		return c.ClientIP()
+	}
+	addr = addr.Unmap()
+	if addr.Is6() {
+		return netip.PrefixFrom(addr, 64).Masked().String()
+	}
+	return addr.String()
+}
+
+// RequireSecretToken - URLのパラメーター param（末尾の suffix は除く）が共有リンク・カレンダーのトークンの形でなければ
+// notFound で404を返す。RateLimit の前に置き、でたらめなトークンでカウンタを増やさせない
+func RequireSecretToken(param, suffix string, notFound error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !usecase.ValidSecretToken(strings.TrimSuffix(c.Param(param), suffix)) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": notFound.Error()})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitRouter(limit int, window time.Duration, middlewares ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handlers := append(middlewares,
+		RateLimit(limit, window, func(c *gin.Context) string { return c.Param("token") }),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	router.GET("/t/:token", handlers...)
+	return router
+}
+
+func get(router *gin.Engine, path string) int {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w.Code
+}
+
+func TestRateLimitLimitsEachKey(t *testing.T) {
+	router := newRateLimitRouter(2, time.Minute)
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if got := get(router, "/t/a"); got != want {
+			t.Fatalf("request %d status = %d, want %d", i+1, got, want)
+		}
+	}
+	if got := get(router, "/t/b"); got != http.StatusOK {
+		t.Errorf("other key status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestRateLimitKeepsActiveCounters(t *testing.T) {
+	router := newRateLimitRouter(1, time.Minute)
+
+	get(router, "/t/target")
+	// 期間中のカウンタで埋まっても捨てず、新しい key は数えられないため429にする
+	for i := 0; i < rateLimitMaxKeys-1; i++ {
+		get(router, "/t/k"+strconv.Itoa(i))
+	}
+	if got := get(router, "/t/overflow"); got != http.StatusTooManyRequests {
+		t.Errorf("new key status = %d, want %d", got, http.StatusTooManyRequests)
+	}
+	if got := get(router, "/t/target"); got != http.StatusTooManyRequests {
+		t.Errorf("limited key status = %d, want %d", got, http.StatusTooManyRequests)
+	}
+}
+
+func TestRateLimitReplacesExpiredCounters(t *testing.T) {
+	window := 50 * time.Millisecond
+	router := newRateLimitRouter(1, window)
+
+	for i := 0; i < rateLimitMaxKeys; i++ {
+		get(router, "/t/k"+strconv.Itoa(i))
+	}
+	time.Sleep(window)
+	if got := get(router, "/t/new"); got != http.StatusOK {
+		t.Errorf("new key after the window status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestClientNetwork(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for _, tt := range []struct {
+		remoteAddr string
+		want       string
+	}{
+		{"203.0.113.7:1234", "203.0.113.7"},
+		{"[2001:db8:1:2:3:4:5:6]:1234", "2001:db8:1:2::/64"},
+		{"[2001:db8:1:2:ffff::1]:1234", "2001:db8:1:2::/64"},
+		{"[::ffff:203.0.113.7]:1234", "203.0.113.7"},
+	} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.RemoteAddr = tt.remoteAddr
+		if got := ClientNetwork(c); got != tt.want {
+			t.Errorf("ClientNetwork(%s) = %q, want %q", tt.remoteAddr, got, tt.want)
+		}
+	}
+}
+
+func TestRequireSecretTokenRejectsMalformedTokens(t *testing.T) {
+	notFound := errors.New("share not found")
+	router := newRateLimitRouter(1, time.Minute, RequireSecretToken("token", ".ics", notFound))
+	valid := strings.Repeat("A", 42) + "_"
+
+	for _, token := range []string{"short", strings.Repeat("A", 44), strings.Repeat("A", 42) + "+", strings.Repeat("A", 42) + "="} {
+		if got := get(router, "/t/"+token); got != http.StatusNotFound {
+			t.Errorf("token %q status = %d, want %d", token, got, http.StatusNotFound)
+		}
+	}
+	if got := get(router, "/t/"+valid+".ics"); got != http.StatusOK {
+		t.Errorf("valid token status = %d, want %d", got, http.StatusOK)
+	}
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"strings"
+	"taine-api/domain"
 	"taine-api/handler"
 	"taine-api/infra"
+	"taine-api/infra/blob"
+	"taine-api/infra/notify"
+	"taine-api/infra/opengraph"
 	"taine-api/infra/postgres"
 	"taine-api/interface/middleware"
 	"taine-api/usecase"
 	"time"
+	_ "time/tzdata" // 組織のタイムゾーン解決のため、tzdataの無い実行環境でも動くよう埋め込む
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -31,6 +38,11 @@ func main() {
 		log.Fatal("Error connecting to database: ", err)
 	}
 
+	blobStore, err := blob.NewFromEnv()
+	if err != nil {
+		log.Fatal("Error initializing blob store: ", err)
+	}
+
 	router := gin.New()
 	router.Use(gin.Recovery(), gin.Logger())
 
@@ -38,7 +50,8 @@ func main() {
 	cfg := cors.Config{
 		AllowOrigins:     []string{allowed},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "If-Match", handler.SharePasswordHeader},
+		ExposeHeaders:    []string{"ETag"}, // 楽観的排他制御のバージョンをブラウザから読めるようにする
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour, // プリフライト結果を12時間キャッシュ
 	}
@@ -50,12 +63,50 @@ func main() {
 	membershipRepository := postgres.NewMembershipRepository(db.DB)
 	tweetRepository := postgres.NewTweetRepository(db.DB)
 	wishRepository := postgres.NewWishRepository(db.DB)
+	tagRepository := postgres.NewTagRepository(db.DB)
+	commentRepository := postgres.NewCommentRepository(db.DB)
+	voteRepository := postgres.NewVoteRepository(db.DB)
+	attachmentRepository := postgres.NewAttachmentRepository(db.DB)
+	linkRepository := postgres.NewLinkRepository(db.DB)
+	contributionRepository := postgres.NewContributionRepository(db.DB)
+	reminderRepository := postgres.NewReminderRepository(db.DB)
+	seriesRepository := postgres.NewWishSeriesRepository(db.DB)
+	listRepository := postgres.NewListRepository(db.DB)
+	shareLinkRepository := postgres.NewShareLinkRepository(db.DB)
+	calendarFeedRepository := postgres.NewCalendarFeedRepository(db.DB)
 
 	// サービスの初期化
 	userService := usecase.NewUserService(userRepository)
 	orgService := usecase.NewOrganizationSvc(orgRepository, userRepository, membershipRepository)
 	membershipService := usecase.NewMembershipSvc(membershipRepository, userRepository, orgRepository)
-	wishService := usecase.NewWishSvc(wishRepository, orgRepository)
+	wishService := usecase.NewWishSvc(wishRepository, orgRepository, tagRepository, voteRepository, membershipRepository, userRepository, listRepository, attachmentRepository, blobStore)
+	listService := usecase.NewListSvc(listRepository, orgRepository)
+	shareService := usecase.NewShareSvc(shareLinkRepository, orgRepository, listRepository, wishRepository)
+	calendarService := usecase.NewCalendarSvc(calendarFeedRepository, orgRepository, membershipRepository, wishRepository)
+	tagService := usecase.NewTagSvc(tagRepository, orgRepository)
+	commentService := usecase.NewCommentSvc(commentRepository, wishRepository, orgRepository, userRepository)
+	contributionService := usecase.NewContributionSvc(contributionRepository, wishRepository, orgRepository, userRepository)
+	attachmentService := usecase.NewAttachmentSvc(attachmentRepository, wishRepository, orgRepository, blobStore)
+	reminderService := usecase.NewReminderSvc(reminderRepository, wishRepository, orgRepository)
+	seriesService := usecase.NewSeriesSvc(seriesRepository, orgRepository, tagRepository, membershipRepository, listRepository)
+	policyService := usecase.NewPolicySvc(membershipRepository, userRepository, orgRepository)
+
+	// ゴミ箱の自動削除（組織ごとの保持期間を過ぎたWishを完全削除）
+	trashPurger := usecase.NewTrashPurger(wishRepository, orgRepository, attachmentRepository, blobStore)
+	go trashPurger.Run(context.Background(), time.Hour)
+
+	// リンクのプレビュー取得（追加時に即時、取りこぼしは1分ごとに拾う）
+	linkUnfurler := usecase.NewLinkUnfurler(linkRepository, opengraph.NewFetcher(opengraph.Options{}))
+	go linkUnfurler.Run(context.Background(), time.Minute)
+	linkService := usecase.NewLinkSvc(linkRepository, wishRepository, orgRepository, linkUnfurler)
+
+	// リマインダーの通知（組織のタイムゾーンで期日の○日前になったものを1分ごとに送る）
+	reminderScheduler := usecase.NewReminderScheduler(reminderRepository, wishRepository, notify.NewLogNotifier())
+	go reminderScheduler.Run(context.Background(), time.Minute)
+
+	// 繰り返しWishの次の回の作成（期間が始まった回・前の回が完了した繰り返しを1分ごとに進める）
+	seriesScheduler := usecase.NewSeriesScheduler(seriesRepository, tagRepository, membershipRepository, listRepository)
+	go seriesScheduler.Run(context.Background(), time.Minute)
 
 	// ハンドラーの初期化
 	webhookHandler := handler.NewWebhookHandler(userService, orgService, membershipService)
@@ -67,10 +118,35 @@ func main() {
 	tweetUsecase := usecase.NewTweetUsecase(tweetRepository, userRepository)
 	tweetHandler := handler.NewTweetHandler(tweetUsecase, userUsecase)
 
-	wishHandler := handler.NewWishHandler(wishService, userUsecase)
+	wishHandler := handler.NewWishHandler(wishService, userUsecase, policyService)
+	orgHandler := handler.NewOrganizationHandler(orgService)
+	tagHandler := handler.NewTagHandler(tagService)
+	listHandler := handler.NewListHandler(listService)
+	commentHandler := handler.NewCommentHandler(commentService, userUsecase)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, userUsecase)
+	linkHandler := handler.NewLinkHandler(linkService)
+	contributionHandler := handler.NewContributionHandler(contributionService, userUsecase)
+	reminderHandler := handler.NewReminderHandler(reminderService, userUsecase)
+	seriesHandler := handler.NewSeriesHandler(seriesService, userUsecase)
+	shareHandler := handler.NewShareHandler(shareService, userUsecase)
+	calendarHandler := handler.NewCalendarHandler(calendarService, userUsecase)
 
 	router.GET("/api/health", func(c *gin.Context) { c.JSON(200, gin.H{"message": "OK"}) })
 
+	// ローカル保存時の添付ファイル配信（署名付きURLで認可するためJWT不要）
+	if localStore, ok := blobStore.(*blob.LocalStore); ok {
+		router.GET(blob.LocalFilesPath+"/*key", gin.WrapH(localStore.Handler(blob.LocalFilesPath)))
+	}
+
+	// トークンで認可する公開URLは、呼び出し元ごとにも回数を制限する（多数のトークンを試す総当たりと、パスワード確認の負荷を抑える）
+	perClient := middleware.RateLimit(60, time.Minute, middleware.ClientNetwork)
+
+	// 共有リンクの公開ページ（トークンで認可するためJWT不要。パスワードの総当たりと負荷を抑えるためトークンごとに回数を制限する）
+	router.GET("/share/:token", middleware.RequireSecretToken("token", "", domain.ErrShareNotFound), perClient, middleware.RateLimit(30, time.Minute, func(c *gin.Context) string { return c.Param("token") }), shareHandler.GetShared)
+
+	// iCalendarの購読フィード（/calendar/:token.ics）。カレンダーアプリはBearerトークンを送れないため、URLのトークンで認可する
+	router.GET("/calendar/:token", middleware.RequireSecretToken("token", ".ics", domain.ErrCalendarFeedNotFound), perClient, middleware.RateLimit(30, time.Minute, func(c *gin.Context) string { return strings.TrimSuffix(c.Param("token"), ".ics") }), calendarHandler.GetCalendar)
+
 	api := router.Group("/api/v1", middleware.ClerkSessionAuth())
 	api.GET("/me", userHandler.GetUserBySubID)
 
@@ -82,15 +158,126 @@ func main() {
 	api.PUT("/tweets/:id", tweetHandler.UpdateTweet)
 	api.DELETE("/tweets/:id", tweetHandler.DeleteTweet)
 
-	// Wish routes
-	api.GET("/wishes", wishHandler.GetWishesForCurrentOrg) // JWTのorg_external_idを使用
-	api.POST("/wish", wishHandler.CreateWish)
-	api.GET("/wish/:id", wishHandler.GetWish)
-	api.PUT("/wish/:id", wishHandler.UpdateWish)
-	api.DELETE("/wish/:id", wishHandler.DeleteWish)
-	api.POST("/wish/:id/soft-delete", wishHandler.SoftDeleteWish)
-	api.POST("/wish/:id/restore", wishHandler.RestoreWish)
-	api.PATCH("/wish/:id/order", wishHandler.UpdateWishOrder)
+	// Wish routes（org_roleによる権限チェック付き）
+	can := func(action domain.Action) gin.HandlerFunc {
+		return middleware.RequirePermission(policyService, domain.ResourceWish, action)
+	}
+	api.GET("/wishes", can(domain.ActionRead), wishHandler.GetWishesForCurrentOrg) // JWTのorg_external_idを使用
+	api.GET("/wishes/search", can(domain.ActionRead), wishHandler.SearchWishes)
+	api.GET("/wishes/nearby", can(domain.ActionRead), wishHandler.GetNearbyWishes)
+	api.POST("/wishes/reorder", can(domain.ActionUpdate), wishHandler.ReorderWishes)
+	api.POST("/wishes/bulk", can(domain.ActionRead), wishHandler.BulkWishes) // 操作ごとの権限はハンドラーで確認する
+	api.GET("/wishes/trash", can(domain.ActionRead), wishHandler.GetTrash)
+	api.GET("/wishes/consensus", can(domain.ActionRead), wishHandler.GetConsensus)
+	api.GET("/wishes/progress", can(domain.ActionRead), contributionHandler.GetOrganizationProgress)
+	api.POST("/wish", can(domain.ActionCreate), wishHandler.CreateWish)
+	api.GET("/wish/:id", can(domain.ActionRead), wishHandler.GetWish)
+	api.PUT("/wish/:id", can(domain.ActionUpdate), wishHandler.UpdateWish)
+	api.PATCH("/wish/:id", can(domain.ActionUpdate), wishHandler.PatchWish)
+	api.DELETE("/wish/:id", can(domain.ActionDelete), wishHandler.DeleteWish)
+	api.POST("/wish/:id/soft-delete", can(domain.ActionSoftDelete), wishHandler.SoftDeleteWish)
+	api.POST("/wish/:id/restore", can(domain.ActionRestore), wishHandler.RestoreWish)
+	api.PATCH("/wish/:id/order", can(domain.ActionUpdate), wishHandler.UpdateWishOrder)
+	api.POST("/wish/:id/move", can(domain.ActionUpdate), wishHandler.MoveWish) // 別のリスト・組織への移動
+	api.POST("/wish/:id/copy", can(domain.ActionRead), wishHandler.CopyWish)   // コピー先の組織のメンバーかは WishSvc が確認する
+	api.GET("/wish/:id/revisions", can(domain.ActionRead), wishHandler.GetRevisions)
+	api.GET("/wish/:id/revisions/diff", can(domain.ActionRead), wishHandler.GetRevisionDiff)
+	api.POST("/wish/:id/revisions/:rev/revert", can(domain.ActionUpdate), wishHandler.RevertWish)
+	api.PUT("/wish/:id/vote", can(domain.ActionVote), wishHandler.VoteWish)
+	api.DELETE("/wish/:id/vote", can(domain.ActionVote), wishHandler.UnvoteWish)
+
+	// Wish status transitions
+	api.POST("/wish/:id/reopen", can(domain.ActionUpdate), wishHandler.TransitionWish(domain.WishStatusIdea))
+	api.POST("/wish/:id/plan", can(domain.ActionUpdate), wishHandler.TransitionWish(domain.WishStatusPlanned))
+	api.POST("/wish/:id/start", can(domain.ActionUpdate), wishHandler.TransitionWish(domain.WishStatusInProgress))
+	api.POST("/wish/:id/fulfill", can(domain.ActionUpdate), wishHandler.TransitionWish(domain.WishStatusFulfilled))
+	api.POST("/wish/:id/archive", can(domain.ActionUpdate), wishHandler.TransitionWish(domain.WishStatusArchived))
+
+	// Attachment routes（添付の追加・削除はWishの更新権限で行う）
+	api.GET("/wish/:id/attachments", can(domain.ActionRead), attachmentHandler.GetAttachments)
+	api.POST("/wish/:id/attachments", can(domain.ActionUpdate), attachmentHandler.UploadAttachment)
+	api.GET("/wish/:id/attachments/:attachment_id", can(domain.ActionRead), attachmentHandler.GetAttachment)
+	api.DELETE("/wish/:id/attachments/:attachment_id", can(domain.ActionUpdate), attachmentHandler.DeleteAttachment)
+
+	// Link routes
+	api.POST("/wish/:id/links", can(domain.ActionUpdate), linkHandler.CreateLink)
+	api.POST("/wish/:id/links/:link_id/refresh", can(domain.ActionUpdate), linkHandler.RefreshLink)
+	api.DELETE("/wish/:id/links/:link_id", can(domain.ActionUpdate), linkHandler.DeleteLink)
+
+	// Checklist routes（チェックで進捗・ステータスが変わるため項目の操作は更新権限）
+	api.GET("/wish/:id/checklist", can(domain.ActionRead), wishHandler.GetChecklist)
+	api.POST("/wish/:id/checklist", can(domain.ActionUpdate), wishHandler.CreateChecklistItem)
+	api.PATCH("/wish/:id/checklist/:item_id", can(domain.ActionUpdate), wishHandler.UpdateChecklistItem)
+	api.POST("/wish/:id/checklist/:item_id/move", can(domain.ActionUpdate), wishHandler.MoveChecklistItem)
+	api.DELETE("/wish/:id/checklist/:item_id", can(domain.ActionUpdate), wishHandler.DeleteChecklistItem)
+
+	// Contribution routes（他人の記録の削除可否は ContributionSvc が判定する）
+	api.GET("/wish/:id/progress", can(domain.ActionRead), contributionHandler.GetWishProgress)
+	api.GET("/wish/:id/contributions", can(domain.ActionRead), contributionHandler.GetContributions)
+	api.POST("/wish/:id/contributions", can(domain.ActionUpdate), contributionHandler.CreateContribution)
+	api.DELETE("/wish/:id/contributions/:contribution_id", can(domain.ActionUpdate), contributionHandler.DeleteContribution)
+
+	// Reminder routes（自分のリマインダーのみ操作するため閲覧権限で足りる）
+	api.GET("/wish/:id/reminders", can(domain.ActionRead), reminderHandler.GetReminders)
+	api.PUT("/wish/:id/reminders", can(domain.ActionRead), reminderHandler.SetReminders)
+
+	// Recurring wish routes（各回はふつうのWishとして /wish/:id で操作する）
+	api.GET("/wish-series", can(domain.ActionRead), seriesHandler.GetSeriesList)
+	api.POST("/wish-series", can(domain.ActionCreate), seriesHandler.CreateSeries)
+	api.GET("/wish-series/:id", can(domain.ActionRead), seriesHandler.GetSeries)
+	api.PUT("/wish-series/:id", can(domain.ActionUpdate), seriesHandler.UpdateSeries)
+	api.POST("/wish-series/:id/pause", can(domain.ActionUpdate), seriesHandler.TransitionSeries(domain.SeriesStatusPaused))
+	api.POST("/wish-series/:id/resume", can(domain.ActionUpdate), seriesHandler.TransitionSeries(domain.SeriesStatusActive))
+	api.POST("/wish-series/:id/stop", can(domain.ActionUpdate), seriesHandler.TransitionSeries(domain.SeriesStatusStopped))
+
+	// Comment routes
+	canComment := func(action domain.Action) gin.HandlerFunc {
+		return middleware.RequirePermission(policyService, domain.ResourceComment, action)
+	}
+	api.GET("/wish/:id/comments", canComment(domain.ActionRead), commentHandler.GetComments)
+	api.POST("/wish/:id/comments", canComment(domain.ActionCreate), commentHandler.CreateComment)
+	api.PUT("/wish/:id/comments/:comment_id", canComment(domain.ActionUpdate), commentHandler.UpdateComment)
+	api.DELETE("/wish/:id/comments/:comment_id", canComment(domain.ActionDelete), commentHandler.DeleteComment)
+
+	// Tag routes
+	canTag := func(action domain.Action) gin.HandlerFunc {
+		return middleware.RequirePermission(policyService, domain.ResourceTag, action)
+	}
+	api.GET("/tags", canTag(domain.ActionRead), tagHandler.GetTags)
+	api.POST("/tags", canTag(domain.ActionCreate), tagHandler.CreateTag)
+	api.PUT("/tags/:id", canTag(domain.ActionUpdate), tagHandler.UpdateTag)
+	api.POST("/tags/:id/merge", canTag(domain.ActionDelete), tagHandler.MergeTag)
+	api.DELETE("/tags/:id", canTag(domain.ActionDelete), tagHandler.DeleteTag)
+
+	// List routes（各リストのWishは GET /wishes?list=:id、リスト間の移動は /wish/:id/move と /wishes/reorder）
+	canList := func(action domain.Action) gin.HandlerFunc {
+		return middleware.RequirePermission(policyService, domain.ResourceList, action)
+	}
+	api.GET("/lists", canList(domain.ActionRead), listHandler.GetLists)
+	api.POST("/lists", canList(domain.ActionCreate), listHandler.CreateList)
+	api.POST("/lists/reorder", canList(domain.ActionUpdate), listHandler.ReorderLists)
+	api.GET("/lists/:id", canList(domain.ActionRead), listHandler.GetList)
+	api.PUT("/lists/:id", canList(domain.ActionUpdate), listHandler.UpdateList)
+	api.POST("/lists/:id/archive", canList(domain.ActionUpdate), listHandler.SetListArchived(true))
+	api.POST("/lists/:id/unarchive", canList(domain.ActionUpdate), listHandler.SetListArchived(false))
+	api.DELETE("/lists/:id", canList(domain.ActionDelete), listHandler.DeleteList)
+
+	// Calendar feed routes（メンバーごと・組織ごとの購読URL。rotate で作成・作り直し）
+	api.GET("/calendar-feed", can(domain.ActionRead), calendarHandler.GetCalendarFeed)
+	api.POST("/calendar-feed/rotate", can(domain.ActionRead), calendarHandler.RotateCalendarFeed)
+	api.DELETE("/calendar-feed", can(domain.ActionRead), calendarHandler.DeleteCalendarFeed)
+
+	// Share link routes（公開ページは /share/:token）
+	canShare := func(action domain.Action) gin.HandlerFunc {
+		return middleware.RequirePermission(policyService, domain.ResourceShare, action)
+	}
+	api.GET("/shares", canShare(domain.ActionRead), shareHandler.GetShareLinks)
+	api.POST("/shares", canShare(domain.ActionCreate), shareHandler.CreateShareLink)
+	api.POST("/shares/:id/revoke", canShare(domain.ActionDelete), shareHandler.RevokeShareLink)
+
+	// Organization settings routes
+	api.GET("/organization/settings", middleware.RequirePermission(policyService, domain.ResourceSettings, domain.ActionRead), orgHandler.GetSettings)
+	api.PUT("/organization/settings", middleware.RequirePermission(policyService, domain.ResourceSettings, domain.ActionManage), orgHandler.UpdateSettings)
 
 	router.Run(":8080")
 }
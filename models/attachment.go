@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishAttachment represents an image attached to a wish
+type WishAttachment struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID `gorm:"type:uuid;not null"`
+	UploadedBy     uuid.UUID `gorm:"type:uuid;not null"`
+	FileName       string    `gorm:"type:text;not null"`
+	ContentType    string    `gorm:"type:text;not null"`
+	Size           int64     `gorm:"type:bigint;not null"`
+	Width          int       `gorm:"type:int;not null"`
+	Height         int       `gorm:"type:int;not null"`
+	BlobKey        string    `gorm:"type:text;not null"`
+	ThumbnailKey   string    `gorm:"type:text;not null"`
+	CreatedAt      time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishAttachment model
+func (WishAttachment) TableName() string {
+	return "wish_attachments"
+}
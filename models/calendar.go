@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed represents a member's secret iCalendar subscription to an organization's dated wishes
+type CalendarFeed struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
+	TokenHash      string     `gorm:"type:text;not null;unique"` // トークンのSHA-256（hex）。トークン自体は保存しない
+	TokenPrefix    string     `gorm:"type:text;not null"`        // 見分けるためのトークンの先頭
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	RotatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"` // 最後にトークンを作り直した日時
+	LastFetchedAt  *time.Time `gorm:"type:timestamptz"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the CalendarFeed model
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
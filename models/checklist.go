@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishChecklistItem represents one ordered step under a wish
+type WishChecklistItem struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID  `gorm:"type:uuid;not null"`
+	Title          string     `gorm:"type:text;not null"`
+	Rank           string     `gorm:"type:text;not null"` // Wish内の並び順（LexoRank形式, COLLATE "C"）
+	AssigneeID     *uuid.UUID `gorm:"type:uuid"`
+	CompletedAt    *time.Time `gorm:"type:timestamptz"` // チェック済みの場合のみ
+	CompletedBy    *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishChecklistItem model
+func (WishChecklistItem) TableName() string {
+	return "wish_checklist_items"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishComment represents a comment (or a one-level reply) on a wish
+type WishComment struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID  `gorm:"type:uuid;not null"`
+	ParentID       *uuid.UUID `gorm:"type:uuid"` // 返信の場合のみ。返信への返信は不可
+	AuthorID       uuid.UUID  `gorm:"type:uuid;not null"`
+	Body           string     `gorm:"type:text;not null"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	EditedAt       *time.Time `gorm:"type:timestamptz"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishComment model
+func (WishComment) TableName() string {
+	return "wish_comments"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishContribution represents money a member set aside toward a wish
+type WishContribution struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID `gorm:"type:uuid;not null"`
+	MemberID       uuid.UUID `gorm:"type:uuid;not null"`
+	Amount         int64     `gorm:"type:bigint;not null"` // 通貨の最小単位。負の値は取り崩し
+	Currency       string    `gorm:"type:text;not null"`   // ISO 4217
+	Note           string    `gorm:"type:text;not null;default:''"`
+	ContributedAt  time.Time `gorm:"type:timestamptz;not null;default:now()"`
+	CreatedAt      time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishContribution model
+func (WishContribution) TableName() string {
+	return "wish_contributions"
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishLink represents a URL attached to a wish with its cached OpenGraph preview
+type WishLink struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID  `gorm:"type:uuid;not null"`
+	URL            string     `gorm:"type:text;not null"`
+	Status         string     `gorm:"type:text;not null;default:'pending'"` // 'pending'|'ready'|'failed'
+	Title          string     `gorm:"type:text;not null;default:''"`
+	Description    string     `gorm:"type:text;not null;default:''"`
+	ImageURL       string     `gorm:"type:text;not null;default:''"`
+	SiteName       string     `gorm:"type:text;not null;default:''"`
+	FetchError     string     `gorm:"type:text;not null;default:''"`
+	FetchedAt      *time.Time `gorm:"type:timestamptz"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishLink model
+func (WishLink) TableName() string {
+	return "wish_links"
+}
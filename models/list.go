@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// List represents a named list (board) of wishes
+type List struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	Name           string     `gorm:"type:text;not null"`
+	Icon           string     `gorm:"type:text;not null;default:''"`
+	Rank           string     `gorm:"type:text;not null"`                  // リストの並び順（LexoRank形式, COLLATE "C"）
+	IsDefault      bool       `gorm:"type:boolean;not null;default:false"` // list_id を指定せずに作ったWishが入るリスト（組織に1つ）
+	ArchivedAt     *time.Time `gorm:"type:timestamptz"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the List model
+func (List) TableName() string {
+	return "wish_lists"
+}
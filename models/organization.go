@@ -8,12 +8,14 @@ import (
 
 // Organization represents an organization in the system
 type Organization struct {
-	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ExternalID string     `gorm:"type:text;not null;uniqueIndex"`
-	Name       string     `gorm:"type:text;not null"`
-	CreatedAt  time.Time  `gorm:"type:timestamptz;not null;default:now()"`
-	UpdatedAt  time.Time  `gorm:"type:timestamptz;not null;default:now()"`
-	DeletedAt  *time.Time `gorm:"type:timestamptz;index"`
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ExternalID         string     `gorm:"type:text;not null;uniqueIndex"`
+	Name               string     `gorm:"type:text;not null"`
+	TrashRetentionDays int        `gorm:"type:int;not null;default:30"`     // ゴミ箱のWishを完全削除するまでの日数（0は無期限）
+	Timezone           string     `gorm:"type:text;not null;default:'UTC'"` // 期日・リマインダーの計算に使うIANAタイムゾーン
+	CreatedAt          time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt          time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	DeletedAt          *time.Time `gorm:"type:timestamptz;index"`
 }
 
 // TableName returns the table name for the Organization model
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishReminder represents a member's reminder on a wish, due offset_days before its target date
+type WishReminder struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID  `gorm:"type:uuid;not null"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
+	OffsetDays     int        `gorm:"type:int;not null"`
+	SentFor        *time.Time `gorm:"type:date"` // 最後に通知した時点の期日（期日が変われば再通知）
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishReminder model
+func (WishReminder) TableName() string {
+	return "wish_reminders"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishRevision represents one recorded change of a wish
+type WishRevision struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	WishID         uuid.UUID  `gorm:"type:uuid;not null"`
+	Number         int        `gorm:"type:int;not null"` // Wishごとの連番（1から）
+	ActorID        *uuid.UUID `gorm:"type:uuid"`         // 履歴導入前からあるWishの起点はNULL
+	Snapshot       string     `gorm:"type:jsonb;not null"`
+	Changes        string     `gorm:"type:jsonb;not null"`
+	RevertedFrom   *int       `gorm:"type:int"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Wish Wish `gorm:"foreignKey:WishID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishRevision model
+func (WishRevision) TableName() string {
+	return "wish_revisions"
+}
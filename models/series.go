@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishSeries represents the template of a recurring wish
+type WishSeries struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	Title          string     `gorm:"type:text;not null"`
+	Note           string     `gorm:"type:text;not null;default:''"`
+	PriceAmount    *int64     `gorm:"type:bigint"`
+	PriceCurrency  *string    `gorm:"type:text"`
+	TagIDs         string     `gorm:"type:jsonb;not null;default:'[]'"` // 作成するWishに付けるタグ（削除済みのタグは付けない）
+	AssigneeID     *uuid.UUID `gorm:"type:uuid"`
+	ListID         *uuid.UUID `gorm:"type:uuid"`          // 各回を作るリスト。NULLは既定のリスト
+	Rule           string     `gorm:"type:text;not null"` // RRULE（FREQ=...;INTERVAL=...）
+	StartDate      time.Time  `gorm:"type:date;not null"`
+	Status         string     `gorm:"type:text;not null;default:'active'"`
+	NextDate       *time.Time `gorm:"type:date"` // まだWishを作っていない次の回。ルールが終わるとNULL
+	LastDate       *time.Time `gorm:"type:date"`
+	InstanceCount  int        `gorm:"type:int;not null;default:0"`
+	CreatedBy      uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the WishSeries model
+func (WishSeries) TableName() string {
+	return "wish_series"
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLink represents a public read-only link to an organization's wishes
+type ShareLink struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	ListID         *uuid.UUID `gorm:"type:uuid"`                     // 共有するリスト。NULLは全てのリスト
+	TokenHash      string     `gorm:"type:text;not null;unique"`     // トークンのSHA-256（hex）。トークン自体は保存しない
+	TokenPrefix    string     `gorm:"type:text;not null"`            // 一覧で見分けるためのトークンの先頭
+	PasswordHash   string     `gorm:"type:text;not null;default:''"` // 空はパスワード無し
+	ExpiresAt      *time.Time `gorm:"type:timestamptz"`
+	RevokedAt      *time.Time `gorm:"type:timestamptz"`
+	ViewCount      int64      `gorm:"type:bigint;not null;default:0"`
+	LastViewedAt   *time.Time `gorm:"type:timestamptz"`
+	CreatedBy      uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the ShareLink model
+func (ShareLink) TableName() string {
+	return "share_links"
+}
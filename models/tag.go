@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag represents an organization-scoped tag for wishes
+type Tag struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	Name           string    `gorm:"type:text;not null"`
+	Color          string    `gorm:"type:text;not null;default:''"`
+	CreatedAt      time.Time `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt      time.Time `gorm:"type:timestamptz;not null;default:now()"`
+
+	// Relations
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the table name for the Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// WishTag represents the many-to-many link between wishes and tags
+type WishTag struct {
+	WishID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TagID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// TableName returns the table name for the WishTag model
+func (WishTag) TableName() string {
+	return "wish_tags"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishVote represents a member's vote on a wish (one per member per wish)
+type WishVote struct {
+	WishID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Value     int       `gorm:"type:smallint;not null"` // 1 | -1
+	CreatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+// TableName returns the table name for the WishVote model
+func (WishVote) TableName() string {
+	return "wish_votes"
+}
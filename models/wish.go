@@ -10,12 +10,36 @@ import (
 type Wish struct {
 	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	ListID         uuid.UUID  `gorm:"type:uuid;not null"` // 所属するリスト（wish_lists）。rank はリスト内の並び順
 	Title          string     `gorm:"type:text;not null"`
 	Note           string     `gorm:"type:text;not null;default:''"`
 	OrderNo        int        `gorm:"type:int;not null;default:0"`
+	Rank           string     `gorm:"type:text;not null"` // 並び順（LexoRank形式, COLLATE "C"）
+	Status         string     `gorm:"type:text;not null;default:'idea'"`
+	Score          int        `gorm:"type:int;not null;default:0"` // wish_votes.value の合計（投票時に更新）
+	PriceAmount    *int64     `gorm:"type:bigint"`                 // 通貨の最小単位（円, セント）
+	PriceCurrency  *string    `gorm:"type:text"`                   // ISO 4217
+	TargetDate     *time.Time `gorm:"type:date"`                   // 期日（組織のタイムゾーンでの日付）
+	AssigneeID     *uuid.UUID `gorm:"type:uuid"`
+	Version        int        `gorm:"type:int;not null;default:1"` // 編集ごとに+1（If-Matchの比較に使う）
+	SeriesID       *uuid.UUID `gorm:"type:uuid"`                   // 繰り返しから作られたWishの場合、元のwish_series
+	FulfilledAt    *time.Time `gorm:"type:timestamptz"`
+	FulfilledBy    *uuid.UUID `gorm:"type:uuid"`
 	CreatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
 	UpdatedAt      time.Time  `gorm:"type:timestamptz;not null;default:now()"`
 	DeletedAt      *time.Time `gorm:"type:timestamptz;index"`
+	DeletedBy      *uuid.UUID `gorm:"type:uuid"`
+
+	// 別の組織からコピー・移動して作られたWishの場合、元のWishと組織（origin_kind: copy | move）
+	OriginWishID         *uuid.UUID `gorm:"type:uuid"`
+	OriginOrganizationID *uuid.UUID `gorm:"type:uuid"`
+	OriginKind           *string    `gorm:"type:text"`
+
+	// 場所（レストラン・旅行先など）。緯度・経度はWGS 84の度で、両方あるか両方無いか
+	PlaceName    *string  `gorm:"type:text"`
+	PlaceAddress *string  `gorm:"type:text"`
+	PlaceLat     *float64 `gorm:"type:double precision"`
+	PlaceLng     *float64 `gorm:"type:double precision"`
 
 	// Relations
 	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"taine-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentSvc - Wishへの画像添付。ダウンロードはBlobStoreの署名付きURLで行う
+type AttachmentSvc interface {
+	UploadAttachment(ctx context.Context, orgExternalID string, wishID, uploaderID uuid.UUID, upload AttachmentUpload) (*domain.Attachment, error)
+	ListAttachments(ctx context.Context, orgExternalID string, wishID uuid.UUID) ([]*domain.Attachment, error)
+	GetAttachment(ctx context.Context, orgExternalID string, wishID, attachmentID uuid.UUID) (*domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, orgExternalID string, wishID, attachmentID uuid.UUID) error
+}
+
+// AttachmentUpload - アップロードされたファイル
+type AttachmentUpload struct {
+	FileName string
+	Content  io.Reader
+}
+
+const (
+	// MaxAttachmentSize - 添付1件あたりの最大バイト数
+	MaxAttachmentSize = 10 << 20
+	// AttachmentURLTTL - 署名付きURLの有効期間
+	AttachmentURLTTL = 15 * time.Minute
+	maxFileNameLen   = 255
+)
+
+type attachmentSvc struct {
+	attachmentRepository domain.AttachmentRepository
+	wishRepository       domain.WishRepository
+	orgRepository        domain.OrganizationRepository
+	blobStore            domain.BlobStore
+}
+
+func NewAttachmentSvc(
+	attachmentRepository domain.AttachmentRepository,
+	wishRepository domain.WishRepository,
+	orgRepository domain.OrganizationRepository,
+	blobStore domain.BlobStore,
+) AttachmentSvc {
+	return &attachmentSvc{
+		attachmentRepository: attachmentRepository,
+		wishRepository:       wishRepository,
+		orgRepository:        orgRepository,
+		blobStore:            blobStore,
+	}
+}
+
+// findAttachment - Wishに属する添付を取得
+func (s *attachmentSvc) findAttachment(ctx context.Context, wish *domain.Wish, attachmentID uuid.UUID) (*domain.Attachment, error) {
+	attachment, err := s.attachmentRepository.FindByID(ctx, wish.OrganizationID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil || attachment.WishID != wish.ID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// signURLs - 本体とサムネイルの署名付きURLを設定する
+func (s *attachmentSvc) signURLs(ctx context.Context, attachments ...*domain.Attachment) error {
+	expiresAt := time.Now().Add(AttachmentURLTTL)
+	for _, attachment := range attachments {
+		url, err := s.blobStore.SignedURL(ctx, attachment.BlobKey, AttachmentURLTTL)
+		if err != nil {
+			return err
+		}
+		thumbnailURL, err := s.blobStore.SignedURL(ctx, attachment.ThumbnailKey, AttachmentURLTTL)
+		if err != nil {
+			return err
+		}
+		attachment.URL = url
+		attachment.ThumbnailURL = thumbnailURL
+		attachment.URLExpiresAt = expiresAt
+	}
+	return nil
+}
+
+// sanitizeFileName - パス区切りや制御文字を除いた表示用のファイル名
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == "" {
+		return "image"
+	}
+	if runes := []rune(name); len(runes) > maxFileNameLen {
+		name = string(runes[:maxFileNameLen])
+	}
+	return name
+}
+
+func (s *attachmentSvc) UploadAttachment(ctx context.Context, orgExternalID string, wishID, uploaderID uuid.UUID, upload AttachmentUpload) (*domain.Attachment, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 上限+1バイトまで読み、超えていればエラー
+	data, err := io.ReadAll(io.LimitReader(upload.Content, MaxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	// クライアント申告のContent-Typeは信用せず、中身から判定する
+	contentType := http.DetectContentType(data)
+	if _, ok := attachmentFormats[contentType]; !ok {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+	width, height, thumb, thumbType, err := thumbnail(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	key := domain.AttachmentBlobKey(wish.OrganizationID, wish.ID, id)
+	thumbKey := key + "_thumb"
+
+	if err := s.blobStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, err
+	}
+	if err := s.blobStore.Put(ctx, thumbKey, bytes.NewReader(thumb), int64(len(thumb)), thumbType); err != nil {
+		deleteBlobs(ctx, s.blobStore, key)
+		return nil, err
+	}
+
+	created, err := s.attachmentRepository.Create(ctx, &domain.Attachment{
+		ID:             id,
+		OrganizationID: wish.OrganizationID,
+		WishID:         wish.ID,
+		UploadedBy:     uploaderID,
+		FileName:       sanitizeFileName(upload.FileName),
+		ContentType:    contentType,
+		Size:           int64(len(data)),
+		Width:          width,
+		Height:         height,
+		BlobKey:        key,
+		ThumbnailKey:   thumbKey,
+	})
+	if err != nil {
+		deleteBlobs(ctx, s.blobStore, key, thumbKey)
+		return nil, err
+	}
+	if err := s.signURLs(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *attachmentSvc) ListAttachments(ctx context.Context, orgExternalID string, wishID uuid.UUID) ([]*domain.Attachment, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepository.FindByWishID(ctx, wish.OrganizationID, wish.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.signURLs(ctx, attachments...); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (s *attachmentSvc) GetAttachment(ctx context.Context, orgExternalID string, wishID, attachmentID uuid.UUID) (*domain.Attachment, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.findAttachment(ctx, wish, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.signURLs(ctx, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (s *attachmentSvc) DeleteAttachment(ctx context.Context, orgExternalID string, wishID, attachmentID uuid.UUID) error {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.findAttachment(ctx, wish, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.attachmentRepository.Delete(ctx, wish.OrganizationID, attachment.ID); err != nil {
+		return err
+	}
+
+	// 行を消した後はBlobが残っても参照されないため、失敗はログのみ
+	deleteBlobs(ctx, s.blobStore, attachment.BlobKey, attachment.ThumbnailKey)
+	return nil
+}
+
+// deleteBlobs - Blobを削除する。失敗してもエラーにはしない
+func deleteBlobs(ctx context.Context, store domain.BlobStore, keys ...string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("attachment: failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// attachmentBlobKeys - 添付の元画像とサムネイルのキー
+func attachmentBlobKeys(attachments []*domain.Attachment) []string {
+	keys := make([]string, 0, 2*len(attachments))
+	for _, attachment := range attachments {
+		keys = append(keys, attachment.BlobKey, attachment.ThumbnailKey)
+	}
+	return keys
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"taine-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// CalendarPastDays - フィードに含める過去の期日の日数（それより古いWishはカレンダーから消える）
+	CalendarPastDays = 365
+	// MaxCalendarEvents - フィードに含めるWishの上限。今日以降の期日を優先し、残りを直近の過去で埋める
+	MaxCalendarEvents = 1000
+)
+
+// CalendarSvc - 期日のあるWishをカレンダーアプリで購読するためのiCalendarフィード
+type CalendarSvc interface {
+	// GetFeed - 呼び出し元のフィードを取得。無い場合は domain.ErrCalendarFeedNotFound
+	GetFeed(ctx context.Context, orgExternalID string, userID uuid.UUID) (*domain.CalendarFeed, error)
+	// RotateFeed - フィードを作る、または新しいトークンに作り直す。トークンはハッシュだけを保存するため、ここでしか取得できない
+	RotateFeed(ctx context.Context, orgExternalID string, userID uuid.UUID) (*domain.CalendarFeed, string, error)
+	DeleteFeed(ctx context.Context, orgExternalID string, userID uuid.UUID) error
+	// FeedWishes - トークンのフィードに載せるWishを取得する。認証なしの経路から呼ばれる
+	FeedWishes(ctx context.Context, token string) (*domain.CalendarWishes, error)
+}
+
+type calendarSvc struct {
+	feedRepository       domain.CalendarFeedRepository
+	orgRepository        domain.OrganizationRepository
+	membershipRepository domain.MembershipRepository
+	wishRepository       domain.WishRepository
+}
+
+func NewCalendarSvc(
+	feedRepository domain.CalendarFeedRepository,
+	orgRepository domain.OrganizationRepository,
+	membershipRepository domain.MembershipRepository,
+	wishRepository domain.WishRepository,
+) CalendarSvc {
+	return &calendarSvc{
+		feedRepository:       feedRepository,
+		orgRepository:        orgRepository,
+		membershipRepository: membershipRepository,
+		wishRepository:       wishRepository,
+	}
+}
+
+func (s *calendarSvc) GetFeed(ctx context.Context, orgExternalID string, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := s.feedRepository.FindByUserAndOrg(ctx, userID, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+	return feed, nil
+}
+
+func (s *calendarSvc) RotateFeed(ctx context.Context, orgExternalID string, userID uuid.UUID) (*domain.CalendarFeed, string, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+	feed, err := s.feedRepository.Rotate(ctx, userID, org.ID, hashSecretToken(token), token[:secretTokenPrefixLen])
+	if err != nil {
+		return nil, "", err
+	}
+	return feed, token, nil
+}
+
+func (s *calendarSvc) DeleteFeed(ctx context.Context, orgExternalID string, userID uuid.UUID) error {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return err
+	}
+	return s.feedRepository.DeleteByUserAndOrg(ctx, userID, org.ID)
+}
+
+func (s *calendarSvc) FeedWishes(ctx context.Context, token string) (*domain.CalendarWishes, error) {
+	if token == "" {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+	feed, err := s.feedRepository.FindByTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+
+	// 組織を抜けたメンバー・削除された組織のフィードは見つからない扱いにする
+	org, err := s.orgRepository.FindByID(ctx, feed.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+	member, err := s.membershipRepository.FindByUserAndOrg(ctx, feed.UserID, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+
+	now := time.Now()
+	wishes, err := s.calendarWishes(ctx, org.ID, org.Today(now))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachTags(ctx, wishes); err != nil {
+		return nil, err
+	}
+
+	if err := s.feedRepository.RecordFetch(ctx, feed.ID, now); err != nil {
+		return nil, err
+	}
+	return &domain.CalendarWishes{OrganizationName: org.Name, Wishes: wishes}, nil
+}
+
+// calendarWishes - 全てのリストの、アーカイブ以外の期日のあるWish（ゴミ箱を除く）を期日順に返す
+// 上限で今後の予定が切れないよう、今日以降を近い順に先に取り、残りの枠を過去の期日の新しい順で埋める
+func (s *calendarSvc) calendarWishes(ctx context.Context, organizationID uuid.UUID, today time.Time) ([]*domain.Wish, error) {
+	statuses := append(append([]domain.WishStatus{}, domain.OpenWishStatuses...), domain.WishStatusFulfilled)
+	upcoming, err := s.wishRepository.List(ctx, organizationID, domain.WishListQuery{
+		Limit:          MaxCalendarEvents,
+		Sort:           domain.WishSortTargetDate,
+		Order:          domain.SortAsc,
+		Statuses:       statuses,
+		TargetDateFrom: &today,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(upcoming) >= MaxCalendarEvents {
+		return upcoming, nil
+	}
+
+	from := today.AddDate(0, 0, -CalendarPastDays)
+	past, err := s.wishRepository.List(ctx, organizationID, domain.WishListQuery{
+		Limit:            MaxCalendarEvents - len(upcoming),
+		Sort:             domain.WishSortTargetDate,
+		Order:            domain.SortDesc,
+		Statuses:         statuses,
+		TargetDateFrom:   &from,
+		TargetDateBefore: &today,
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(past)
+	return append(past, upcoming...), nil
+}
+
+// attachTags - 予定のカテゴリにするタグを読み込む
+func (s *calendarSvc) attachTags(ctx context.Context, wishes []*domain.Wish) error {
+	if len(wishes) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(wishes))
+	for i, wish := range wishes {
+		ids[i] = wish.ID
+	}
+	tagsByWish, err := s.wishRepository.FindTagsByWishIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, wish := range wishes {
+		wish.Tags = tagsByWish[wish.ID]
+	}
+	return nil
+}
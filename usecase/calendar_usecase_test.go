@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+type fakeCalendarFeedRepository struct {
+	domain.CalendarFeedRepository
+	feed *domain.CalendarFeed
+}
+
+func (r *fakeCalendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	if tokenHash != r.feed.TokenHash {
+		return nil, nil
+	}
+	return r.feed, nil
+}
+
+func (r *fakeCalendarFeedRepository) RecordFetch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+type fakeMembershipRepository struct {
+	domain.MembershipRepository
+	member *domain.OrganizationMember
+}
+
+func (r *fakeMembershipRepository) FindByUserAndOrg(ctx context.Context, userID, orgID uuid.UUID) (*domain.OrganizationMember, error) {
+	return r.member, nil
+}
+
+// datedWishRepository - 期日の範囲・向き・件数だけを postgres の実装と同じように扱う WishRepository
+type datedWishRepository struct {
+	domain.WishRepository
+	wishes []*domain.Wish
+}
+
+func (r *datedWishRepository) List(ctx context.Context, organizationID uuid.UUID, query domain.WishListQuery) ([]*domain.Wish, error) {
+	var matched []*domain.Wish
+	for _, wish := range r.wishes {
+		if query.TargetDateFrom != nil && wish.TargetDate.Before(*query.TargetDateFrom) {
+			continue
+		}
+		if query.TargetDateBefore != nil && !wish.TargetDate.Before(*query.TargetDateBefore) {
+			continue
+		}
+		matched = append(matched, wish)
+	}
+	slices.SortFunc(matched, func(a, b *domain.Wish) int { return a.TargetDate.Compare(*b.TargetDate) })
+	if query.Order == domain.SortDesc {
+		slices.Reverse(matched)
+	}
+	return matched[:min(len(matched), query.Limit)], nil
+}
+
+func (r *datedWishRepository) FindTagsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*domain.Tag, error) {
+	return map[uuid.UUID][]*domain.Tag{}, nil
+}
+
+// feedWishes - 今日から offsets 日ずらした期日のWishを持つ組織のフィードを取得し、期日を今日からの日数で返す
+func feedWishes(t *testing.T, offsets []int) []int {
+	t.Helper()
+	org := &domain.Organization{ID: uuid.New(), ExternalID: "org_a", Name: "Family"}
+	today := org.Today(time.Now())
+
+	wishes := &datedWishRepository{}
+	for _, offset := range offsets {
+		date := today.AddDate(0, 0, offset)
+		wishes.wishes = append(wishes.wishes, &domain.Wish{ID: uuid.New(), OrganizationID: org.ID, TargetDate: &date})
+	}
+	token := "calendar-token"
+	feed := &domain.CalendarFeed{ID: uuid.New(), UserID: uuid.New(), OrganizationID: org.ID, TokenHash: hashSecretToken(token)}
+	svc := NewCalendarSvc(
+		&fakeCalendarFeedRepository{feed: feed},
+		&fakeOrganizationRepository{orgs: map[string]*domain.Organization{org.ExternalID: org}},
+		&fakeMembershipRepository{member: &domain.OrganizationMember{}},
+		wishes,
+	)
+
+	got, err := svc.FeedWishes(context.Background(), token)
+	if err != nil {
+		t.Fatalf("FeedWishes() error = %v", err)
+	}
+	days := make([]int, len(got.Wishes))
+	for i, wish := range got.Wishes {
+		days[i] = int(wish.TargetDate.Sub(today).Hours() / 24)
+	}
+	return days
+}
+
+func TestCalendarFeedWishesOrdersPastAndUpcoming(t *testing.T) {
+	got := feedWishes(t, []int{3, -CalendarPastDays - 1, -2, 0, -CalendarPastDays, 1})
+	if want := []int{-CalendarPastDays, -2, 0, 1, 3}; !slices.Equal(got, want) {
+		t.Errorf("days = %v, want %v", got, want)
+	}
+}
+
+func TestCalendarFeedWishesKeepsUpcomingWhenFull(t *testing.T) {
+	// 過去の期日だけで上限が埋まる場合も、今後の予定は落とさず、残りの枠を直近の過去で埋める
+	var offsets []int
+	for i := 0; i < MaxCalendarEvents; i++ {
+		offsets = append(offsets, -(i%CalendarPastDays + 1))
+	}
+	offsets = append(offsets, 0, 30)
+
+	got := feedWishes(t, offsets)
+	if len(got) != MaxCalendarEvents {
+		t.Fatalf("got %d wishes, want %d", len(got), MaxCalendarEvents)
+	}
+	if !slices.IsSorted(got) {
+		t.Errorf("days are not in date order: %v", got)
+	}
+	if tail := got[len(got)-3:]; !slices.Equal(tail, []int{-1, 0, 30}) {
+		t.Errorf("last days = %v, want [-1 0 30]", tail)
+	}
+	if got[0] == -CalendarPastDays {
+		t.Errorf("kept the oldest past day instead of the most recent ones")
+	}
+}
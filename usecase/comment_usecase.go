@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"taine-api/domain"
+	"taine-api/models"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// CommentSvc - Wishへのコメント。Wishと同じく呼び出し元の組織にスコープされる
+type CommentSvc interface {
+	CreateComment(ctx context.Context, orgExternalID string, wishID, authorID uuid.UUID, parentID *uuid.UUID, body string) (*domain.Comment, error)
+	ListComments(ctx context.Context, orgExternalID string, wishID uuid.UUID, query domain.CommentListQuery) (*domain.CommentPage, error)
+	// UpdateComment - 本文を編集できるのは投稿者のみ
+	UpdateComment(ctx context.Context, orgExternalID string, wishID, commentID, actorID uuid.UUID, body string) (*domain.Comment, error)
+	// DeleteComment - 投稿者またはadmin以上が削除できる。返信も合わせて削除される
+	DeleteComment(ctx context.Context, orgExternalID string, wishID, commentID, actorID uuid.UUID, actorRole string) error
+}
+
+const (
+	DefaultCommentPageSize = 50
+	MaxCommentPageSize     = 200
+	MaxCommentBodyLen      = 4000
+)
+
+type commentSvc struct {
+	commentRepository domain.CommentRepository
+	wishRepository    domain.WishRepository
+	orgRepository     domain.OrganizationRepository
+	userRepository    domain.UserRepository
+}
+
+func NewCommentSvc(
+	commentRepository domain.CommentRepository,
+	wishRepository domain.WishRepository,
+	orgRepository domain.OrganizationRepository,
+	userRepository domain.UserRepository,
+) CommentSvc {
+	return &commentSvc{
+		commentRepository: commentRepository,
+		wishRepository:    wishRepository,
+		orgRepository:     orgRepository,
+		userRepository:    userRepository,
+	}
+}
+
+// findComment - Wishに属するコメントを取得
+func (s *commentSvc) findComment(ctx context.Context, wish *domain.Wish, commentID uuid.UUID) (*domain.Comment, error) {
+	comment, err := s.commentRepository.FindByID(ctx, wish.OrganizationID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || comment.WishID != wish.ID {
+		return nil, domain.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// attachAuthors - 投稿者をまとめて読み込んで設定する（退会済みのユーザーはnilのまま）
+func (s *commentSvc) attachAuthors(ctx context.Context, comments ...*domain.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(comments))
+	ids := make([]uuid.UUID, 0, len(comments))
+	for _, comment := range comments {
+		if !seen[comment.AuthorID] {
+			seen[comment.AuthorID] = true
+			ids = append(ids, comment.AuthorID)
+		}
+	}
+
+	users, err := s.userRepository.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	usersByID := make(map[uuid.UUID]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	for _, comment := range comments {
+		comment.Author = usersByID[comment.AuthorID]
+	}
+	return nil
+}
+
+// normalizeCommentBody - 前後の空白を除き、空・長すぎる本文を弾く
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > MaxCommentBodyLen {
+		return "", domain.ErrInvalidComment
+	}
+	return body, nil
+}
+
+func (s *commentSvc) CreateComment(ctx context.Context, orgExternalID string, wishID, authorID uuid.UUID, parentID *uuid.UUID, body string) (*domain.Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 返信は同じWishのトップレベルのコメントにのみ付けられる
+	if parentID != nil {
+		parent, err := s.findComment(ctx, wish, *parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ParentID != nil {
+			return nil, domain.ErrReplyDepth
+		}
+	}
+
+	created, err := s.commentRepository.Create(ctx, &domain.Comment{
+		OrganizationID: wish.OrganizationID,
+		WishID:         wish.ID,
+		ParentID:       parentID,
+		AuthorID:       authorID,
+		Body:           body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachAuthors(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *commentSvc) ListComments(ctx context.Context, orgExternalID string, wishID uuid.UUID, query domain.CommentListQuery) (*domain.CommentPage, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = DefaultCommentPageSize
+	}
+	if query.Limit > MaxCommentPageSize {
+		query.Limit = MaxCommentPageSize
+	}
+	if query.ParentID != nil {
+		if _, err := s.findComment(ctx, wish, *query.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 次ページの有無を判定するため1件多く取得
+	limit := query.Limit
+	query.Limit = limit + 1
+	comments, err := s.commentRepository.List(ctx, wish.OrganizationID, wish.ID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		page.NextCursor = domain.NewCommentCursor(page.Comments[limit-1]).Encode()
+	}
+	if err := s.attachAuthors(ctx, page.Comments...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (s *commentSvc) UpdateComment(ctx context.Context, orgExternalID string, wishID, commentID, actorID uuid.UUID, body string) (*domain.Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.findComment(ctx, wish, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actorID {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	updated, err := s.commentRepository.UpdateBody(ctx, wish.OrganizationID, comment.ID, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachAuthors(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *commentSvc) DeleteComment(ctx context.Context, orgExternalID string, wishID, commentID, actorID uuid.UUID, actorRole string) error {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.findComment(ctx, wish, commentID)
+	if err != nil {
+		return err
+	}
+
+	// 他人のコメントはadmin以上のみ削除できる（モデレーション用）
+	if comment.AuthorID != actorID && roleRank[NormalizeRole(actorRole)] < roleRank[models.RoleAdmin] {
+		return domain.ErrPermissionDenied
+	}
+
+	return s.commentRepository.Delete(ctx, wish.OrganizationID, comment.ID)
+}
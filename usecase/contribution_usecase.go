@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"taine-api/domain"
+	"taine-api/models"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ContributionSvc - Wishのための積立の記録と、予算に対する進捗
+type ContributionSvc interface {
+	AddContribution(ctx context.Context, orgExternalID string, wishID, memberID uuid.UUID, input ContributionInput) (*domain.Contribution, error)
+	ListContributions(ctx context.Context, orgExternalID string, wishID uuid.UUID) ([]*domain.Contribution, error)
+	// DeleteContribution - 記録したメンバー本人またはadmin以上が削除できる
+	DeleteContribution(ctx context.Context, orgExternalID string, wishID, contributionID, actorID uuid.UUID, actorRole string) error
+	GetWishProgress(ctx context.Context, orgExternalID string, wishID uuid.UUID) (*domain.WishProgress, error)
+	GetOrganizationProgress(ctx context.Context, orgExternalID string) (*domain.OrganizationProgress, error)
+}
+
+// ContributionInput - 積立の記録で受け付ける項目
+type ContributionInput struct {
+	Amount domain.Money
+	Note   string
+	// ContributedAt - 省略時は現在時刻
+	ContributedAt *time.Time
+}
+
+const MaxContributionNoteLen = 500
+
+type contributionSvc struct {
+	contributionRepository domain.ContributionRepository
+	wishRepository         domain.WishRepository
+	orgRepository          domain.OrganizationRepository
+	userRepository         domain.UserRepository
+}
+
+func NewContributionSvc(
+	contributionRepository domain.ContributionRepository,
+	wishRepository domain.WishRepository,
+	orgRepository domain.OrganizationRepository,
+	userRepository domain.UserRepository,
+) ContributionSvc {
+	return &contributionSvc{
+		contributionRepository: contributionRepository,
+		wishRepository:         wishRepository,
+		orgRepository:          orgRepository,
+		userRepository:         userRepository,
+	}
+}
+
+// attachMembers - 記録したメンバーをまとめて読み込んで設定する（退会済みのユーザーはnilのまま）
+func (s *contributionSvc) attachMembers(ctx context.Context, contributions ...*domain.Contribution) error {
+	if len(contributions) == 0 {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(contributions))
+	ids := make([]uuid.UUID, 0, len(contributions))
+	for _, contribution := range contributions {
+		if !seen[contribution.MemberID] {
+			seen[contribution.MemberID] = true
+			ids = append(ids, contribution.MemberID)
+		}
+	}
+
+	users, err := s.userRepository.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	usersByID := make(map[uuid.UUID]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	for _, contribution := range contributions {
+		contribution.Member = usersByID[contribution.MemberID]
+	}
+	return nil
+}
+
+func (s *contributionSvc) AddContribution(ctx context.Context, orgExternalID string, wishID, memberID uuid.UUID, input ContributionInput) (*domain.Contribution, error) {
+	if err := input.Amount.Validate(); err != nil {
+		return nil, err
+	}
+	note := strings.TrimSpace(input.Note)
+	if input.Amount.Amount == 0 || utf8.RuneCountInString(note) > MaxContributionNoteLen {
+		return nil, domain.ErrInvalidContribution
+	}
+
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+
+	contributedAt := time.Now()
+	if input.ContributedAt != nil {
+		contributedAt = *input.ContributedAt
+	}
+
+	created, err := s.contributionRepository.Create(ctx, &domain.Contribution{
+		OrganizationID: wish.OrganizationID,
+		WishID:         wish.ID,
+		MemberID:       memberID,
+		Amount:         input.Amount,
+		Note:           note,
+		ContributedAt:  contributedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachMembers(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *contributionSvc) ListContributions(ctx context.Context, orgExternalID string, wishID uuid.UUID) ([]*domain.Contribution, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	contributions, err := s.contributionRepository.FindByWishID(ctx, wish.OrganizationID, wish.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachMembers(ctx, contributions...); err != nil {
+		return nil, err
+	}
+	return contributions, nil
+}
+
+func (s *contributionSvc) DeleteContribution(ctx context.Context, orgExternalID string, wishID, contributionID, actorID uuid.UUID, actorRole string) error {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return err
+	}
+	contribution, err := s.contributionRepository.FindByID(ctx, wish.OrganizationID, contributionID)
+	if err != nil {
+		return err
+	}
+	if contribution == nil || contribution.WishID != wish.ID {
+		return domain.ErrContributionNotFound
+	}
+
+	if contribution.MemberID != actorID && roleRank[NormalizeRole(actorRole)] < roleRank[models.RoleAdmin] {
+		return domain.ErrPermissionDenied
+	}
+
+	return s.contributionRepository.Delete(ctx, wish.OrganizationID, contribution.ID)
+}
+
+func (s *contributionSvc) GetWishProgress(ctx context.Context, orgExternalID string, wishID uuid.UUID) (*domain.WishProgress, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := s.progress(ctx, wish.OrganizationID, []*domain.Wish{wish})
+	if err != nil {
+		return nil, err
+	}
+	return progress[0], nil
+}
+
+func (s *contributionSvc) GetOrganizationProgress(ctx context.Context, orgExternalID string) (*domain.OrganizationProgress, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	wishes, err := s.wishRepository.FindBudgeted(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	wishProgress, err := s.progress(ctx, org.ID, wishes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 組織全体は通貨ごとに合算する（為替換算はしない）
+	var all []domain.CurrencyProgress
+	for _, p := range wishProgress {
+		all = append(all, p.Totals...)
+	}
+	return &domain.OrganizationProgress{
+		Totals: mergeCurrencyProgress(all),
+		Wishes: wishProgress,
+	}, nil
+}
+
+// progress - 各Wishの価格と積立額を通貨ごとにまとめる
+func (s *contributionSvc) progress(ctx context.Context, organizationID uuid.UUID, wishes []*domain.Wish) ([]*domain.WishProgress, error) {
+	ids := make([]uuid.UUID, len(wishes))
+	for i, wish := range wishes {
+		ids[i] = wish.ID
+	}
+	totals, err := s.contributionRepository.SumByWishIDs(ctx, organizationID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byWish := make(map[uuid.UUID][]domain.CurrencyProgress, len(wishes))
+	for _, total := range totals {
+		byWish[total.WishID] = append(byWish[total.WishID], domain.CurrencyProgress{
+			Currency:    total.Currency,
+			Contributed: total.Amount,
+		})
+	}
+
+	result := make([]*domain.WishProgress, len(wishes))
+	for i, wish := range wishes {
+		entries := byWish[wish.ID]
+		if wish.Price != nil {
+			entries = append(entries, domain.CurrencyProgress{Currency: wish.Price.Currency, Target: wish.Price.Amount})
+		}
+		result[i] = &domain.WishProgress{Wish: wish, Totals: mergeCurrencyProgress(entries)}
+	}
+	return result, nil
+}
+
+// mergeCurrencyProgress - 同じ通貨の目標額と積立額を合算し、通貨コード順に並べる
+func mergeCurrencyProgress(entries []domain.CurrencyProgress) []domain.CurrencyProgress {
+	byCurrency := make(map[string]*domain.CurrencyProgress)
+	for _, entry := range entries {
+		p, ok := byCurrency[entry.Currency]
+		if !ok {
+			p = &domain.CurrencyProgress{Currency: entry.Currency}
+			byCurrency[entry.Currency] = p
+		}
+		p.Target += entry.Target
+		p.Contributed += entry.Contributed
+	}
+
+	merged := make([]domain.CurrencyProgress, 0, len(byCurrency))
+	for _, p := range byCurrency {
+		merged = append(merged, *p)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Currency < merged[j].Currency })
+	return merged
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"taine-api/domain"
+	"time"
+)
+
+const (
+	// unfurlBatchSize - 1回に取得待ちから拾う件数
+	unfurlBatchSize = 20
+	// unfurlTimeout - 1件あたりの取得の制限時間（fetcher自身のタイムアウトとは別の安全弁）
+	unfurlTimeout = 10 * time.Second
+)
+
+// LinkUnfurler - 取得待ちのリンクのプレビューを取得してキャッシュするバックグラウンド処理
+// リンク追加時は Notify で即座に起こし、取りこぼしは interval ごとの巡回で拾う
+type LinkUnfurler struct {
+	linkRepository domain.LinkRepository
+	fetcher        domain.LinkPreviewFetcher
+	wake           chan struct{}
+}
+
+func NewLinkUnfurler(linkRepository domain.LinkRepository, fetcher domain.LinkPreviewFetcher) *LinkUnfurler {
+	return &LinkUnfurler{
+		linkRepository: linkRepository,
+		fetcher:        fetcher,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// Notify - 取得待ちのリンクが増えたことを知らせる。ブロックしない
+func (u *LinkUnfurler) Notify() {
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run - Notify されたとき、または intervalごとに UnfurlPending を実行する。ctxがキャンセルされると終了する
+func (u *LinkUnfurler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := u.UnfurlPending(ctx); err != nil {
+			log.Println("link unfurl failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-u.wake:
+		}
+	}
+}
+
+// UnfurlPending - 取得待ちのリンクが無くなるまで取得する。1件の取得の失敗で他のリンクを止めない
+// 結果の保存に失敗した場合はリンクがpendingのまま残り、続けるとすぐに同じリンクを再取得してしまうため、
+// そこで打ち切ってエラーを返す（次の巡回で再試行する）
+func (u *LinkUnfurler) UnfurlPending(ctx context.Context) error {
+	for {
+		links, err := u.linkRepository.FindPending(ctx, unfurlBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			return nil
+		}
+
+		for _, link := range links {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := u.unfurl(ctx, link); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// unfurl - 1件のプレビューを取得して保存する。取得の失敗は結果として保存し、保存の失敗だけを返す
+func (u *LinkUnfurler) unfurl(ctx context.Context, link *domain.WishLink) error {
+	fetchCtx, cancel := context.WithTimeout(ctx, unfurlTimeout)
+	defer cancel()
+
+	preview, fetchErr := u.fetcher.Fetch(fetchCtx, link.URL)
+	if fetchErr != nil {
+		preview = nil
+		log.Printf("link unfurl: link=%s url=%q err=%v", link.ID, link.URL, fetchErr)
+	}
+
+	// 取得の制限時間を使い切っても保存できるよう、保存は呼び出し元のctxで行う
+	if err := u.linkRepository.SaveResult(ctx, link.ID, preview, fetchErr); err != nil {
+		log.Printf("link unfurl: failed to save result: link=%s err=%v", link.ID, err)
+		return err
+	}
+	return nil
+}
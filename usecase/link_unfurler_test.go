@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+// fakeLinkRepository - 保存されるまでリンクをpendingとして返し続ける LinkRepository
+type fakeLinkRepository struct {
+	domain.LinkRepository
+	pending      []*domain.WishLink
+	saveErr      error
+	findCalls    int
+	savedResults int
+}
+
+func (r *fakeLinkRepository) FindPending(ctx context.Context, limit int) ([]*domain.WishLink, error) {
+	r.findCalls++
+	if r.findCalls > 10 {
+		return nil, errors.New("FindPending called repeatedly")
+	}
+	return r.pending, nil
+}
+
+func (r *fakeLinkRepository) SaveResult(ctx context.Context, id uuid.UUID, preview *domain.LinkPreview, fetchErr error) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.savedResults++
+	for i, link := range r.pending {
+		if link.ID == id {
+			r.pending = append(r.pending[:i:i], r.pending[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+type fakeFetcher struct {
+	calls int
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, rawURL string) (*domain.LinkPreview, error) {
+	f.calls++
+	return &domain.LinkPreview{Title: rawURL}, nil
+}
+
+func TestUnfurlPendingSavesEveryLink(t *testing.T) {
+	repo := &fakeLinkRepository{pending: []*domain.WishLink{
+		{ID: uuid.New(), URL: "https://example.com/a"},
+		{ID: uuid.New(), URL: "https://example.com/b"},
+	}}
+	fetcher := &fakeFetcher{}
+
+	if err := NewLinkUnfurler(repo, fetcher).UnfurlPending(context.Background()); err != nil {
+		t.Fatalf("UnfurlPending() error = %v", err)
+	}
+	if repo.savedResults != 2 || fetcher.calls != 2 {
+		t.Errorf("saved %d and fetched %d links, want 2 and 2", repo.savedResults, fetcher.calls)
+	}
+}
+
+func TestUnfurlPendingStopsWhenSaveFails(t *testing.T) {
+	saveErr := errors.New("connection reset")
+	repo := &fakeLinkRepository{
+		pending: []*domain.WishLink{
+			{ID: uuid.New(), URL: "https://example.com/a"},
+			{ID: uuid.New(), URL: "https://example.com/b"},
+		},
+		saveErr: saveErr,
+	}
+	fetcher := &fakeFetcher{}
+
+	err := NewLinkUnfurler(repo, fetcher).UnfurlPending(context.Background())
+	if !errors.Is(err, saveErr) {
+		t.Fatalf("UnfurlPending() error = %v, want %v", err, saveErr)
+	}
+	// 保存できなかったリンクを拾い直して再取得し続けない
+	if repo.findCalls != 1 || fetcher.calls != 1 {
+		t.Errorf("FindPending called %d times and fetched %d links, want 1 and 1", repo.findCalls, fetcher.calls)
+	}
+}
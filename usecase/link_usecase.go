@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+// LinkSvc - WishへのURLリンク。プレビューは LinkUnfurler がバックグラウンドで取得する
+type LinkSvc interface {
+	AddLink(ctx context.Context, orgExternalID string, wishID uuid.UUID, rawURL string) (*domain.WishLink, error)
+	// RefreshLink - プレビューを取得し直す
+	RefreshLink(ctx context.Context, orgExternalID string, wishID, linkID uuid.UUID) (*domain.WishLink, error)
+	DeleteLink(ctx context.Context, orgExternalID string, wishID, linkID uuid.UUID) error
+}
+
+const (
+	MaxLinksPerWish = 10
+	MaxLinkURLLen   = 2048
+)
+
+type linkSvc struct {
+	linkRepository domain.LinkRepository
+	wishRepository domain.WishRepository
+	orgRepository  domain.OrganizationRepository
+	unfurler       *LinkUnfurler
+}
+
+func NewLinkSvc(
+	linkRepository domain.LinkRepository,
+	wishRepository domain.WishRepository,
+	orgRepository domain.OrganizationRepository,
+	unfurler *LinkUnfurler,
+) LinkSvc {
+	return &linkSvc{
+		linkRepository: linkRepository,
+		wishRepository: wishRepository,
+		orgRepository:  orgRepository,
+		unfurler:       unfurler,
+	}
+}
+
+// findLink - Wishに属するリンクを取得
+func (s *linkSvc) findLink(ctx context.Context, wish *domain.Wish, linkID uuid.UUID) (*domain.WishLink, error) {
+	link, err := s.linkRepository.FindByID(ctx, wish.OrganizationID, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || link.WishID != wish.ID {
+		return nil, domain.ErrLinkNotFound
+	}
+	return link, nil
+}
+
+// normalizeLinkURL - http(s)の絶対URLのみ受け付ける。接続先の検査は取得時に行う
+func normalizeLinkURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > MaxLinkURLLen {
+		return "", domain.ErrInvalidLink
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return "", domain.ErrInvalidLink
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+func (s *linkSvc) AddLink(ctx context.Context, orgExternalID string, wishID uuid.UUID, rawURL string) (*domain.WishLink, error) {
+	linkURL, err := normalizeLinkURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.linkRepository.CountByWishID(ctx, wish.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxLinksPerWish {
+		return nil, domain.ErrTooManyLinks
+	}
+
+	created, err := s.linkRepository.Create(ctx, &domain.WishLink{
+		OrganizationID: wish.OrganizationID,
+		WishID:         wish.ID,
+		URL:            linkURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.unfurler.Notify()
+	return created, nil
+}
+
+func (s *linkSvc) RefreshLink(ctx context.Context, orgExternalID string, wishID, linkID uuid.UUID) (*domain.WishLink, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.findLink(ctx, wish, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.linkRepository.MarkPending(ctx, wish.OrganizationID, link.ID); err != nil {
+		return nil, err
+	}
+	s.unfurler.Notify()
+
+	link.Status = domain.LinkStatusPending
+	link.FetchError = ""
+	return link, nil
+}
+
+func (s *linkSvc) DeleteLink(ctx context.Context, orgExternalID string, wishID, linkID uuid.UUID) error {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return err
+	}
+	link, err := s.findLink(ctx, wish, linkID)
+	if err != nil {
+		return err
+	}
+	return s.linkRepository.Delete(ctx, wish.OrganizationID, link.ID)
+}
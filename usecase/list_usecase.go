@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"taine-api/domain"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxListNameLen = 50
+	MaxListIconLen = 32
+)
+
+// ListSvc - Wishのリスト（ボード）の管理。リスト間のWishの移動は WishSvc.ReorderWishes で行う
+type ListSvc interface {
+	// ListLists - 組織のリストを並び順に取得。既定のリストが無ければ作る
+	ListLists(ctx context.Context, orgExternalID string, includeArchived bool) ([]*domain.List, error)
+	GetList(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.List, error)
+	// CreateList - 末尾に新しいリストを作る
+	CreateList(ctx context.Context, orgExternalID, name, icon string) (*domain.List, error)
+	UpdateList(ctx context.Context, orgExternalID string, id uuid.UUID, name, icon string) (*domain.List, error)
+	// ReorderLists - 組織の全てのリスト（アーカイブ済みを含む）を order の順に並べる
+	ReorderLists(ctx context.Context, orgExternalID string, order []uuid.UUID) ([]*domain.List, error)
+	// SetListArchived - アーカイブ・解除。アーカイブしたリストにはWishを作成・移動できない
+	SetListArchived(ctx context.Context, orgExternalID string, id uuid.UUID, archived bool) (*domain.List, error)
+	// DeleteList - Wishが残っていないリストを削除する。ゴミ箱のWishは既定のリストへ移す
+	DeleteList(ctx context.Context, orgExternalID string, id uuid.UUID) error
+}
+
+type listSvc struct {
+	listRepository domain.ListRepository
+	orgRepository  domain.OrganizationRepository
+}
+
+func NewListSvc(listRepository domain.ListRepository, orgRepository domain.OrganizationRepository) ListSvc {
+	return &listSvc{
+		listRepository: listRepository,
+		orgRepository:  orgRepository,
+	}
+}
+
+// validateList - 名前とアイコンを検証し、正規化した値を返す
+func validateList(name, icon string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	icon = strings.TrimSpace(icon)
+	if name == "" || utf8.RuneCountInString(name) > MaxListNameLen || utf8.RuneCountInString(icon) > MaxListIconLen {
+		return "", "", domain.ErrInvalidList
+	}
+	return name, icon, nil
+}
+
+// findList - 組織のリストを取得
+func findList(ctx context.Context, repo domain.ListRepository, organizationID, id uuid.UUID) (*domain.List, error) {
+	list, err := repo.FindByID(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, domain.ErrListNotFound
+	}
+	return list, nil
+}
+
+// targetList - Wishを作成・移動する先のリスト。listIDがnilなら既定のリスト
+func targetList(ctx context.Context, repo domain.ListRepository, organizationID uuid.UUID, listID *uuid.UUID) (*domain.List, error) {
+	if listID == nil {
+		return repo.EnsureDefault(ctx, organizationID)
+	}
+	list, err := findList(ctx, repo, organizationID, *listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.Archived() {
+		return nil, domain.ErrListArchived
+	}
+	return list, nil
+}
+
+func (s *listSvc) ListLists(ctx context.Context, orgExternalID string, includeArchived bool) ([]*domain.List, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.listRepository.EnsureDefault(ctx, org.ID); err != nil {
+		return nil, err
+	}
+	return s.listRepository.FindByOrganizationID(ctx, org.ID, includeArchived)
+}
+
+func (s *listSvc) GetList(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.List, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return findList(ctx, s.listRepository, org.ID, id)
+}
+
+func (s *listSvc) CreateList(ctx context.Context, orgExternalID, name, icon string) (*domain.List, error) {
+	name, icon, err := validateList(name, icon)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	// 既定のリストを先に作り、新しいリストがその後ろに並ぶようにする
+	if _, err := s.listRepository.EnsureDefault(ctx, org.ID); err != nil {
+		return nil, err
+	}
+	lists, err := s.listRepository.FindByOrganizationID(ctx, org.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	var last string
+	if len(lists) > 0 {
+		last = lists[len(lists)-1].Rank
+	}
+	rank, err := domain.RankBetween(last, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listRepository.Create(ctx, &domain.List{
+		OrganizationID: org.ID,
+		Name:           name,
+		Icon:           icon,
+		Rank:           rank,
+	})
+}
+
+func (s *listSvc) UpdateList(ctx context.Context, orgExternalID string, id uuid.UUID, name, icon string) (*domain.List, error) {
+	name, icon, err := validateList(name, icon)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := findList(ctx, s.listRepository, org.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Name = name
+	list.Icon = icon
+	return s.listRepository.Update(ctx, list)
+}
+
+func (s *listSvc) ReorderLists(ctx context.Context, orgExternalID string, order []uuid.UUID) ([]*domain.List, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := s.listRepository.FindByOrganizationID(ctx, org.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// 一部だけ並べ替えると残りのリストとの前後が決まらないため、全てのリストをちょうど1回ずつ指定させる
+	if len(order) != len(lists) {
+		return nil, domain.ErrInvalidReorder
+	}
+	known := make(map[uuid.UUID]bool, len(lists))
+	for _, list := range lists {
+		known[list.ID] = true
+	}
+	for _, id := range order {
+		if !known[id] {
+			return nil, domain.ErrInvalidReorder
+		}
+		delete(known, id)
+	}
+
+	if err := s.listRepository.UpdateRanks(ctx, org.ID, order, domain.RankSequence(len(order))); err != nil {
+		return nil, err
+	}
+	return s.listRepository.FindByOrganizationID(ctx, org.ID, true)
+}
+
+func (s *listSvc) SetListArchived(ctx context.Context, orgExternalID string, id uuid.UUID, archived bool) (*domain.List, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := findList(ctx, s.listRepository, org.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.IsDefault && archived {
+		return nil, domain.ErrDefaultList
+	}
+	if list.Archived() == archived {
+		return list, nil
+	}
+
+	if archived {
+		now := time.Now()
+		list.ArchivedAt = &now
+	} else {
+		list.ArchivedAt = nil
+	}
+	return s.listRepository.Update(ctx, list)
+}
+
+func (s *listSvc) DeleteList(ctx context.Context, orgExternalID string, id uuid.UUID) error {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return err
+	}
+	list, err := findList(ctx, s.listRepository, org.ID, id)
+	if err != nil {
+		return err
+	}
+	if list.IsDefault {
+		return domain.ErrDefaultList
+	}
+	defaultList, err := s.listRepository.EnsureDefault(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+	return s.listRepository.Delete(ctx, org.ID, list.ID, defaultList.ID)
+}
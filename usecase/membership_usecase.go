@@ -35,13 +35,10 @@ func (s *membershipSvc) UpsertByExternalIDs(ctx context.Context, userSubID, orgE
 	}
 
 	// FindOrgByExternalID
-	org, err := s.orgRepository.FindByExternalID(ctx, orgExternalID)
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
 	if err != nil {
 		return err
 	}
-	if org == nil {
-		return domain.ErrOrganizationNotFound
-	}
 
 	// Upsert membership
 	_, err = s.membershipRepository.UpsertByUserAndOrg(ctx, user.ID, org.ID, role)
@@ -59,13 +56,10 @@ func (s *membershipSvc) SoftDeleteByExternalIDs(ctx context.Context, userSubID,
 	}
 
 	// FindOrgByExternalID
-	org, err := s.orgRepository.FindByExternalID(ctx, orgExternalID)
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
 	if err != nil {
 		return err
 	}
-	if org == nil {
-		return domain.ErrOrganizationNotFound
-	}
 
 	// Soft delete membership
 	return s.membershipRepository.SoftDeleteByUserAndOrg(ctx, user.ID, org.ID)
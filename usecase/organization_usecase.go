@@ -4,14 +4,29 @@ import (
 	"context"
 	"taine-api/domain"
 	"taine-api/models"
+	"time"
 )
 
 type OrganizationSvc interface {
 	UpsertByExternalID(ctx context.Context, externalID, name string) error
 	UpsertByExternalIDWithCreator(ctx context.Context, externalID, name, creatorSubID string) error
 	SoftDeleteByExternalID(ctx context.Context, externalID string) error
+	GetSettings(ctx context.Context, externalID string) (*domain.Organization, error)
+	UpdateTrashRetentionDays(ctx context.Context, externalID string, days int) (*domain.Organization, error)
+	// UpdateSettings - 指定された項目を全て検証してから更新する
+	UpdateSettings(ctx context.Context, externalID string, input OrganizationSettingsInput) (*domain.Organization, error)
 }
 
+// OrganizationSettingsInput - 組織設定の更新項目。nilの項目は変更しない
+type OrganizationSettingsInput struct {
+	TrashRetentionDays *int
+	// Timezone - IANAのタイムゾーン名（例: Asia/Tokyo）
+	Timezone *string
+}
+
+// MaxTrashRetentionDays - ゴミ箱の保持期間の上限（約10年）
+const MaxTrashRetentionDays = 3650
+
 type organizationSvc struct {
 	orgRepository        domain.OrganizationRepository
 	userRepository       domain.UserRepository
@@ -63,3 +78,58 @@ func (s *organizationSvc) UpsertByExternalIDWithCreator(ctx context.Context, ext
 func (s *organizationSvc) SoftDeleteByExternalID(ctx context.Context, externalID string) error {
 	return s.orgRepository.SoftDeleteByExternalID(ctx, externalID)
 }
+
+func (s *organizationSvc) GetSettings(ctx context.Context, externalID string) (*domain.Organization, error) {
+	return findOrganization(ctx, s.orgRepository, externalID)
+}
+
+func (s *organizationSvc) UpdateTrashRetentionDays(ctx context.Context, externalID string, days int) (*domain.Organization, error) {
+	// 0は無期限保持
+	if days < 0 || days > MaxTrashRetentionDays {
+		return nil, domain.ErrInvalidOrganizationSettings
+	}
+
+	org, err := s.GetSettings(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepository.UpdateTrashRetentionDays(ctx, org.ID, days)
+}
+
+// validateTimezone - "Local" はサーバーの環境に依存するので受け付けない
+func validateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return domain.ErrInvalidOrganizationSettings
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return domain.ErrInvalidOrganizationSettings
+	}
+	return nil
+}
+
+func (s *organizationSvc) UpdateSettings(ctx context.Context, externalID string, input OrganizationSettingsInput) (*domain.Organization, error) {
+	if input.TrashRetentionDays != nil && (*input.TrashRetentionDays < 0 || *input.TrashRetentionDays > MaxTrashRetentionDays) {
+		return nil, domain.ErrInvalidOrganizationSettings
+	}
+	if input.Timezone != nil {
+		if err := validateTimezone(*input.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	org, err := s.GetSettings(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if input.TrashRetentionDays != nil {
+		if org, err = s.orgRepository.UpdateTrashRetentionDays(ctx, org.ID, *input.TrashRetentionDays); err != nil {
+			return nil, err
+		}
+	}
+	if input.Timezone != nil {
+		if org, err = s.orgRepository.UpdateTimezone(ctx, org.ID, *input.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	return org, nil
+}
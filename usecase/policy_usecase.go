@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"taine-api/domain"
+	"taine-api/models"
+)
+
+// roleRank - ロールの強さ。大きいほど権限が強い
+var roleRank = map[string]int{
+	models.RoleMember: 1,
+	models.RoleAdmin:  2,
+	models.RoleOwner:  3,
+}
+
+// rolePolicy - (resource, action) ごとに必要な最小ロール
+var rolePolicy = map[domain.Resource]map[domain.Action]string{
+	domain.ResourceWish: {
+		domain.ActionRead:       models.RoleMember,
+		domain.ActionCreate:     models.RoleMember,
+		domain.ActionUpdate:     models.RoleMember,
+		domain.ActionSoftDelete: models.RoleMember,
+		domain.ActionRestore:    models.RoleAdmin,
+		domain.ActionDelete:     models.RoleAdmin,
+		domain.ActionVote:       models.RoleMember,
+	},
+	domain.ResourceTag: {
+		domain.ActionRead:   models.RoleMember,
+		domain.ActionCreate: models.RoleMember,
+		domain.ActionUpdate: models.RoleMember,
+		domain.ActionDelete: models.RoleAdmin, // 削除・統合は全Wishに影響するためadmin以上
+	},
+	domain.ResourceList: {
+		domain.ActionRead:   models.RoleMember,
+		domain.ActionCreate: models.RoleMember,
+		domain.ActionUpdate: models.RoleMember,
+		domain.ActionDelete: models.RoleAdmin,
+	},
+	// 共有リンクは組織外にWishを公開するため、作成・取り消しはadmin以上
+	domain.ResourceShare: {
+		domain.ActionRead:   models.RoleMember,
+		domain.ActionCreate: models.RoleAdmin,
+		domain.ActionDelete: models.RoleAdmin,
+	},
+	// 他人のコメントの編集・削除の可否は CommentSvc が投稿者とロールで判定する
+	domain.ResourceComment: {
+		domain.ActionRead:   models.RoleMember,
+		domain.ActionCreate: models.RoleMember,
+		domain.ActionUpdate: models.RoleMember,
+		domain.ActionDelete: models.RoleMember,
+	},
+	domain.ResourceSettings: {
+		domain.ActionRead:   models.RoleMember,
+		domain.ActionManage: models.RoleOwner,
+	},
+}
+
+// NormalizeRole - Clerkのロール表記（"org:admin"）をアプリのロール（"admin"）に揃える
+func NormalizeRole(role string) string {
+	return strings.TrimPrefix(strings.TrimSpace(role), "org:")
+}
+
+type PolicySvc interface {
+	// ResolveRole - JWTのorg_roleクレームを優先し、無い場合はorganization_members.roleを使う
+	ResolveRole(ctx context.Context, subID, orgExternalID, claimRole string) (string, error)
+	Authorize(ctx context.Context, subID, orgExternalID, claimRole string, resource domain.Resource, action domain.Action) (*domain.PermissionDecision, error)
+}
+
+type policySvc struct {
+	membershipRepository domain.MembershipRepository
+	userRepository       domain.UserRepository
+	orgRepository        domain.OrganizationRepository
+}
+
+func NewPolicySvc(
+	membershipRepository domain.MembershipRepository,
+	userRepository domain.UserRepository,
+	orgRepository domain.OrganizationRepository,
+) PolicySvc {
+	return &policySvc{
+		membershipRepository: membershipRepository,
+		userRepository:       userRepository,
+		orgRepository:        orgRepository,
+	}
+}
+
+func (s *policySvc) ResolveRole(ctx context.Context, subID, orgExternalID, claimRole string) (string, error) {
+	if role := NormalizeRole(claimRole); roleRank[role] > 0 {
+		return role, nil
+	}
+
+	// クレームが無い・未知のロールの場合はDBのメンバーシップを参照
+	user, err := s.userRepository.GetUserBySubID(ctx, subID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", nil
+	}
+	org, err := s.orgRepository.FindByExternalID(ctx, orgExternalID)
+	if err != nil {
+		return "", err
+	}
+	if org == nil {
+		return "", nil
+	}
+	member, err := s.membershipRepository.FindByUserAndOrg(ctx, user.ID, org.ID)
+	if err != nil {
+		return "", err
+	}
+	if member == nil {
+		return "", nil
+	}
+	return NormalizeRole(member.Role), nil
+}
+
+func (s *policySvc) Authorize(ctx context.Context, subID, orgExternalID, claimRole string, resource domain.Resource, action domain.Action) (*domain.PermissionDecision, error) {
+	role, err := s.ResolveRole(ctx, subID, orgExternalID, claimRole)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(role, resource, action), nil
+}
+
+// Evaluate - ロールが (resource, action) を実行できるかを判定する
+func Evaluate(role string, resource domain.Resource, action domain.Action) *domain.PermissionDecision {
+	decision := &domain.PermissionDecision{
+		Resource: resource,
+		Action:   action,
+		Role:     role,
+	}
+
+	required, ok := rolePolicy[resource][action]
+	switch {
+	case !ok:
+		decision.Code = "action_not_permitted"
+	case roleRank[role] == 0:
+		decision.Code = "not_a_member"
+		decision.RequiredRole = required
+	case roleRank[role] < roleRank[required]:
+		decision.Code = "insufficient_role"
+		decision.RequiredRole = required
+	default:
+		decision.Allowed = true
+	}
+	return decision
+}
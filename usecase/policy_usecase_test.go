@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"taine-api/domain"
+	"taine-api/models"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeRole(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"org:admin", models.RoleAdmin},
+		{"admin", models.RoleAdmin},
+		{" org:owner ", models.RoleOwner},
+		{"org:member", models.RoleMember},
+		{"", ""},
+		// 未知のロールはそのまま返し、Evaluate でメンバー外として扱う
+		{"org:guest", "guest"},
+		{"org:org:admin", "org:admin"},
+		{"ADMIN", "ADMIN"},
+	} {
+		if got := NormalizeRole(tt.in); got != tt.want {
+			t.Errorf("NormalizeRole(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	const (
+		member = models.RoleMember
+		admin  = models.RoleAdmin
+		owner  = models.RoleOwner
+		none   = "" // ポリシーに無い操作
+	)
+	// (resource, action) ごとに必要な最小ロール
+	policy := []struct {
+		resource domain.Resource
+		action   domain.Action
+		required string
+	}{
+		{domain.ResourceWish, domain.ActionRead, member},
+		{domain.ResourceWish, domain.ActionCreate, member},
+		{domain.ResourceWish, domain.ActionUpdate, member},
+		{domain.ResourceWish, domain.ActionSoftDelete, member},
+		{domain.ResourceWish, domain.ActionVote, member},
+		{domain.ResourceWish, domain.ActionRestore, admin},
+		{domain.ResourceWish, domain.ActionDelete, admin},
+		{domain.ResourceWish, domain.ActionManage, none},
+		{domain.ResourceTag, domain.ActionRead, member},
+		{domain.ResourceTag, domain.ActionCreate, member},
+		{domain.ResourceTag, domain.ActionUpdate, member},
+		{domain.ResourceTag, domain.ActionDelete, admin},
+		{domain.ResourceTag, domain.ActionSoftDelete, none},
+		{domain.ResourceList, domain.ActionRead, member},
+		{domain.ResourceList, domain.ActionCreate, member},
+		{domain.ResourceList, domain.ActionUpdate, member},
+		{domain.ResourceList, domain.ActionDelete, admin},
+		{domain.ResourceList, domain.ActionVote, none},
+		{domain.ResourceShare, domain.ActionRead, member},
+		{domain.ResourceShare, domain.ActionCreate, admin},
+		{domain.ResourceShare, domain.ActionDelete, admin},
+		{domain.ResourceShare, domain.ActionUpdate, none},
+		{domain.ResourceComment, domain.ActionRead, member},
+		{domain.ResourceComment, domain.ActionCreate, member},
+		{domain.ResourceComment, domain.ActionUpdate, member},
+		{domain.ResourceComment, domain.ActionDelete, member},
+		{domain.ResourceSettings, domain.ActionRead, member},
+		{domain.ResourceSettings, domain.ActionManage, owner},
+		{domain.ResourceSettings, domain.ActionUpdate, none},
+		{domain.Resource("billing"), domain.ActionRead, none},
+	}
+	// 既知のロールの強さ。0はメンバーとして扱わないロール
+	roles := []struct {
+		role string
+		rank int
+	}{
+		{member, 1},
+		{admin, 2},
+		{owner, 3},
+		{"", 0},
+		{"guest", 0},
+		{"org:admin", 0}, // 正規化されていないロール
+	}
+	rank := map[string]int{member: 1, admin: 2, owner: 3}
+
+	// rolePolicy に足した操作がこの表から漏れないようにする
+	listed := 0
+	for _, p := range policy {
+		if p.required != none {
+			listed++
+		}
+	}
+	total := 0
+	for _, actions := range rolePolicy {
+		total += len(actions)
+	}
+	if listed != total {
+		t.Errorf("the table lists %d permitted actions, rolePolicy has %d", listed, total)
+	}
+
+	for _, p := range policy {
+		for _, r := range roles {
+			got := Evaluate(r.role, p.resource, p.action)
+
+			var wantCode, wantRequired string
+			switch {
+			case p.required == none:
+				wantCode = "action_not_permitted"
+			case r.rank == 0:
+				wantCode, wantRequired = "not_a_member", p.required
+			case r.rank < rank[p.required]:
+				wantCode, wantRequired = "insufficient_role", p.required
+			}
+			want := domain.PermissionDecision{
+				Allowed:      wantCode == "",
+				Code:         wantCode,
+				Resource:     p.resource,
+				Action:       p.action,
+				Role:         r.role,
+				RequiredRole: wantRequired,
+			}
+			if *got != want {
+				t.Errorf("Evaluate(%q, %s, %s) = %+v, want %+v", r.role, p.resource, p.action, *got, want)
+			}
+		}
+	}
+}
+
+type fakeUserRepository struct {
+	domain.UserRepository
+	users map[string]*domain.User
+}
+
+func (r *fakeUserRepository) GetUserBySubID(ctx context.Context, subID string) (*domain.User, error) {
+	return r.users[subID], nil
+}
+
+func TestPolicySvcResolveRole(t *testing.T) {
+	org := &domain.Organization{ID: uuid.New(), ExternalID: "org_a"}
+	user := &domain.User{ID: uuid.New(), SubID: "user_1"}
+	svc := NewPolicySvc(
+		&fakeMembershipRepository{member: &domain.OrganizationMember{UserID: user.ID, OrganizationID: org.ID, Role: "org:admin"}},
+		&fakeUserRepository{users: map[string]*domain.User{user.SubID: user}},
+		&fakeOrganizationRepository{orgs: map[string]*domain.Organization{org.ExternalID: org}},
+	)
+
+	for _, tt := range []struct {
+		name       string
+		subID, org string
+		claimRole  string
+		want       string
+	}{
+		{"claim wins over the membership", "user_1", "org_a", "org:owner", models.RoleOwner},
+		{"unknown claim falls back to the membership", "user_1", "org_a", "org:guest", models.RoleAdmin},
+		{"missing claim falls back to the membership", "user_1", "org_a", "", models.RoleAdmin},
+		{"unknown user", "user_2", "org_a", "", ""},
+		{"unknown organization", "user_1", "org_b", "", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ResolveRole(context.Background(), tt.subID, tt.org, tt.claimRole)
+			if err != nil {
+				t.Fatalf("ResolveRole() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"taine-api/domain"
+	"time"
+)
+
+const (
+	// reminderBatchSize - 1回に拾う期限到来リマインダーの件数
+	reminderBatchSize = 100
+	// notifyTimeout - 1件あたりの通知の制限時間
+	notifyTimeout = 10 * time.Second
+)
+
+// ReminderScheduler - 期限が到来したリマインダーを拾って Notifier で届けるバックグラウンド処理
+// 送信前に MarkSent で確保するため、複数プロセスで動かしても二重には届かない
+type ReminderScheduler struct {
+	reminderRepository domain.ReminderRepository
+	wishRepository     domain.WishRepository
+	notifier           domain.Notifier
+}
+
+func NewReminderScheduler(
+	reminderRepository domain.ReminderRepository,
+	wishRepository domain.WishRepository,
+	notifier domain.Notifier,
+) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminderRepository: reminderRepository,
+		wishRepository:     wishRepository,
+		notifier:           notifier,
+	}
+}
+
+// Run - intervalごとに SendDue を実行する。ctxがキャンセルされると終了する
+func (s *ReminderScheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx, time.Now()); err != nil {
+			log.Println("reminder delivery failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue - 期限が到来したリマインダーを全て届ける。1件の失敗で他のリマインダーを止めない
+// 失敗したものは確保を戻して次回に再送する
+func (s *ReminderScheduler) SendDue(ctx context.Context, now time.Time) error {
+	for {
+		reminders, err := s.reminderRepository.FindDue(ctx, now, reminderBatchSize)
+		if err != nil {
+			return err
+		}
+
+		failed := false
+		for _, reminder := range reminders {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !s.send(ctx, reminder) {
+				failed = true
+			}
+		}
+
+		// 失敗分は同じ周回で再び拾われるため、次のtickまで待つ
+		if failed || len(reminders) < reminderBatchSize {
+			return nil
+		}
+	}
+}
+
+// send - 1件のリマインダーを確保して届ける。再送が必要な失敗のときだけfalseを返す
+func (s *ReminderScheduler) send(ctx context.Context, reminder *domain.WishReminder) bool {
+	wish, err := s.wishRepository.FindByID(ctx, reminder.OrganizationID, reminder.WishID)
+	if err != nil {
+		log.Printf("reminder: failed to load wish: reminder=%s err=%v", reminder.ID, err)
+		return false
+	}
+	// 拾った後に削除・期日の変更があった場合は送らない。期日の変更は次回の FindDue で判定し直す
+	if wish == nil || wish.DeletedAt != nil || wish.TargetDate == nil ||
+		reminder.DueFor == nil || !wish.TargetDate.Equal(*reminder.DueFor) {
+		return true
+	}
+	targetDate := *wish.TargetDate
+
+	claimed, err := s.reminderRepository.MarkSent(ctx, reminder.ID, targetDate)
+	if err != nil {
+		log.Printf("reminder: failed to claim: reminder=%s err=%v", reminder.ID, err)
+		return false
+	}
+	if !claimed {
+		return true // 他のワーカーが送信済み
+	}
+
+	notifyCtx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	err = s.notifier.NotifyReminder(notifyCtx, &domain.ReminderNotification{
+		UserID:     reminder.UserID,
+		Wish:       wish,
+		Offset:     reminder.Offset,
+		TargetDate: targetDate,
+	})
+	if err == nil {
+		return true
+	}
+
+	log.Printf("reminder: failed to notify: reminder=%s user=%s err=%v", reminder.ID, reminder.UserID, err)
+	if err := s.reminderRepository.UnmarkSent(ctx, reminder.ID, targetDate); err != nil {
+		log.Printf("reminder: failed to release claim: reminder=%s err=%v", reminder.ID, err)
+	}
+	return false
+}
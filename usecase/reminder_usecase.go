@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReminderSvc - メンバー自身のWishのリマインダー。通知は ReminderScheduler が行う
+type ReminderSvc interface {
+	GetReminders(ctx context.Context, orgExternalID string, wishID, userID uuid.UUID) ([]*domain.WishReminder, error)
+	// SetReminders - 呼び出し元のリマインダーを offsets と同じ内容に置き換える。空なら全て外す
+	SetReminders(ctx context.Context, orgExternalID string, wishID, userID uuid.UUID, offsets []domain.ReminderOffset) ([]*domain.WishReminder, error)
+}
+
+// MaxRemindersPerWish - 1人のメンバーが1つのWishに設定できるリマインダーの上限
+const MaxRemindersPerWish = 5
+
+type reminderSvc struct {
+	reminderRepository domain.ReminderRepository
+	wishRepository     domain.WishRepository
+	orgRepository      domain.OrganizationRepository
+}
+
+func NewReminderSvc(
+	reminderRepository domain.ReminderRepository,
+	wishRepository domain.WishRepository,
+	orgRepository domain.OrganizationRepository,
+) ReminderSvc {
+	return &reminderSvc{
+		reminderRepository: reminderRepository,
+		wishRepository:     wishRepository,
+		orgRepository:      orgRepository,
+	}
+}
+
+func (s *reminderSvc) GetReminders(ctx context.Context, orgExternalID string, wishID, userID uuid.UUID) ([]*domain.WishReminder, error) {
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reminderRepository.FindByWishAndUser(ctx, wish.ID, userID)
+}
+
+func (s *reminderSvc) SetReminders(ctx context.Context, orgExternalID string, wishID, userID uuid.UUID, offsets []domain.ReminderOffset) ([]*domain.WishReminder, error) {
+	// 重複を除いて早い順に並べる
+	seen := make(map[domain.ReminderOffset]bool, len(offsets))
+	unique := make([]domain.ReminderOffset, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset < 0 || offset.Days() > domain.MaxReminderOffsetDays {
+			return nil, domain.ErrInvalidReminder
+		}
+		if !seen[offset] {
+			seen[offset] = true
+			unique = append(unique, offset)
+		}
+	}
+	if len(unique) > MaxRemindersPerWish {
+		return nil, domain.ErrTooManyReminders
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] > unique[j] })
+
+	wish, err := findWishInOrg(ctx, s.orgRepository, s.wishRepository, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reminderRepository.ReplaceForUser(ctx, wish.OrganizationID, wish.ID, userID, unique)
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"taine-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// seriesBatchSize - 1回に拾う繰り返しの件数
+const seriesBatchSize = 100
+
+// seriesInstances - 繰り返しの次の回のWishを作る。SeriesSvc（作成直後）と SeriesScheduler で共有する
+type seriesInstances struct {
+	seriesRepository     domain.WishSeriesRepository
+	tagRepository        domain.TagRepository
+	membershipRepository domain.MembershipRepository
+	listRepository       domain.ListRepository
+}
+
+func newSeriesInstances(
+	seriesRepository domain.WishSeriesRepository,
+	tagRepository domain.TagRepository,
+	membershipRepository domain.MembershipRepository,
+	listRepository domain.ListRepository,
+) *seriesInstances {
+	return &seriesInstances{
+		seriesRepository:     seriesRepository,
+		tagRepository:        tagRepository,
+		membershipRepository: membershipRepository,
+		listRepository:       listRepository,
+	}
+}
+
+// createNext - 次の回のWishを作り、繰り返しを進める。他のワーカーが先に進めていた場合はfalse
+// 次の回が既に始まっている場合は、始まっている最後の回を作る（止まっていた間の回はまとめて作らない）。
+// まだ始まっていない場合は前の回が完了したときで、次の回を前倒しで作る
+func (g *seriesInstances) createNext(ctx context.Context, series *domain.WishSeries, today time.Time) (bool, error) {
+	if series.Status != domain.SeriesStatusActive || series.NextDate == nil {
+		return false, nil
+	}
+	pending := *series.NextDate
+	occurrence := pending
+	if !pending.After(today) {
+		if latest, ok := series.Rule.Latest(series.StartDate, today); ok && latest.After(pending) {
+			occurrence = latest
+		}
+	}
+	advance := domain.SeriesAdvance{From: pending, Created: occurrence}
+	if next, ok := series.Rule.Next(series.StartDate, occurrence); ok {
+		advance.Next = &next
+	}
+
+	// 削除されたタグや組織を抜けた担当者はテンプレートにあっても付けない
+	tags, err := g.tagRepository.FindByIDs(ctx, series.OrganizationID, series.TagIDs)
+	if err != nil {
+		return false, err
+	}
+	tagIDs := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+	assigneeID := series.AssigneeID
+	if assigneeID != nil {
+		member, err := g.membershipRepository.FindByUserAndOrg(ctx, *assigneeID, series.OrganizationID)
+		if err != nil {
+			return false, err
+		}
+		if member == nil {
+			assigneeID = nil
+		}
+	}
+	// テンプレートのリストが削除・アーカイブされていれば既定のリストに作る
+	var list *domain.List
+	if series.ListID != nil {
+		if list, err = g.listRepository.FindByID(ctx, series.OrganizationID, *series.ListID); err != nil {
+			return false, err
+		}
+		if list != nil && list.Archived() {
+			list = nil
+		}
+	}
+	if list == nil {
+		if list, err = g.listRepository.EnsureDefault(ctx, series.OrganizationID); err != nil {
+			return false, err
+		}
+	}
+
+	created := false
+	err = g.seriesRepository.Transaction(ctx, func(seriesRepo domain.WishSeriesRepository, wishRepo domain.WishRepository) error {
+		advanced, err := seriesRepo.Advance(ctx, series.ID, advance)
+		if err != nil || !advanced {
+			return err
+		}
+
+		// 新しいWishはリストの先頭に置く（利用者の作成・移動と同じrankにならないようロックしてから読む）
+		rank, err := topRank(ctx, wishRepo, series.OrganizationID, list.ID)
+		if err != nil {
+			return err
+		}
+
+		wish, err := wishRepo.Create(ctx, &domain.Wish{
+			OrganizationID: series.OrganizationID,
+			ListID:         list.ID,
+			Title:          series.Title,
+			Note:           series.Note,
+			Rank:           rank,
+			Status:         domain.WishStatusIdea,
+			Price:          series.Price,
+			TargetDate:     &occurrence,
+			AssigneeID:     assigneeID,
+			SeriesID:       &series.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := wishRepo.ReplaceTags(ctx, wish.ID, tagIDs); err != nil {
+			return err
+		}
+		wish.Tags = tagsOf(tagIDs)
+		created = true
+		return recordRevision(ctx, wishRepo, wish, nil, series.CreatedBy, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// SeriesScheduler - 期間が始まった回、または前の回が完了した繰り返しの次のWishを作るバックグラウンド処理
+// Advance で次の回を確保してから作るため、複数プロセスで動かしても二重には作らない
+type SeriesScheduler struct {
+	seriesRepository domain.WishSeriesRepository
+	instances        *seriesInstances
+}
+
+func NewSeriesScheduler(
+	seriesRepository domain.WishSeriesRepository,
+	tagRepository domain.TagRepository,
+	membershipRepository domain.MembershipRepository,
+	listRepository domain.ListRepository,
+) *SeriesScheduler {
+	return &SeriesScheduler{
+		seriesRepository: seriesRepository,
+		instances:        newSeriesInstances(seriesRepository, tagRepository, membershipRepository, listRepository),
+	}
+}
+
+// Run - intervalごとに CreateDue を実行する。ctxがキャンセルされると終了する
+func (s *SeriesScheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.CreateDue(ctx, time.Now()); err != nil {
+			log.Println("series instance creation failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CreateDue - 次の回を作る時期が来た繰り返しを全て進める。1件の失敗で他の繰り返しを止めない
+func (s *SeriesScheduler) CreateDue(ctx context.Context, now time.Time) error {
+	for {
+		due, err := s.seriesRepository.FindDue(ctx, now, seriesBatchSize)
+		if err != nil {
+			return err
+		}
+
+		failed := false
+		for _, series := range due {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if _, err := s.instances.createNext(ctx, series, *series.Today); err != nil {
+				log.Printf("series: failed to create instance: series=%s err=%v", series.ID, err)
+				failed = true
+			}
+		}
+
+		// 失敗分は同じ周回で再び拾われるため、次のtickまで待つ
+		if failed || len(due) < seriesBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"taine-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeriesSvc - 繰り返しWishのテンプレート。各回のWishは seriesInstances が作る
+type SeriesSvc interface {
+	ListSeries(ctx context.Context, orgExternalID string) ([]*domain.WishSeries, error)
+	GetSeries(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.WishSeries, error)
+	// CreateSeries - テンプレートを作り、最初の回が既に始まっていればそのWishもすぐに作る
+	CreateSeries(ctx context.Context, orgExternalID string, actorID uuid.UUID, input SeriesInput) (*domain.WishSeries, error)
+	// UpdateSeries - これから作るWishの内容を変更する。作成済みのWishは変更しない
+	UpdateSeries(ctx context.Context, orgExternalID string, id uuid.UUID, input SeriesTemplateInput) (*domain.WishSeries, error)
+	// TransitionSeries - 一時停止（paused）・再開（active）・終了（stopped）。作成済みのWishは変更しない
+	TransitionSeries(ctx context.Context, orgExternalID string, id uuid.UUID, to domain.SeriesStatus) (*domain.WishSeries, error)
+}
+
+// SeriesTemplateInput - 各回のWishにコピーする内容
+type SeriesTemplateInput struct {
+	Title      string
+	Note       string
+	Price      *domain.Money
+	TagIDs     []uuid.UUID
+	AssigneeID *uuid.UUID
+	// ListID - 各回を作るリスト。nilは既定のリスト
+	ListID *uuid.UUID
+}
+
+// SeriesInput - 新しい繰り返しの内容。StartDate はルールの起点（DTSTART）
+type SeriesInput struct {
+	SeriesTemplateInput
+	Rule      string
+	StartDate time.Time
+}
+
+type seriesSvc struct {
+	seriesRepository     domain.WishSeriesRepository
+	orgRepository        domain.OrganizationRepository
+	tagRepository        domain.TagRepository
+	membershipRepository domain.MembershipRepository
+	listRepository       domain.ListRepository
+	instances            *seriesInstances
+}
+
+func NewSeriesSvc(
+	seriesRepository domain.WishSeriesRepository,
+	orgRepository domain.OrganizationRepository,
+	tagRepository domain.TagRepository,
+	membershipRepository domain.MembershipRepository,
+	listRepository domain.ListRepository,
+) SeriesSvc {
+	return &seriesSvc{
+		seriesRepository:     seriesRepository,
+		orgRepository:        orgRepository,
+		tagRepository:        tagRepository,
+		membershipRepository: membershipRepository,
+		listRepository:       listRepository,
+		instances:            newSeriesInstances(seriesRepository, tagRepository, membershipRepository, listRepository),
+	}
+}
+
+// findSeries - 呼び出し元の組織に属する繰り返しを取得
+func (s *seriesSvc) findSeries(ctx context.Context, organizationID, id uuid.UUID) (*domain.WishSeries, error) {
+	series, err := s.seriesRepository.FindByID(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, domain.ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+// validateTemplate - WishSvc の作成時と同じ検証をし、タグIDの重複を除いて返す
+func (s *seriesSvc) validateTemplate(ctx context.Context, organizationID uuid.UUID, input SeriesTemplateInput) ([]uuid.UUID, error) {
+	if input.Title == "" {
+		return nil, domain.ErrWishTitleRequired
+	}
+	if err := validatePrice(input.Price); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(input.TagIDs))
+	tagIDs := make([]uuid.UUID, 0, len(input.TagIDs))
+	for _, id := range input.TagIDs {
+		if !seen[id] {
+			seen[id] = true
+			tagIDs = append(tagIDs, id)
+		}
+	}
+	tags, err := s.tagRepository.FindByIDs(ctx, organizationID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != len(tagIDs) {
+		return nil, domain.ErrTagNotFound
+	}
+
+	if input.AssigneeID != nil {
+		member, err := s.membershipRepository.FindByUserAndOrg(ctx, *input.AssigneeID, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, domain.ErrInvalidAssignee
+		}
+	}
+	if input.ListID != nil {
+		if _, err := targetList(ctx, s.listRepository, organizationID, input.ListID); err != nil {
+			return nil, err
+		}
+	}
+	return tagIDs, nil
+}
+
+func (s *seriesSvc) ListSeries(ctx context.Context, orgExternalID string) ([]*domain.WishSeries, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.seriesRepository.FindByOrganizationID(ctx, org.ID)
+}
+
+func (s *seriesSvc) GetSeries(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.WishSeries, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findSeries(ctx, org.ID, id)
+}
+
+func (s *seriesSvc) CreateSeries(ctx context.Context, orgExternalID string, actorID uuid.UUID, input SeriesInput) (*domain.WishSeries, error) {
+	rule, err := domain.ParseRecurrenceRule(input.Rule)
+	if err != nil {
+		return nil, err
+	}
+	startDate := domain.DateOf(input.StartDate)
+	first, ok := rule.First(startDate)
+	if !ok {
+		return nil, domain.ErrInvalidRecurrence // 1回も発生しないルール
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := s.validateTemplate(ctx, org.ID, input.SeriesTemplateInput)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.seriesRepository.Create(ctx, &domain.WishSeries{
+		OrganizationID: org.ID,
+		Title:          input.Title,
+		Note:           input.Note,
+		Price:          input.Price,
+		TagIDs:         tagIDs,
+		AssigneeID:     input.AssigneeID,
+		ListID:         input.ListID,
+		Rule:           rule,
+		StartDate:      startDate,
+		Status:         domain.SeriesStatusActive,
+		NextDate:       &first,
+		CreatedBy:      actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 最初の回が既に始まっていればスケジューラーを待たずに作る
+	today := org.Today(time.Now())
+	if first.After(today) {
+		return series, nil
+	}
+	if _, err := s.instances.createNext(ctx, series, today); err != nil {
+		return nil, err
+	}
+	return s.findSeries(ctx, org.ID, series.ID)
+}
+
+func (s *seriesSvc) UpdateSeries(ctx context.Context, orgExternalID string, id uuid.UUID, input SeriesTemplateInput) (*domain.WishSeries, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.findSeries(ctx, org.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := s.validateTemplate(ctx, org.ID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	series.Title = input.Title
+	series.Note = input.Note
+	series.Price = input.Price
+	series.TagIDs = tagIDs
+	series.AssigneeID = input.AssigneeID
+	series.ListID = input.ListID
+	return s.seriesRepository.UpdateTemplate(ctx, series)
+}
+
+func (s *seriesSvc) TransitionSeries(ctx context.Context, orgExternalID string, id uuid.UUID, to domain.SeriesStatus) (*domain.WishSeries, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.findSeries(ctx, org.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := series.Status
+	switch {
+	case to == domain.SeriesStatusPaused && from == domain.SeriesStatusActive:
+	case to == domain.SeriesStatusActive && from == domain.SeriesStatusPaused:
+		// 停止中に過ぎた回は作らず、今日以降の最初の回から再開する（今日の回は次の実行で作られる）
+		today := org.Today(time.Now())
+		if series.NextDate != nil && series.NextDate.Before(today) {
+			next, ok := series.Rule.Next(series.StartDate, today.AddDate(0, 0, -1))
+			if ok {
+				series.NextDate = &next
+			} else {
+				series.NextDate = nil
+				to = domain.SeriesStatusCompleted
+			}
+		}
+	case to == domain.SeriesStatusStopped && (from == domain.SeriesStatusActive || from == domain.SeriesStatusPaused):
+		series.NextDate = nil
+	default:
+		return nil, domain.ErrInvalidSeriesTransition
+	}
+
+	series.Status = to
+	return s.seriesRepository.UpdateStatus(ctx, series, from)
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"taine-api/domain"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxSharedWishes - 共有リンクで表示するWishの上限
+	MaxSharedWishes       = 500
+	MaxSharePasswordLen   = 128
+	sharePasswordSaltLen  = 16
+	sharePasswordKeyLen   = 32
+	sharePasswordIter     = 600000
+	sharePasswordHashName = "pbkdf2-sha256"
+)
+
+// ShareSvc - 組織外の人向けの読み取り専用の共有リンク
+type ShareSvc interface {
+	ListShareLinks(ctx context.Context, orgExternalID string) ([]*domain.ShareLink, error)
+	// CreateShareLink - リンクと、そのトークンを返す。トークンはハッシュだけを保存するため、ここでしか取得できない
+	CreateShareLink(ctx context.Context, orgExternalID string, actorID uuid.UUID, input ShareLinkInput) (*domain.ShareLink, string, error)
+	// RevokeShareLink - リンクを取り消す。閲覧数の記録のため削除はしない
+	RevokeShareLink(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.ShareLink, error)
+	// ViewShared - トークン（とパスワード）で共有されたWishを取得し、閲覧数を数える。認証なしの経路から呼ばれる
+	ViewShared(ctx context.Context, token, password string) (*domain.SharedWishes, error)
+}
+
+// ShareLinkInput - 新しい共有リンクの設定
+type ShareLinkInput struct {
+	// ListID - 共有するリスト。nilは全てのリスト
+	ListID *uuid.UUID
+	// ExpiresAt - nilは無期限
+	ExpiresAt *time.Time
+	// Password - 空はパスワード無し
+	Password string
+}
+
+type shareSvc struct {
+	shareRepository domain.ShareLinkRepository
+	orgRepository   domain.OrganizationRepository
+	listRepository  domain.ListRepository
+	wishRepository  domain.WishRepository
+}
+
+func NewShareSvc(
+	shareRepository domain.ShareLinkRepository,
+	orgRepository domain.OrganizationRepository,
+	listRepository domain.ListRepository,
+	wishRepository domain.WishRepository,
+) ShareSvc {
+	return &shareSvc{
+		shareRepository: shareRepository,
+		orgRepository:   orgRepository,
+		listRepository:  listRepository,
+		wishRepository:  wishRepository,
+	}
+}
+
+// hashSharePassword - "pbkdf2-sha256$反復回数$ソルト$ハッシュ" の形式にする
+func hashSharePassword(password string) (string, error) {
+	salt := make([]byte, sharePasswordSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, sharePasswordIter, sharePasswordKeyLen)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s$%d$%s$%s", sharePasswordHashName, sharePasswordIter,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// checkSharePassword - hashSharePassword の形式のハッシュとパスワードを比べる
+func checkSharePassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != sharePasswordHashName {
+		return false
+	}
+	iter, err := strconv.Atoi(parts[1])
+	if err != nil || iter <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	got, err := pbkdf2.Key(sha256.New, password, salt, iter, len(want))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+func (s *shareSvc) ListShareLinks(ctx context.Context, orgExternalID string) ([]*domain.ShareLink, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.shareRepository.FindByOrganizationID(ctx, org.ID)
+}
+
+func (s *shareSvc) CreateShareLink(ctx context.Context, orgExternalID string, actorID uuid.UUID, input ShareLinkInput) (*domain.ShareLink, string, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, "", domain.ErrInvalidShare
+	}
+	if utf8.RuneCountInString(input.Password) > MaxSharePasswordLen {
+		return nil, "", domain.ErrInvalidShare
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, "", err
+	}
+	if input.ListID != nil {
+		if _, err := findList(ctx, s.listRepository, org.ID, *input.ListID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	link := &domain.ShareLink{
+		OrganizationID: org.ID,
+		ListID:         input.ListID,
+		TokenHash:      hashSecretToken(token),
+		TokenPrefix:    token[:secretTokenPrefixLen],
+		ExpiresAt:      input.ExpiresAt,
+		CreatedBy:      actorID,
+	}
+	if input.Password != "" {
+		if link.PasswordHash, err = hashSharePassword(input.Password); err != nil {
+			return nil, "", err
+		}
+	}
+
+	created, err := s.shareRepository.Create(ctx, link)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, token, nil
+}
+
+func (s *shareSvc) RevokeShareLink(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.ShareLink, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.shareRepository.Revoke(ctx, org.ID, id, time.Now())
+}
+
+func (s *shareSvc) ViewShared(ctx context.Context, token, password string) (*domain.SharedWishes, error) {
+	if token == "" {
+		return nil, domain.ErrShareNotFound
+	}
+	link, err := s.shareRepository.FindByTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, domain.ErrShareNotFound
+	}
+	now := time.Now()
+	if !link.Active(now) {
+		return nil, domain.ErrShareExpired
+	}
+	if link.HasPassword() && !checkSharePassword(link.PasswordHash, password) {
+		return nil, domain.ErrSharePasswordRequired
+	}
+
+	// 組織が削除された後のリンクは見つからない扱いにする
+	org, err := s.orgRepository.FindByID(ctx, link.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, domain.ErrShareNotFound
+	}
+	shared := &domain.SharedWishes{OrganizationName: org.Name}
+	if link.ListID != nil {
+		if shared.List, err = findList(ctx, s.listRepository, org.ID, *link.ListID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 進行中のWishだけを並び順に返す（達成・アーカイブ済みとゴミ箱のWishは出さない）
+	shared.Wishes, err = s.wishRepository.List(ctx, org.ID, domain.WishListQuery{
+		Limit:    MaxSharedWishes,
+		Sort:     domain.WishSortPriority,
+		Order:    domain.SortAsc,
+		Statuses: domain.OpenWishStatuses,
+		ListID:   link.ListID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachSharedDetails(ctx, shared.Wishes); err != nil {
+		return nil, err
+	}
+
+	if err := s.shareRepository.RecordView(ctx, link.ID, now); err != nil {
+		return nil, err
+	}
+	return shared, nil
+}
+
+// attachSharedDetails - 共有ページで見せるタグとリンクだけを読み込む
+func (s *shareSvc) attachSharedDetails(ctx context.Context, wishes []*domain.Wish) error {
+	if len(wishes) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(wishes))
+	for i, wish := range wishes {
+		ids[i] = wish.ID
+	}
+	tagsByWish, err := s.wishRepository.FindTagsByWishIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	linksByWish, err := s.wishRepository.FindLinksByWishIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, wish := range wishes {
+		wish.Tags = tagsByWish[wish.ID]
+		wish.Links = linksByWish[wish.ID]
+	}
+	return nil
+}
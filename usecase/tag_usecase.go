@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"taine-api/domain"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const MaxTagNameLen = 50
+
+// tagColorPattern - タグの色は "#RRGGBB" 形式
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type TagSvc interface {
+	CreateTag(ctx context.Context, orgExternalID, name, color string) (*domain.Tag, error)
+	ListTags(ctx context.Context, orgExternalID string) ([]*domain.Tag, error)
+	UpdateTag(ctx context.Context, orgExternalID string, id uuid.UUID, name, color string) (*domain.Tag, error)
+	DeleteTag(ctx context.Context, orgExternalID string, id uuid.UUID) error
+	// MergeTags - sourceIDのタグを付けていたWishをtargetIDに付け替え、sourceIDを削除する
+	MergeTags(ctx context.Context, orgExternalID string, sourceID, targetID uuid.UUID) (*domain.Tag, error)
+}
+
+type tagSvc struct {
+	tagRepository domain.TagRepository
+	orgRepository domain.OrganizationRepository
+}
+
+func NewTagSvc(tagRepository domain.TagRepository, orgRepository domain.OrganizationRepository) TagSvc {
+	return &tagSvc{
+		tagRepository: tagRepository,
+		orgRepository: orgRepository,
+	}
+}
+
+// validateTag - 名前と色を検証し、正規化した値を返す
+func validateTag(name, color string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxTagNameLen {
+		return "", "", domain.ErrInvalidTag
+	}
+	if color != "" && !tagColorPattern.MatchString(color) {
+		return "", "", domain.ErrInvalidTag
+	}
+	return name, strings.ToLower(color), nil
+}
+
+func (s *tagSvc) CreateTag(ctx context.Context, orgExternalID, name, color string) (*domain.Tag, error) {
+	name, color, err := validateTag(name, color)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tagRepository.Create(ctx, &domain.Tag{
+		OrganizationID: org.ID,
+		Name:           name,
+		Color:          color,
+	})
+}
+
+func (s *tagSvc) ListTags(ctx context.Context, orgExternalID string) ([]*domain.Tag, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tagRepository.FindByOrganizationID(ctx, org.ID)
+}
+
+func (s *tagSvc) UpdateTag(ctx context.Context, orgExternalID string, id uuid.UUID, name, color string) (*domain.Tag, error) {
+	name, color, err := validateTag(name, color)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tagRepository.Update(ctx, &domain.Tag{
+		ID:             id,
+		OrganizationID: org.ID,
+		Name:           name,
+		Color:          color,
+	})
+}
+
+func (s *tagSvc) DeleteTag(ctx context.Context, orgExternalID string, id uuid.UUID) error {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return err
+	}
+	return s.tagRepository.Delete(ctx, org.ID, id)
+}
+
+func (s *tagSvc) MergeTags(ctx context.Context, orgExternalID string, sourceID, targetID uuid.UUID) (*domain.Tag, error) {
+	if sourceID == targetID {
+		return nil, domain.ErrInvalidTag
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 統合先も同じ組織のタグであることを確認
+	target, err := s.tagRepository.FindByID(ctx, org.ID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, domain.ErrTagNotFound
+	}
+
+	if err := s.tagRepository.Merge(ctx, org.ID, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
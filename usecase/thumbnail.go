@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"taine-api/domain"
+)
+
+const (
+	// thumbnailMaxSide - サムネイルの長辺（px）
+	thumbnailMaxSide = 320
+	// maxImagePixels - デコードを許可する最大画素数（解凍爆弾対策）
+	maxImagePixels = 40_000_000
+	// thumbnailSamples - 出力1pxあたり縦横それぞれ何点サンプリングして平均するか
+	thumbnailSamples = 4
+)
+
+// attachmentFormats - 受け付けるMIMEタイプとデコーダ
+var attachmentFormats = map[string]struct {
+	decode       func(r *bytes.Reader) (image.Image, error)
+	decodeConfig func(r *bytes.Reader) (image.Config, error)
+}{
+	"image/jpeg": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return jpeg.DecodeConfig(r) },
+	},
+	"image/png": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return png.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return png.DecodeConfig(r) },
+	},
+	"image/gif": {
+		decode:       func(r *bytes.Reader) (image.Image, error) { return gif.Decode(r) },
+		decodeConfig: func(r *bytes.Reader) (image.Config, error) { return gif.DecodeConfig(r) },
+	},
+}
+
+// thumbnail - 画像の寸法とサムネイルを返す。サムネイルはPNG（透過を保つ）以外JPEGで出力する
+func thumbnail(data []byte, contentType string) (width, height int, thumb []byte, thumbType string, err error) {
+	format, ok := attachmentFormats[contentType]
+	if !ok {
+		return 0, 0, nil, "", domain.ErrUnsupportedMediaType
+	}
+
+	// 本体をデコードする前に寸法だけ読んで巨大な画像を弾く
+	cfg, err := format.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, "", domain.ErrUnsupportedMediaType
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return 0, 0, nil, "", domain.ErrAttachmentTooLarge
+	}
+
+	src, err := format.decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, "", domain.ErrUnsupportedMediaType
+	}
+
+	dst := resizeToFit(src, thumbnailMaxSide)
+	var buf bytes.Buffer
+	thumbType = "image/jpeg"
+	if contentType == "image/png" {
+		thumbType = "image/png"
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	}
+	if err != nil {
+		return 0, 0, nil, "", err
+	}
+	return cfg.Width, cfg.Height, buf.Bytes(), thumbType, nil
+}
+
+// resizeToFit - 長辺がmaxSide以下になるよう縮小する（拡大はしない）
+// 出力1pxごとに元画像を格子状にサンプリングして平均する簡易的な面積平均
+func resizeToFit(src image.Image, maxSide int) *image.NRGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if sw > maxSide || sh > maxSide {
+		if sw >= sh {
+			dw, dh = maxSide, max(1, sh*maxSide/sw)
+		} else {
+			dw, dh = max(1, sw*maxSide/sh), maxSide
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			// 透明な画素の色が混ざらないよう、アルファ乗算済みの値で平均する
+			var r, g, bl, a uint32
+			for sy := 0; sy < thumbnailSamples; sy++ {
+				for sx := 0; sx < thumbnailSamples; sx++ {
+					px := b.Min.X + (x*thumbnailSamples+sx)*sw/(dw*thumbnailSamples)
+					py := b.Min.Y + (y*thumbnailSamples+sy)*sh/(dh*thumbnailSamples)
+					cr, cg, cb, ca := src.At(px, py).RGBA()
+					r += cr
+					g += cg
+					bl += cb
+					a += ca
+				}
+			}
+			n := uint32(thumbnailSamples * thumbnailSamples)
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"taine-api/domain"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func solidImage(w, h int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestThumbnailFormats(t *testing.T) {
+	src := solidImage(640, 480, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+
+	var jpg, gifData bytes.Buffer
+	if err := jpeg.Encode(&jpg, src, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := gif.Encode(&gifData, src, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		contentType string
+		data        []byte
+		thumbType   string
+		decode      func(r *bytes.Reader) (image.Image, error)
+	}{
+		{"image/png", encodePNG(t, src), "image/png", func(r *bytes.Reader) (image.Image, error) { return png.Decode(r) }},
+		{"image/jpeg", jpg.Bytes(), "image/jpeg", func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) }},
+		// GIFはJPEGのサムネイルにする
+		{"image/gif", gifData.Bytes(), "image/jpeg", func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) }},
+	} {
+		t.Run(tt.contentType, func(t *testing.T) {
+			width, height, thumb, thumbType, err := thumbnail(tt.data, tt.contentType)
+			if err != nil {
+				t.Fatalf("thumbnail() error = %v", err)
+			}
+			if width != 640 || height != 480 {
+				t.Errorf("size = %dx%d, want 640x480", width, height)
+			}
+			if thumbType != tt.thumbType {
+				t.Errorf("thumbType = %q, want %q", thumbType, tt.thumbType)
+			}
+			img, err := tt.decode(bytes.NewReader(thumb))
+			if err != nil {
+				t.Fatalf("decode thumbnail: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != 320 || b.Dy() != 240 {
+				t.Errorf("thumbnail size = %dx%d, want 320x240", b.Dx(), b.Dy())
+			}
+		})
+	}
+}
+
+func TestThumbnailRejectsInvalidImages(t *testing.T) {
+	png1x1 := encodePNG(t, solidImage(1, 1, color.NRGBA{A: 255}))
+
+	for _, tt := range []struct {
+		name        string
+		data        []byte
+		contentType string
+		want        error
+	}{
+		{"unsupported type", png1x1, "image/webp", domain.ErrUnsupportedMediaType},
+		{"mismatched type", png1x1, "image/jpeg", domain.ErrUnsupportedMediaType},
+		{"not an image", []byte("hello"), "image/png", domain.ErrUnsupportedMediaType},
+		{"truncated", png1x1[:len(png1x1)/2], "image/png", domain.ErrUnsupportedMediaType},
+		// 寸法だけ大きく、画素データを持たない解凍爆弾はデコード前に弾く
+		{"too many pixels", pngHeader(t, 10_000, 10_000), "image/png", domain.ErrAttachmentTooLarge},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, _, err := thumbnail(tt.data, tt.contentType); !errors.Is(err, tt.want) {
+				t.Errorf("thumbnail() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// pngHeader - 指定した寸法の IHDR だけを持つPNG（DecodeConfig は通るがデコードはできない）
+func pngHeader(t *testing.T, width, height int) []byte {
+	t.Helper()
+	data := encodePNG(t, solidImage(1, 1, color.NRGBA{A: 255}))
+	// シグネチャ(8) + 長さ(4) + "IHDR"(4) の後に幅と高さが続く
+	put := func(off, v int) {
+		data[off], data[off+1], data[off+2], data[off+3] = byte(v>>24), byte(v>>16), byte(v>>8), byte(v)
+	}
+	put(16, width)
+	put(20, height)
+	// IHDR のCRC（"IHDR" と13バイトのデータが対象）を付け直す
+	put(29, int(crc32.ChecksumIEEE(data[12:29])))
+	return data
+}
+
+func TestResizeToFit(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		w, h  int
+		wantW int
+		wantH int
+	}{
+		{"landscape", 1000, 500, 320, 160},
+		{"portrait", 500, 1000, 160, 320},
+		{"square", 800, 800, 320, 320},
+		{"small images are not enlarged", 100, 50, 100, 50},
+		{"very thin images keep one pixel", 10_000, 10, 320, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := resizeToFit(solidImage(tt.w, tt.h, color.NRGBA{A: 255}), thumbnailMaxSide)
+			if b := dst.Bounds(); b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Errorf("resizeToFit(%dx%d) = %dx%d, want %dx%d", tt.w, tt.h, b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResizeToFitAveragesPixels(t *testing.T) {
+	// 白黒1pxの縦縞を半分に縮めると灰色になり、透明度も平均される
+	src := image.NewNRGBA(image.Rect(0, 0, 640, 2))
+	for x := 0; x < 640; x++ {
+		v := uint8(0)
+		if x%2 == 0 {
+			v = 255
+		}
+		src.SetNRGBA(x, 0, color.NRGBA{R: v, G: v, B: v, A: 255})
+		src.SetNRGBA(x, 1, color.NRGBA{R: v, G: v, B: v, A: 0})
+	}
+
+	got := resizeToFit(src, thumbnailMaxSide).NRGBAAt(10, 0)
+	if got.R < 120 || got.R > 135 || got.A < 120 || got.A > 135 {
+		t.Errorf("averaged pixel = %+v, want about half grey and half transparent", got)
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	// secretTokenBytes - 共有リンク・カレンダーのURLに使うトークンのランダムなバイト数
+	secretTokenBytes = 32
+	// secretTokenPrefixLen - 一覧でトークンを見分けるために保存する先頭の文字数
+	secretTokenPrefixLen = 6
+)
+
+// newSecretToken - URLにそのまま使えるランダムなトークンを作る
+func newSecretToken() (string, error) {
+	raw := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// ValidSecretToken - token が newSecretToken で作れる形か（base64urlで43文字）
+// 形の違うトークンはデータベースやレート制限のカウンタに触れる前に弾ける
+func ValidSecretToken(token string) bool {
+	if len(token) != base64.RawURLEncoding.EncodedLen(secretTokenBytes) {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil
+}
+
+// hashSecretToken - トークンを保存・検索用のハッシュにする。トークンは十分にランダムなのでソルトは要らない
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"taine-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashPurger - 組織ごとの保持期間を過ぎたゴミ箱のWishを完全削除するバックグラウンド処理
+// 添付の行はWishと一緒に消えるため、元画像とサムネイルのBlobも削除する
+type TrashPurger struct {
+	wishRepository       domain.WishRepository
+	orgRepository        domain.OrganizationRepository
+	attachmentRepository domain.AttachmentRepository
+	blobStore            domain.BlobStore
+}
+
+func NewTrashPurger(
+	wishRepository domain.WishRepository,
+	orgRepository domain.OrganizationRepository,
+	attachmentRepository domain.AttachmentRepository,
+	blobStore domain.BlobStore,
+) *TrashPurger {
+	return &TrashPurger{
+		wishRepository:       wishRepository,
+		orgRepository:        orgRepository,
+		attachmentRepository: attachmentRepository,
+		blobStore:            blobStore,
+	}
+}
+
+// Run - intervalごとに PurgeOnce を実行する。ctxがキャンセルされると終了する
+func (p *TrashPurger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.PurgeOnce(ctx); err != nil {
+			log.Println("trash purge failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce - 全組織のゴミ箱を1回掃除する。1組織の失敗で他の組織を止めない
+func (p *TrashPurger) PurgeOnce(ctx context.Context) error {
+	orgs, err := p.orgRepository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, org := range orgs {
+		if org.TrashRetentionDays <= 0 {
+			continue // 無期限保持
+		}
+
+		before := now.AddDate(0, 0, -org.TrashRetentionDays)
+		// 添付の行は完全削除でカスケード削除されるため、先にBlobのキーを読んでおく
+		// （ゴミ箱のWishには添付できないため、この間に添付が増えることは無い）
+		attachments, err := p.attachmentRepository.FindByTrashedBefore(ctx, org.ID, before)
+		if err != nil {
+			log.Printf("trash purge failed: org=%s err=%v", org.ExternalID, err)
+			continue
+		}
+		purged, err := p.wishRepository.PurgeDeleted(ctx, org.ID, before)
+		if err != nil {
+			log.Printf("trash purge failed: org=%s err=%v", org.ExternalID, err)
+			continue
+		}
+
+		purgedIDs := make(map[uuid.UUID]bool, len(purged))
+		for _, wish := range purged {
+			purgedIDs[wish.ID] = true
+			log.Printf("trash purge: org=%s wish=%s title=%q deleted_at=%s",
+				org.ExternalID, wish.ID, wish.Title, wish.DeletedAt.Format(time.RFC3339))
+		}
+		// 読んでから完全削除するまでに復元されたWishの添付は残す
+		var purgedAttachments []*domain.Attachment
+		for _, attachment := range attachments {
+			if purgedIDs[attachment.WishID] {
+				purgedAttachments = append(purgedAttachments, attachment)
+			}
+		}
+		deleteBlobs(ctx, p.blobStore, attachmentBlobKeys(purgedAttachments)...)
+	}
+	return nil
+}
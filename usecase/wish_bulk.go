@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkWishOperations - 一括操作で1回に指定できる操作の上限
+const MaxBulkWishOperations = 100
+
+// BulkWishOp - 一括操作の種類
+type BulkWishOp string
+
+const (
+	BulkWishCreate     BulkWishOp = "create"
+	BulkWishUpdate     BulkWishOp = "update"
+	BulkWishSoftDelete BulkWishOp = "soft_delete"
+	BulkWishRestore    BulkWishOp = "restore"
+	BulkWishDelete     BulkWishOp = "delete"
+)
+
+// bulkWishActions - 操作ごとに必要な権限（単体のルートと同じ）
+var bulkWishActions = map[BulkWishOp]domain.Action{
+	BulkWishCreate:     domain.ActionCreate,
+	BulkWishUpdate:     domain.ActionUpdate,
+	BulkWishSoftDelete: domain.ActionSoftDelete,
+	BulkWishRestore:    domain.ActionRestore,
+	BulkWishDelete:     domain.ActionDelete,
+}
+
+// Action - 操作に必要な権限。未知の操作は空文字
+func (op BulkWishOp) Action() domain.Action {
+	return bulkWishActions[op]
+}
+
+// BulkWishOperation - 一括操作の1件
+type BulkWishOperation struct {
+	Op BulkWishOp
+	// ID - create 以外で対象のWish
+	ID uuid.UUID
+	// ExpectedVersion - 0より大きければ、バージョンが一致する場合だけ書き込む（restore 以外）
+	ExpectedVersion int
+	// Input - create の内容
+	Input WishInput
+	// Patch - update の内容（PatchWish と同じく指定した項目だけ変更する）
+	Patch WishPatch
+}
+
+// BulkWishResult - 一括操作1件の結果。Wish は create・update の場合だけ設定する
+type BulkWishResult struct {
+	Wish *domain.Wish
+	Err  error
+}
+
+// BulkWishes - 複数の操作を順番に実行し、操作ごとの結果を返す
+// atomic の場合は1つのトランザクションで実行し、1件でも失敗すれば全てを取り消す
+// （失敗した操作以外の結果は domain.ErrBulkRolledBack になる）。
+// atomic でない場合は操作ごとに単体の操作と同じように実行し、失敗した操作だけがエラーになる
+func (s *wishSvc) BulkWishes(ctx context.Context, orgExternalID string, actorID uuid.UUID, operations []BulkWishOperation, atomic bool) ([]BulkWishResult, error) {
+	if len(operations) == 0 || len(operations) > MaxBulkWishOperations {
+		return nil, domain.ErrInvalidBulkOperation
+	}
+	for _, operation := range operations {
+		if operation.Op.Action() == "" {
+			return nil, domain.ErrInvalidBulkOperation
+		}
+	}
+	if _, err := findOrganization(ctx, s.orgRepository, orgExternalID); err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkWishResult, len(operations))
+	if !atomic {
+		for i, operation := range operations {
+			results[i].Wish, results[i].Err = s.runBulkOperation(ctx, orgExternalID, actorID, operation)
+		}
+		return results, nil
+	}
+
+	failed := -1
+	var pendingBlobs []string
+	err := s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		// 全ての操作が同じトランザクションのリポジトリを使うようにする
+		// 完全削除したWishのBlobは、取り消された場合に備えてコミット後に消す
+		tx := *s
+		tx.wishRepository = repo
+		tx.pendingBlobs = &pendingBlobs
+		for i, operation := range operations {
+			wish, err := tx.runBulkOperation(ctx, orgExternalID, actorID, operation)
+			if err != nil {
+				failed = i
+				return err
+			}
+			results[i].Wish = wish
+		}
+		return nil
+	})
+	if err != nil && failed < 0 {
+		return nil, err
+	}
+	if err != nil {
+		for i := range results {
+			results[i] = BulkWishResult{Err: domain.ErrBulkRolledBack}
+		}
+		results[failed].Err = err
+		return results, nil
+	}
+	deleteBlobs(ctx, s.blobStore, pendingBlobs...)
+	return results, nil
+}
+
+// runBulkOperation - 一括操作の1件を単体の操作で実行する
+func (s *wishSvc) runBulkOperation(ctx context.Context, orgExternalID string, actorID uuid.UUID, operation BulkWishOperation) (*domain.Wish, error) {
+	switch operation.Op {
+	case BulkWishCreate:
+		return s.CreateWishByOrganizationExternalID(ctx, orgExternalID, actorID, operation.Input)
+	case BulkWishUpdate:
+		return s.PatchWish(ctx, orgExternalID, operation.ID, actorID, operation.ExpectedVersion, operation.Patch)
+	case BulkWishSoftDelete:
+		return nil, s.SoftDeleteWish(ctx, orgExternalID, operation.ID, actorID, operation.ExpectedVersion)
+	case BulkWishRestore:
+		return nil, s.RestoreWish(ctx, orgExternalID, operation.ID, actorID)
+	case BulkWishDelete:
+		return nil, s.DeleteWish(ctx, orgExternalID, operation.ID, operation.ExpectedVersion)
+	default:
+		return nil, domain.ErrInvalidBulkOperation
+	}
+}
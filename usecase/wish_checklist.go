@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"taine-api/domain"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxChecklistItems - 1つのWishに追加できるチェックリスト項目の上限
+	MaxChecklistItems = 100
+	// MaxChecklistTitleLength - チェックリスト項目のタイトルの最大文字数
+	MaxChecklistTitleLength = 200
+)
+
+// ChecklistItemInput - チェックリスト項目の変更。nilの項目は変更しない
+type ChecklistItemInput struct {
+	Title *string
+	// AssigneeID - 担当メンバー。ClearAssignee で外す
+	AssigneeID    *uuid.UUID
+	ClearAssignee bool
+	Done          *bool
+	// FulfillWhenComplete - この変更で全ての項目がチェック済みになった場合、Wishを達成（fulfilled）にする
+	FulfillWhenComplete bool
+}
+
+// normalizeChecklistTitle - 前後の空白を除き、空や長すぎるタイトルを拒否する
+func normalizeChecklistTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > MaxChecklistTitleLength {
+		return "", domain.ErrInvalidChecklistItem
+	}
+	return title, nil
+}
+
+// findChecklistItem - Wishに属するチェックリスト項目を取得
+func findChecklistItem(ctx context.Context, repo domain.WishRepository, wishID, id uuid.UUID) (*domain.ChecklistItem, error) {
+	item, err := repo.FindChecklistItem(ctx, wishID, id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+	return item, nil
+}
+
+// lockLiveWish - トランザクション内でWishをロックして取得する。チェックリストの変更を直列化するために使う
+func lockLiveWish(ctx context.Context, repo domain.WishRepository, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	wish, err := repo.FindByIDForUpdate(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if wish == nil || wish.DeletedAt != nil {
+		return nil, domain.ErrWishNotFound
+	}
+	return wish, nil
+}
+
+func (s *wishSvc) ListChecklist(ctx context.Context, orgExternalID string, wishID uuid.UUID) ([]*domain.ChecklistItem, error) {
+	wish, err := s.findWish(ctx, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	return s.wishRepository.FindChecklistItems(ctx, wish.ID)
+}
+
+func (s *wishSvc) AddChecklistItem(ctx context.Context, orgExternalID string, wishID uuid.UUID, title string, assigneeID *uuid.UUID) (*domain.ChecklistItem, error) {
+	title, err := normalizeChecklistTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	wish, err := s.findWish(ctx, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateAssignee(ctx, wish.OrganizationID, assigneeID); err != nil {
+		return nil, err
+	}
+
+	var created *domain.ChecklistItem
+	err = s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		if _, err := lockLiveWish(ctx, repo, wish.OrganizationID, wish.ID); err != nil {
+			return err
+		}
+		items, err := repo.FindChecklistItems(ctx, wish.ID)
+		if err != nil {
+			return err
+		}
+		if len(items) >= MaxChecklistItems {
+			return domain.ErrTooManyChecklistItems
+		}
+
+		// 新しい項目は末尾に置く
+		var last string
+		if len(items) > 0 {
+			last = items[len(items)-1].Rank
+		}
+		rank, err := domain.RankBetween(last, "")
+		if err != nil {
+			return err
+		}
+
+		created, err = repo.CreateChecklistItem(ctx, &domain.ChecklistItem{
+			OrganizationID: wish.OrganizationID,
+			WishID:         wish.ID,
+			Title:          title,
+			Rank:           rank,
+			AssigneeID:     assigneeID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateChecklistItem - 変更後の項目と、進捗・ステータスを反映したWishを返す
+func (s *wishSvc) UpdateChecklistItem(ctx context.Context, orgExternalID string, wishID, itemID, actorID uuid.UUID, input ChecklistItemInput) (*domain.ChecklistItem, *domain.Wish, error) {
+	var title string
+	if input.Title != nil {
+		var err error
+		if title, err = normalizeChecklistTitle(*input.Title); err != nil {
+			return nil, nil, err
+		}
+	}
+	wish, err := s.findWish(ctx, orgExternalID, wishID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.validateAssignee(ctx, wish.OrganizationID, input.AssigneeID); err != nil {
+		return nil, nil, err
+	}
+
+	var updated *domain.ChecklistItem
+	var progress domain.ChecklistProgress
+	err = s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		if _, err := lockLiveWish(ctx, repo, wish.OrganizationID, wish.ID); err != nil {
+			return err
+		}
+		item, err := findChecklistItem(ctx, repo, wish.ID, itemID)
+		if err != nil {
+			return err
+		}
+
+		if input.Title != nil {
+			item.Title = title
+		}
+		switch {
+		case input.ClearAssignee:
+			item.AssigneeID = nil
+		case input.AssigneeID != nil:
+			item.AssigneeID = input.AssigneeID
+		}
+		// 既に同じ状態の場合は完了日時・完了者を書き換えない
+		if input.Done != nil && *input.Done != item.Done() {
+			if *input.Done {
+				now := time.Now()
+				item.CompletedAt = &now
+				item.CompletedBy = &actorID
+			} else {
+				item.CompletedAt = nil
+				item.CompletedBy = nil
+			}
+		}
+
+		if updated, err = repo.UpdateChecklistItem(ctx, item); err != nil {
+			return err
+		}
+		counts, err := repo.CountChecklistByWishIDs(ctx, []uuid.UUID{wish.ID})
+		if err != nil {
+			return err
+		}
+		progress = counts[wish.ID]
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 最後の項目をチェックした場合だけ達成にする。既に達成・アーカイブ済みなどで遷移できない場合はそのまま
+	if input.FulfillWhenComplete && input.Done != nil && *input.Done && progress.Complete() &&
+		wish.Status.CanTransitionTo(domain.WishStatusFulfilled) {
+		fulfilled, err := s.TransitionWishStatus(ctx, orgExternalID, wish.ID, actorID, domain.WishStatusFulfilled)
+		if err == nil {
+			return updated, fulfilled, nil
+		}
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			return nil, nil, err
+		}
+	}
+
+	current, err := s.GetWish(ctx, orgExternalID, wish.ID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, current, nil
+}
+
+// MoveChecklistItem - 項目を afterID の直後、beforeID の直前、どちらも無ければ先頭へ移動する
+func (s *wishSvc) MoveChecklistItem(ctx context.Context, orgExternalID string, wishID, itemID uuid.UUID, afterID, beforeID *uuid.UUID) (*domain.ChecklistItem, error) {
+	if afterID != nil && beforeID != nil {
+		return nil, domain.ErrInvalidChecklistMove
+	}
+	if (afterID != nil && *afterID == itemID) || (beforeID != nil && *beforeID == itemID) {
+		return nil, domain.ErrInvalidChecklistMove
+	}
+	wish, err := s.findWish(ctx, orgExternalID, wishID)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved *domain.ChecklistItem
+	err = s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		if _, err := lockLiveWish(ctx, repo, wish.OrganizationID, wish.ID); err != nil {
+			return err
+		}
+		items, err := repo.FindChecklistItems(ctx, wish.ID)
+		if err != nil {
+			return err
+		}
+
+		// 移動する項目を除いた並びの中で、前後の項目のrankの間に置く
+		var item *domain.ChecklistItem
+		others := make([]*domain.ChecklistItem, 0, len(items))
+		for _, other := range items {
+			if other.ID == itemID {
+				item = other
+			} else {
+				others = append(others, other)
+			}
+		}
+		if item == nil {
+			return domain.ErrChecklistItemNotFound
+		}
+
+		at := 0
+		if anchorID := afterID; anchorID != nil || beforeID != nil {
+			if anchorID == nil {
+				anchorID = beforeID
+			}
+			at = -1
+			for i, other := range others {
+				if other.ID == *anchorID {
+					at = i
+				}
+			}
+			if at < 0 {
+				return domain.ErrChecklistItemNotFound
+			}
+			if afterID != nil {
+				at++
+			}
+		}
+
+		var lower, upper string
+		if at > 0 {
+			lower = others[at-1].Rank
+		}
+		if at < len(others) {
+			upper = others[at].Rank
+		}
+		if item.Rank, err = domain.RankBetween(lower, upper); err != nil {
+			return err
+		}
+		moved, err = repo.UpdateChecklistItem(ctx, item)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
+func (s *wishSvc) DeleteChecklistItem(ctx context.Context, orgExternalID string, wishID, itemID uuid.UUID) error {
+	wish, err := s.findWish(ctx, orgExternalID, wishID)
+	if err != nil {
+		return err
+	}
+	if wish.DeletedAt != nil {
+		return domain.ErrWishNotFound
+	}
+	return s.wishRepository.DeleteChecklistItem(ctx, wish.ID, itemID)
+}
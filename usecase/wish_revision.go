@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"taine-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// wishChange - saveWithRevision で適用する変更
+type wishChange struct {
+	// apply - ロックして読み直したWishに変更を加える。エラーを返すと保存しない
+	apply func(wish *domain.Wish) error
+	// tagIDs - nilの場合はタグを変更しない
+	tagIDs []uuid.UUID
+	// revertedFrom - 履歴を戻す操作の場合、戻した先の履歴番号
+	revertedFrom *int
+	// expectedVersion - 0より大きければ、バージョンが一致する場合だけ保存する
+	expectedVersion int
+	// save - apply を適用したWishを保存し、保存後のWishを返す。nilの場合は repo.Update で編集できる項目を保存する
+	// （status・ゴミ箱・並び順など Update で保存しない項目の変更に使う）
+	save func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) (*domain.Wish, error)
+	// reorder - order_no を変える変更。行をロックする前にrankのロックを取り、ReorderWishes とのデッドロックを避ける
+	reorder bool
+}
+
+// saveWithRevision - Wishをロックして読み直し、変更を適用して保存し、差分を履歴に記録する
+// 読み直した値を変更前として記録するため、同時に編集されても履歴の「変更前」が古くならない
+func (s *wishSvc) saveWithRevision(ctx context.Context, organizationID, id, actorID uuid.UUID, change wishChange) (*domain.Wish, error) {
+	var updated *domain.Wish
+	err := s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		var err error
+		updated, err = changeWithRevision(ctx, repo, organizationID, id, actorID, change)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// changeWithRevision - saveWithRevision の本体。呼び出し元のトランザクションの中で実行する
+func changeWithRevision(ctx context.Context, repo domain.WishRepository, organizationID, id, actorID uuid.UUID, change wishChange) (*domain.Wish, error) {
+	if change.reorder {
+		if err := repo.LockRanks(ctx, organizationID); err != nil {
+			return nil, err
+		}
+	}
+	wish, err := repo.FindByIDForUpdate(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if wish == nil {
+		return nil, domain.ErrWishNotFound
+	}
+	tagsByWish, err := repo.FindTagsByWishIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	wish.Tags = tagsByWish[id]
+	before := domain.NewWishSnapshot(wish)
+	orderNo := wish.OrderNo
+
+	if err := change.apply(wish); err != nil {
+		return nil, err
+	}
+	save := change.save
+	if save == nil {
+		save = func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) (*domain.Wish, error) {
+			return repo.Update(ctx, wish, change.expectedVersion)
+		}
+	}
+	updated, err := save(ctx, repo, wish)
+	if err != nil {
+		return nil, err
+	}
+	updated.Tags = wish.Tags
+	if change.tagIDs != nil {
+		if err := repo.ReplaceTags(ctx, id, change.tagIDs); err != nil {
+			return nil, err
+		}
+		updated.Tags = tagsOf(change.tagIDs)
+	}
+	// 旧APIの order_no が変わった場合は、その並びになる位置へ移動する
+	if updated.OrderNo != orderNo {
+		rank, err := priorityRank(ctx, repo, updated)
+		if err != nil {
+			return nil, err
+		}
+		if err := repo.UpdateRank(ctx, organizationID, id, rank, 0); err != nil {
+			return nil, err
+		}
+		updated.Rank = rank
+	}
+
+	if err := recordRevision(ctx, repo, updated, before, actorID, change.revertedFrom); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// reloadAfter - 個別の更新（status・ゴミ箱・並び順）で保存し、保存後のWishを読み直す wishChange.save を作る
+func reloadAfter(write func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) error) func(context.Context, domain.WishRepository, *domain.Wish) (*domain.Wish, error) {
+	return func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) (*domain.Wish, error) {
+		if err := write(ctx, repo, wish); err != nil {
+			return nil, err
+		}
+		updated, err := repo.FindByID(ctx, wish.OrganizationID, wish.ID)
+		if err != nil {
+			return nil, err
+		}
+		if updated == nil {
+			return nil, domain.ErrWishNotFound
+		}
+		return updated, nil
+	}
+}
+
+// softDeleteChange - Wishをゴミ箱に入れる変更。既にゴミ箱にある場合は domain.ErrWishAlreadyDeleted
+func softDeleteChange(actorID uuid.UUID, expectedVersion int) wishChange {
+	return wishChange{
+		apply: func(wish *domain.Wish) error {
+			if wish.DeletedAt != nil {
+				return domain.ErrWishAlreadyDeleted
+			}
+			now := time.Now()
+			wish.DeletedAt = &now
+			wish.DeletedBy = &actorID
+			return nil
+		},
+		save: reloadAfter(func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) error {
+			return repo.SoftDelete(ctx, wish.OrganizationID, wish.ID, actorID, expectedVersion)
+		}),
+	}
+}
+
+// rankChange - Wishを listID の rank の位置へ移動する変更。listID が今のリストと違えばリストも移す
+// expectedVersion > 0 の場合、Wishがそのバージョンのままのときだけ移動する
+func rankChange(listID uuid.UUID, rank string, expectedVersion int) wishChange {
+	var moveList bool
+	return wishChange{
+		apply: func(wish *domain.Wish) error {
+			moveList = wish.ListID != listID
+			wish.ListID = listID
+			wish.Rank = rank
+			return nil
+		},
+		save: reloadAfter(func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) error {
+			if moveList {
+				return repo.UpdateList(ctx, wish.OrganizationID, wish.ID, listID, rank, expectedVersion)
+			}
+			return repo.UpdateRank(ctx, wish.OrganizationID, wish.ID, rank, expectedVersion)
+		}),
+	}
+}
+
+// recordRevision - 変更後のWish（Tagsは少なくともIDが設定済み）を履歴に記録する
+// 変更が無い場合は記録しない（履歴を戻す操作は常に記録する）。
+// 履歴導入前からあるWishは、最初の変更時に変更前の状態を起点として記録し、そこへ戻せるようにする
+func recordRevision(ctx context.Context, repo domain.WishRepository, wish *domain.Wish, before *domain.WishSnapshot, actorID uuid.UUID, revertedFrom *int) error {
+	after := domain.NewWishSnapshot(wish)
+	changes := domain.DiffSnapshots(before, after)
+	if len(changes) == 0 && revertedFrom == nil {
+		return nil
+	}
+
+	if before != nil {
+		latest, err := repo.FindRevisions(ctx, wish.ID, 0, 1)
+		if err != nil {
+			return err
+		}
+		if len(latest) == 0 {
+			if _, err := repo.CreateRevision(ctx, &domain.WishRevision{
+				OrganizationID: wish.OrganizationID,
+				WishID:         wish.ID,
+				Snapshot:       before,
+				Changes:        domain.DiffSnapshots(nil, before),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := repo.CreateRevision(ctx, &domain.WishRevision{
+		OrganizationID: wish.OrganizationID,
+		WishID:         wish.ID,
+		ActorID:        &actorID,
+		Snapshot:       after,
+		Changes:        changes,
+		RevertedFrom:   revertedFrom,
+	})
+	return err
+}
+
+// tagsOf - 履歴のスナップショット用にIDだけのタグを作る
+func tagsOf(tagIDs []uuid.UUID) []*domain.Tag {
+	tags := make([]*domain.Tag, len(tagIDs))
+	for i, id := range tagIDs {
+		tags[i] = &domain.Tag{ID: id}
+	}
+	return tags
+}
+
+// attachActors - 履歴の変更者をまとめて読み込んで設定する（退会済みのユーザーはnilのまま）
+func (s *wishSvc) attachActors(ctx context.Context, revisions ...*domain.WishRevision) error {
+	seen := make(map[uuid.UUID]bool, len(revisions))
+	ids := make([]uuid.UUID, 0, len(revisions))
+	for _, revision := range revisions {
+		if revision.ActorID != nil && !seen[*revision.ActorID] {
+			seen[*revision.ActorID] = true
+			ids = append(ids, *revision.ActorID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users, err := s.userRepository.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	usersByID := make(map[uuid.UUID]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	for _, revision := range revisions {
+		if revision.ActorID != nil {
+			revision.Actor = usersByID[*revision.ActorID]
+		}
+	}
+	return nil
+}
+
+// findRevision - Wishの指定番号の履歴を取得
+func (s *wishSvc) findRevision(ctx context.Context, wishID uuid.UUID, number int) (*domain.WishRevision, error) {
+	if number <= 0 {
+		return nil, domain.ErrRevisionNotFound
+	}
+	revision, err := s.wishRepository.FindRevision(ctx, wishID, number)
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		return nil, domain.ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+func (s *wishSvc) ListRevisions(ctx context.Context, orgExternalID string, id uuid.UUID, before, limit int) (*domain.WishRevisionPage, error) {
+	if limit <= 0 {
+		limit = DefaultRevisionPageSize
+	}
+	if limit > MaxRevisionPageSize {
+		limit = MaxRevisionPageSize
+	}
+
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 次ページの有無を判定するため1件多く取得
+	revisions, err := s.wishRepository.FindRevisions(ctx, wish.ID, before, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.WishRevisionPage{Revisions: revisions}
+	if len(revisions) > limit {
+		page.Revisions = revisions[:limit]
+		page.NextBefore = page.Revisions[limit-1].Number
+	}
+	if err := s.attachActors(ctx, page.Revisions...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (s *wishSvc) DiffRevisions(ctx context.Context, orgExternalID string, id uuid.UUID, from, to int) ([]domain.FieldChange, error) {
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.findRevision(ctx, wish.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.findRevision(ctx, wish.ID, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DiffSnapshots(fromRevision.Snapshot, toRevision.Snapshot), nil
+}
+
+// RevertWish - 編集できる項目を戻す。status・リスト・並び順・ゴミ箱の状態は履歴には残るが、専用の操作で変更するため戻さない
+// 削除済みのタグや組織を抜けた担当者は戻せないため外した状態で戻す
+func (s *wishSvc) RevertWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, number int) (*domain.Wish, error) {
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return nil, err
+	}
+	if wish.DeletedAt != nil {
+		return nil, domain.ErrWishNotFound
+	}
+
+	revision, err := s.findRevision(ctx, wish.ID, number)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := revision.Snapshot
+
+	tags, err := s.tagRepository.FindByIDs(ctx, wish.OrganizationID, snapshot.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+
+	assigneeID := snapshot.AssigneeID
+	if err := s.validateAssignee(ctx, wish.OrganizationID, assigneeID); err == domain.ErrInvalidAssignee {
+		assigneeID = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var targetDate *time.Time
+	if snapshot.TargetDate != nil {
+		date, err := domain.ParseDate(*snapshot.TargetDate)
+		if err != nil {
+			return nil, err
+		}
+		targetDate = &date
+	}
+
+	updated, err := s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, wishChange{
+		tagIDs:       tagIDs,
+		revertedFrom: &revision.Number,
+		apply: func(wish *domain.Wish) error {
+			wish.Title = snapshot.Title
+			wish.Note = snapshot.Note
+			wish.OrderNo = snapshot.OrderNo
+			wish.Price = snapshot.Price
+			wish.TargetDate = targetDate
+			wish.AssigneeID = assigneeID
+			wish.Place = snapshot.Place
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+// TransferWish - Wishを呼び出し元が所属する別の組織へコピー・移動する
+// タイトル・メモ・価格・期日・場所とタグ（移動先に同じ名前のタグが無ければ作る）を引き継ぐ。
+// 担当者・投票・コメント・チェックリストは組織内の情報なので引き継がない。
+// 移動の場合、元のWishはゴミ箱に入る
+func (s *wishSvc) TransferWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, transfer domain.WishTransfer) (*domain.Wish, error) {
+	if transfer.Kind != domain.TransferCopy && transfer.Kind != domain.TransferMove {
+		return nil, domain.ErrInvalidTransfer
+	}
+
+	source, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := findOrganization(ctx, s.orgRepository, transfer.OrganizationExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Kind == domain.TransferMove && target.ID == source.ID {
+		return nil, domain.ErrInvalidTransfer
+	}
+	// 両方の組織のメンバーであること（退会済みは含まない）
+	for _, orgID := range []uuid.UUID{source.ID, target.ID} {
+		member, err := s.membershipRepository.FindByUserAndOrg(ctx, actorID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	wish, err := s.wishRepository.FindByID(ctx, source.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if wish == nil || wish.DeletedAt != nil {
+		return nil, domain.ErrWishNotFound
+	}
+	tagsByWish, err := s.wishRepository.FindTagsByWishIDs(ctx, []uuid.UUID{wish.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := targetList(ctx, s.listRepository, target.ID, transfer.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := &domain.Wish{
+		OrganizationID: target.ID,
+		ListID:         list.ID,
+		Title:          wish.Title,
+		Note:           wish.Note,
+		Status:         domain.WishStatusIdea,
+		Price:          wish.Price,
+		TargetDate:     wish.TargetDate,
+		Place:          wish.Place,
+		Origin: &domain.WishOrigin{
+			WishID:         &wish.ID,
+			OrganizationID: &source.ID,
+			Kind:           transfer.Kind,
+		},
+	}
+
+	// 移動先に作るタグもWishと同じトランザクションで作り、失敗した場合に使われないタグを残さない
+	var created *domain.Wish
+	err = s.tagRepository.Transaction(ctx, func(tags domain.TagRepository, repo domain.WishRepository) error {
+		tagIDs, err := matchTags(ctx, tags, target.ID, tagsByWish[wish.ID])
+		if err != nil {
+			return err
+		}
+		// 移動先のリストの先頭に置く
+		if copied.Rank, err = topRank(ctx, repo, target.ID, list.ID); err != nil {
+			return err
+		}
+		if created, err = repo.Create(ctx, copied); err != nil {
+			return err
+		}
+		if err := repo.ReplaceTags(ctx, created.ID, tagIDs); err != nil {
+			return err
+		}
+		created.Tags = tagsOf(tagIDs)
+		if err := recordRevision(ctx, repo, created, nil, actorID, nil); err != nil {
+			return err
+		}
+		if transfer.Kind == domain.TransferMove {
+			_, err := changeWithRevision(ctx, repo, source.ID, wish.ID, actorID, softDeleteChange(actorID, wish.Version))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// matchTags - 元のタグと同じ名前（大文字・小文字を区別しない）の組織のタグを返す。無いタグは同じ色で作る
+func matchTags(ctx context.Context, tagRepository domain.TagRepository, organizationID uuid.UUID, sourceTags []*domain.Tag) ([]uuid.UUID, error) {
+	if len(sourceTags) == 0 {
+		return nil, nil
+	}
+	tags, err := tagRepository.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]uuid.UUID, len(tags))
+	for _, tag := range tags {
+		byName[strings.ToLower(tag.Name)] = tag.ID
+	}
+
+	tagIDs := make([]uuid.UUID, 0, len(sourceTags))
+	for _, sourceTag := range sourceTags {
+		name := strings.ToLower(sourceTag.Name)
+		if tagID, ok := byName[name]; ok {
+			tagIDs = append(tagIDs, tagID)
+			continue
+		}
+		tag, err := tagRepository.Create(ctx, &domain.Tag{
+			OrganizationID: organizationID,
+			Name:           sourceTag.Name,
+			Color:          sourceTag.Color,
+		})
+		if errors.Is(err, domain.ErrTagNameConflict) {
+			// 同時に同じ名前のタグが作られた場合はそれを使う
+			return matchTags(ctx, tagRepository, organizationID, sourceTags)
+		}
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = tag.ID
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return tagIDs, nil
+}
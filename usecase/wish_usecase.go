@@ -2,190 +2,1272 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"math"
+	"sort"
+	"strings"
 	"taine-api/domain"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
+// WishSvc - Wishの操作。全ての操作は呼び出し元の組織（Clerkのorg external_id）にスコープされ、
+// 他組織のWishは domain.ErrWishNotFound として扱う。
 type WishSvc interface {
-	CreateWish(ctx context.Context, organizationID uuid.UUID, title, note string, orderNo int) (*domain.Wish, error)
-	CreateWishByOrganizationExternalID(ctx context.Context, externalID, title, note string, orderNo int) (*domain.Wish, error)
-	GetWish(ctx context.Context, id uuid.UUID) (*domain.Wish, error)
-	GetWishesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*domain.Wish, error)
-	GetWishesByOrganizationExternalID(ctx context.Context, externalID string) ([]*domain.Wish, error)
-	UpdateWish(ctx context.Context, id uuid.UUID, title, note string, orderNo int) (*domain.Wish, error)
-	DeleteWish(ctx context.Context, id uuid.UUID) error
-	SoftDeleteWish(ctx context.Context, id uuid.UUID) error
-	RestoreWish(ctx context.Context, id uuid.UUID) error
-	UpdateWishOrder(ctx context.Context, id uuid.UUID, orderNo int) error
+	CreateWishByOrganizationExternalID(ctx context.Context, externalID string, actorID uuid.UUID, input WishInput) (*domain.Wish, error)
+	// GetWish - Wishを取得する。viewerID の投票を MyVote に設定する
+	GetWish(ctx context.Context, orgExternalID string, id, viewerID uuid.UUID) (*domain.Wish, error)
+	ListWishes(ctx context.Context, orgExternalID string, query domain.WishListQuery) (*domain.WishPage, error)
+	SearchWishes(ctx context.Context, orgExternalID, q string, limit int) ([]*domain.WishSearchResult, error)
+	// NearbyWishes - 場所の座標が center から radiusMeters 以内のWish（ゴミ箱を除く全てのリスト）を近い順に取得
+	NearbyWishes(ctx context.Context, orgExternalID string, center domain.GeoPoint, radiusMeters float64, limit int) ([]*domain.NearbyWish, error)
+	// UpdateWish / UpdateWishOrder / DeleteWish / SoftDeleteWish - expectedVersion > 0 の場合、
+	// Wishのバージョンが一致するときだけ書き込み、一致しなければ domain.ErrWishVersionMismatch を返す
+	UpdateWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion int, input WishInput) (*domain.Wish, error)
+	// PatchWish - patch.Mask に含まれる項目だけを変更し、変更後の状態を検証する
+	PatchWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion int, patch WishPatch) (*domain.Wish, error)
+	// BulkWishes - 複数の作成・更新・削除をまとめて実行する。atomic の場合は全て成功した時だけ反映する
+	BulkWishes(ctx context.Context, orgExternalID string, actorID uuid.UUID, operations []BulkWishOperation, atomic bool) ([]BulkWishResult, error)
+	DeleteWish(ctx context.Context, orgExternalID string, id uuid.UUID, expectedVersion int) error
+	SoftDeleteWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion int) error
+	RestoreWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID) error
+	ListTrash(ctx context.Context, orgExternalID string) ([]*domain.TrashedWish, error)
+	// UpdateWishOrder - 旧APIの order_no（大きいほど先頭）を変更し、その並びになる位置へWishを移動する
+	UpdateWishOrder(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion, orderNo int) (*domain.Wish, error)
+	ReorderWishes(ctx context.Context, orgExternalID string, actorID uuid.UUID, reorder domain.WishReorder) ([]*domain.Wish, error)
+	// TransferWish - 呼び出し元が所属する別の組織へWishをコピー・移動し、作られたWishを返す
+	TransferWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, transfer domain.WishTransfer) (*domain.Wish, error)
+	TransitionWishStatus(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, to domain.WishStatus) (*domain.Wish, error)
+	// VoteWish - 投票する。domain.VoteNone の場合は投票を取り消す
+	VoteWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, value domain.VoteValue) (*domain.Wish, error)
+	// ListConsensus - 組織の全メンバーが賛成しているWishをスコア順に取得
+	ListConsensus(ctx context.Context, orgExternalID string, viewerID uuid.UUID, limit int) ([]*domain.Wish, error)
+	// ListRevisions - 変更履歴を新しい順に取得。before（0は最新から）より前の番号を limit 件返す
+	ListRevisions(ctx context.Context, orgExternalID string, id uuid.UUID, before, limit int) (*domain.WishRevisionPage, error)
+	// DiffRevisions - 2つの履歴の間の項目ごとの差分
+	DiffRevisions(ctx context.Context, orgExternalID string, id uuid.UUID, from, to int) ([]domain.FieldChange, error)
+	// RevertWish - 指定した履歴の状態に戻す。戻した操作も新しい履歴として記録する
+	RevertWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, number int) (*domain.Wish, error)
+	// ListChecklist - Wishのチェックリスト項目を並び順に取得
+	ListChecklist(ctx context.Context, orgExternalID string, wishID uuid.UUID) ([]*domain.ChecklistItem, error)
+	// AddChecklistItem - チェックリストの末尾に項目を追加
+	AddChecklistItem(ctx context.Context, orgExternalID string, wishID uuid.UUID, title string, assigneeID *uuid.UUID) (*domain.ChecklistItem, error)
+	// UpdateChecklistItem - タイトル・担当・チェック状態を変更。全てチェックされたらWishを達成にすることもできる
+	UpdateChecklistItem(ctx context.Context, orgExternalID string, wishID, itemID, actorID uuid.UUID, input ChecklistItemInput) (*domain.ChecklistItem, *domain.Wish, error)
+	// MoveChecklistItem - 項目を別の項目の前後、または先頭へ移動
+	MoveChecklistItem(ctx context.Context, orgExternalID string, wishID, itemID uuid.UUID, afterID, beforeID *uuid.UUID) (*domain.ChecklistItem, error)
+	DeleteChecklistItem(ctx context.Context, orgExternalID string, wishID, itemID uuid.UUID) error
 }
 
+// WishInput - Wishの作成・更新で受け付ける項目
+type WishInput struct {
+	Title   string
+	Note    string
+	OrderNo int
+	// TagIDs - nilの場合、更新時はタグを変更しない
+	TagIDs []uuid.UUID
+	// Price - nilの場合、更新時は価格を変更しない。ClearPrice で価格を外す
+	Price      *domain.Money
+	ClearPrice bool
+	// TargetDate - 期日（domain.DateOf の形式）。nilの場合、更新時は変更しない。ClearTargetDate で外す
+	TargetDate      *time.Time
+	ClearTargetDate bool
+	// AssigneeID - 担当メンバー。nilの場合、更新時は変更しない。ClearAssignee で外す
+	AssigneeID    *uuid.UUID
+	ClearAssignee bool
+	// ListID - 作成時のリスト。nilの場合は既定のリスト。更新では使わない（リスト間の移動は ReorderWishes）
+	ListID *uuid.UUID
+	// Place - 場所。nilの場合、更新時は変更しない。ClearPlace で外す
+	Place      *domain.Place
+	ClearPlace bool
+}
+
+// WishField - 部分更新（PatchWish）で指定できる項目
+type WishField string
+
+const (
+	WishFieldTitle         WishField = "title"
+	WishFieldNote          WishField = "note"
+	WishFieldOrderNo       WishField = "order_no"
+	WishFieldTagIDs        WishField = "tag_ids"
+	WishFieldPriceAmount   WishField = "price.amount"
+	WishFieldPriceCurrency WishField = "price.currency"
+	WishFieldTargetDate    WishField = "target_date"
+	WishFieldAssigneeID    WishField = "assignee_id"
+	WishFieldPlaceName     WishField = "place.name"
+	WishFieldPlaceAddress  WishField = "place.address"
+	WishFieldPlaceLat      WishField = "place.lat"
+	WishFieldPlaceLng      WishField = "place.lng"
+)
+
+// WishPatch - Mask に含まれる項目だけを値で上書きする。ポインタ・スライスの項目はnilで外す
+// 価格は金額と通貨を別々に指定でき、上書き後に両方そろっているか両方無いかを検証する
+// 場所も項目ごとに上書きし、全て空になった場合は場所を外す
+type WishPatch struct {
+	Mask          map[WishField]bool
+	Title         string
+	Note          string
+	OrderNo       int
+	TagIDs        []uuid.UUID
+	PriceAmount   *int64
+	PriceCurrency *string
+	TargetDate    *time.Time
+	AssigneeID    *uuid.UUID
+	PlaceName     string
+	PlaceAddress  string
+	PlaceLat      *float64
+	PlaceLng      *float64
+}
+
+// Has - 項目が更新対象かどうか
+func (p WishPatch) Has(field WishField) bool {
+	return p.Mask[field]
+}
+
+// validatePrice - 価格は0以上で、通貨コードが正しいこと
+func validatePrice(price *domain.Money) error {
+	if price == nil {
+		return nil
+	}
+	if err := price.Validate(); err != nil {
+		return err
+	}
+	if price.Amount < 0 {
+		return domain.ErrInvalidMoney
+	}
+	return nil
+}
+
+const (
+	DefaultWishPageSize = 50
+	MaxWishPageSize     = 200
+
+	DefaultWishSearchLimit = 20
+	MaxWishSearchLimit     = 100
+	MaxWishSearchQueryLen  = 200
+	wishSnippetRadius      = 40
+
+	DefaultConsensusLimit = 20
+	MaxConsensusLimit     = 100
+
+	DefaultNearbyRadiusMeters = 5000
+	MaxNearbyRadiusMeters     = 1000000
+	DefaultNearbyLimit        = 50
+	MaxNearbyLimit            = 200
+
+	DefaultRevisionPageSize = 20
+	MaxRevisionPageSize     = 100
+)
+
 type wishSvc struct {
-	wishRepository domain.WishRepository
-	orgRepository  domain.OrganizationRepository
+	wishRepository       domain.WishRepository
+	orgRepository        domain.OrganizationRepository
+	tagRepository        domain.TagRepository
+	voteRepository       domain.VoteRepository
+	membershipRepository domain.MembershipRepository
+	userRepository       domain.UserRepository
+	listRepository       domain.ListRepository
+	attachmentRepository domain.AttachmentRepository
+	blobStore            domain.BlobStore
+	// pendingBlobs - 一括操作のトランザクション中は、完全削除したWishの添付のBlobをコミットまで消さずにここへ溜める
+	pendingBlobs *[]string
 }
 
 func NewWishSvc(
 	wishRepository domain.WishRepository,
 	orgRepository domain.OrganizationRepository,
+	tagRepository domain.TagRepository,
+	voteRepository domain.VoteRepository,
+	membershipRepository domain.MembershipRepository,
+	userRepository domain.UserRepository,
+	listRepository domain.ListRepository,
+	attachmentRepository domain.AttachmentRepository,
+	blobStore domain.BlobStore,
 ) WishSvc {
 	return &wishSvc{
-		wishRepository: wishRepository,
-		orgRepository:  orgRepository,
+		wishRepository:       wishRepository,
+		orgRepository:        orgRepository,
+		tagRepository:        tagRepository,
+		voteRepository:       voteRepository,
+		membershipRepository: membershipRepository,
+		userRepository:       userRepository,
+		listRepository:       listRepository,
+		attachmentRepository: attachmentRepository,
+		blobStore:            blobStore,
 	}
 }
 
-func (s *wishSvc) CreateWish(ctx context.Context, organizationID uuid.UUID, title, note string, orderNo int) (*domain.Wish, error) {
-	if title == "" {
-		return nil, errors.New("title is required")
+// findWish - 呼び出し元の組織に属するWishを取得
+func (s *wishSvc) findWish(ctx context.Context, orgExternalID string, id uuid.UUID) (*domain.Wish, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
 	}
-
-	// 組織の存在確認
-	org, err := s.orgRepository.FindByExternalID(ctx, organizationID.String())
+	wish, err := s.wishRepository.FindByID(ctx, org.ID, id)
 	if err != nil {
 		return nil, err
 	}
-	if org == nil {
-		return nil, errors.New("organization not found")
+	if wish == nil {
+		return nil, domain.ErrWishNotFound
 	}
+	return wish, nil
+}
 
-	wish := &domain.Wish{
-		OrganizationID: organizationID,
-		Title:          title,
-		Note:           note,
-		OrderNo:        orderNo,
+// attachDetails - 複数のWishのタグ・コメント数・リンク・チェックリストの進捗をまとめて読み込んで設定する
+func (s *wishSvc) attachDetails(ctx context.Context, wishes ...*domain.Wish) error {
+	if len(wishes) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(wishes))
+	for i, wish := range wishes {
+		ids[i] = wish.ID
+	}
+	tagsByWish, err := s.wishRepository.FindTagsByWishIDs(ctx, ids)
+	if err != nil {
+		return err
 	}
 
-	return s.wishRepository.Create(ctx, wish)
+	commentCounts, err := s.wishRepository.CountCommentsByWishIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	linksByWish, err := s.wishRepository.FindLinksByWishIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	checklists, err := s.wishRepository.CountChecklistByWishIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, wish := range wishes {
+		wish.Tags = tagsByWish[wish.ID]
+		if wish.Tags == nil {
+			wish.Tags = []*domain.Tag{}
+		}
+		wish.CommentCount = commentCounts[wish.ID]
+		wish.Links = linksByWish[wish.ID]
+		if wish.Links == nil {
+			wish.Links = []*domain.WishLink{}
+		}
+		wish.Checklist = checklists[wish.ID]
+	}
+	return nil
 }
 
-func (s *wishSvc) CreateWishByOrganizationExternalID(ctx context.Context, externalID, title, note string, orderNo int) (*domain.Wish, error) {
-	if title == "" {
-		return nil, errors.New("title is required")
+// attachMyVotes - 閲覧者自身の投票をまとめて読み込んで設定する
+func (s *wishSvc) attachMyVotes(ctx context.Context, viewerID uuid.UUID, wishes ...*domain.Wish) error {
+	if len(wishes) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(wishes))
+	for i, wish := range wishes {
+		ids[i] = wish.ID
+	}
+	votes, err := s.voteRepository.FindByUser(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, wish := range wishes {
+		wish.MyVote = votes[wish.ID]
+	}
+	return nil
+}
+
+// validateAssignee - 担当者が組織のメンバーであることを確認する
+func (s *wishSvc) validateAssignee(ctx context.Context, organizationID uuid.UUID, assigneeID *uuid.UUID) error {
+	if assigneeID == nil {
+		return nil
+	}
+	member, err := s.membershipRepository.FindByUserAndOrg(ctx, *assigneeID, organizationID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return domain.ErrInvalidAssignee
+	}
+	return nil
+}
+
+// resolveTagIDs - 重複を除き、全てのタグが組織に属することを確認する
+func (s *wishSvc) resolveTagIDs(ctx context.Context, organizationID uuid.UUID, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool, len(tagIDs))
+	unique := make([]uuid.UUID, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	tags, err := s.tagRepository.FindByIDs(ctx, organizationID, unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != len(unique) {
+		return nil, domain.ErrTagNotFound
+	}
+	return unique, nil
+}
+
+func (s *wishSvc) CreateWishByOrganizationExternalID(ctx context.Context, externalID string, actorID uuid.UUID, input WishInput) (*domain.Wish, error) {
+	if input.Title == "" {
+		return nil, domain.ErrWishTitleRequired
+	}
+
+	if err := validatePrice(input.Price); err != nil {
+		return nil, err
+	}
+	place := input.Place.Normalize()
+	if err := place.Validate(); err != nil {
+		return nil, err
 	}
 
 	// external_idから組織を取得
-	org, err := s.orgRepository.FindByExternalID(ctx, externalID)
+	org, err := findOrganization(ctx, s.orgRepository, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := targetList(ctx, s.listRepository, org.ID, input.ListID)
 	if err != nil {
 		return nil, err
 	}
 
+	var tagIDs []uuid.UUID
+	if input.TagIDs != nil {
+		if tagIDs, err = s.resolveTagIDs(ctx, org.ID, input.TagIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateAssignee(ctx, org.ID, input.AssigneeID); err != nil {
+		return nil, err
+	}
+
 	wish := &domain.Wish{
 		OrganizationID: org.ID,
-		Title:          title,
-		Note:           note,
-		OrderNo:        orderNo,
+		ListID:         list.ID,
+		Title:          input.Title,
+		Note:           input.Note,
+		OrderNo:        input.OrderNo,
+		Status:         domain.WishStatusIdea,
+		Price:          input.Price,
+		TargetDate:     input.TargetDate,
+		AssigneeID:     input.AssigneeID,
+		Place:          place,
+	}
+
+	var created *domain.Wish
+	err = s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		// 新しいWishはリストの先頭に置く
+		var err error
+		if wish.Rank, err = topRank(ctx, repo, org.ID, list.ID); err != nil {
+			return err
+		}
+		if created, err = repo.Create(ctx, wish); err != nil {
+			return err
+		}
+		if err := repo.ReplaceTags(ctx, created.ID, tagIDs); err != nil {
+			return err
+		}
+		created.Tags = tagsOf(tagIDs)
+		return recordRevision(ctx, repo, created, nil, actorID, nil)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.wishRepository.Create(ctx, wish)
+	if err := s.attachDetails(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
-func (s *wishSvc) GetWish(ctx context.Context, id uuid.UUID) (*domain.Wish, error) {
-	wish, err := s.wishRepository.FindByID(ctx, id)
+func (s *wishSvc) GetWish(ctx context.Context, orgExternalID string, id, viewerID uuid.UUID) (*domain.Wish, error) {
+	wish, err := s.findWish(ctx, orgExternalID, id)
 	if err != nil {
 		return nil, err
 	}
-	if wish == nil {
-		return nil, errors.New("wish not found")
+	if err := s.attachDetails(ctx, wish); err != nil {
+		return nil, err
+	}
+	if err := s.attachMyVotes(ctx, viewerID, wish); err != nil {
+		return nil, err
 	}
 	return wish, nil
 }
 
-func (s *wishSvc) GetWishesByOrganization(ctx context.Context, organizationID uuid.UUID) ([]*domain.Wish, error) {
-	return s.wishRepository.FindByOrganizationID(ctx, organizationID)
+func (s *wishSvc) ListWishes(ctx context.Context, orgExternalID string, query domain.WishListQuery) (*domain.WishPage, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 既定値の補完
+	if query.Limit <= 0 {
+		query.Limit = DefaultWishPageSize
+	}
+	if query.Limit > MaxWishPageSize {
+		query.Limit = MaxWishPageSize
+	}
+	if query.View != "" {
+		if err := resolveWishView(&query, org.Today(time.Now())); err != nil {
+			return nil, err
+		}
+	}
+	if query.Sort == "" {
+		query.Sort = domain.WishSortPriority
+	}
+	if !query.Sort.Valid() {
+		return nil, domain.ErrInvalidWishQuery
+	}
+	if query.Order == "" {
+		query.Order = query.Sort.DefaultOrder()
+	}
+
+	// リストの指定が無ければ既定のリストのWishを返す
+	if query.AllLists {
+		query.ListID = nil
+	} else if query.ListID == nil {
+		list, err := s.listRepository.EnsureDefault(ctx, org.ID)
+		if err != nil {
+			return nil, err
+		}
+		query.ListID = &list.ID
+	} else if _, err := findList(ctx, s.listRepository, org.ID, *query.ListID); err != nil {
+		return nil, err
+	}
+
+	// カーソルは発行時と同じ並び順でのみ有効
+	if query.After != nil && (query.After.Sort != query.Sort || query.After.Order != query.Order) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	// 次ページの有無を判定するため1件多く取得
+	limit := query.Limit
+	query.Limit = limit + 1
+	wishes, err := s.wishRepository.List(ctx, org.ID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.WishPage{Wishes: wishes}
+	if len(wishes) > limit {
+		page.Wishes = wishes[:limit]
+		page.NextCursor = domain.NewWishCursor(query.Sort, query.Order, page.Wishes[limit-1]).Encode()
+	}
+	if err := s.attachDetails(ctx, page.Wishes...); err != nil {
+		return nil, err
+	}
+	if query.ViewerID != nil {
+		if err := s.attachMyVotes(ctx, *query.ViewerID, page.Wishes...); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
 }
 
-func (s *wishSvc) GetWishesByOrganizationExternalID(ctx context.Context, externalID string) ([]*domain.Wish, error) {
-	// external_idから組織を取得
-	org, err := s.orgRepository.FindByExternalID(ctx, externalID)
+// resolveWishView - upcoming/overdue を組織の今日の日付を基準にした期日の範囲に変換する
+// statusの指定が無ければ完了・アーカイブ済みは除き、並び順の指定が無ければ期日順にする
+func resolveWishView(query *domain.WishListQuery, today time.Time) error {
+	switch query.View {
+	case domain.WishViewUpcoming:
+		query.TargetDateFrom = &today
+	case domain.WishViewOverdue:
+		query.TargetDateBefore = &today
+	default:
+		return domain.ErrInvalidWishQuery
+	}
+	if len(query.Statuses) == 0 {
+		query.Statuses = domain.OpenWishStatuses
+	}
+	if query.Sort == "" {
+		query.Sort = domain.WishSortTargetDate
+	}
+	return nil
+}
+
+func (s *wishSvc) SearchWishes(ctx context.Context, orgExternalID, q string, limit int) ([]*domain.WishSearchResult, error) {
+	q = strings.TrimSpace(q)
+	if q == "" || utf8.RuneCountInString(q) > MaxWishSearchQueryLen {
+		return nil, domain.ErrInvalidWishQuery
+	}
+	if limit <= 0 {
+		limit = DefaultWishSearchLimit
+	}
+	if limit > MaxWishSearchLimit {
+		limit = MaxWishSearchLimit
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
 	if err != nil {
 		return nil, err
 	}
-	if org == nil {
-		return nil, errors.New("organization not found")
+
+	results, err := s.wishRepository.Search(ctx, org.ID, q, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	// 組織のIDでWishを取得
-	return s.wishRepository.FindByOrganizationID(ctx, org.ID)
+	// ハイライト付きの抜粋を作成
+	wishes := make([]*domain.Wish, len(results))
+	for i, result := range results {
+		result.TitleSnippet = domain.HighlightSnippet(result.Wish.Title, q, wishSnippetRadius)
+		result.NoteSnippet = domain.HighlightSnippet(result.Wish.Note, q, wishSnippetRadius)
+		wishes[i] = result.Wish
+	}
+	if err := s.attachDetails(ctx, wishes...); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
-func (s *wishSvc) UpdateWish(ctx context.Context, id uuid.UUID, title, note string, orderNo int) (*domain.Wish, error) {
-	if title == "" {
-		return nil, errors.New("title is required")
+func (s *wishSvc) NearbyWishes(ctx context.Context, orgExternalID string, center domain.GeoPoint, radiusMeters float64, limit int) ([]*domain.NearbyWish, error) {
+	if !center.Valid() || math.IsNaN(radiusMeters) || radiusMeters < 0 || radiusMeters > MaxNearbyRadiusMeters {
+		return nil, domain.ErrInvalidWishQuery
+	}
+	if radiusMeters == 0 {
+		radiusMeters = DefaultNearbyRadiusMeters
+	}
+	if limit <= 0 {
+		limit = DefaultNearbyLimit
+	}
+	if limit > MaxNearbyLimit {
+		limit = MaxNearbyLimit
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.wishRepository.FindNearby(ctx, org.ID, domain.NearbyQuery{
+		Center:       center,
+		RadiusMeters: radiusMeters,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, len(results))
+	for i, result := range results {
+		wishes[i] = result.Wish
+	}
+	if err := s.attachDetails(ctx, wishes...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *wishSvc) UpdateWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion int, input WishInput) (*domain.Wish, error) {
+	if input.Title == "" {
+		return nil, domain.ErrWishTitleRequired
+	}
+
+	if err := validatePrice(input.Price); err != nil {
+		return nil, err
+	}
+	place := input.Place.Normalize()
+	if err := place.Validate(); err != nil {
+		return nil, err
 	}
 
 	// 既存のWishを取得
-	wish, err := s.wishRepository.FindByID(ctx, id)
+	wish, err := s.findWish(ctx, orgExternalID, id)
 	if err != nil {
 		return nil, err
 	}
-	if wish == nil {
-		return nil, errors.New("wish not found")
+
+	var tagIDs []uuid.UUID
+	if input.TagIDs != nil {
+		if tagIDs, err = s.resolveTagIDs(ctx, wish.OrganizationID, input.TagIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateAssignee(ctx, wish.OrganizationID, input.AssigneeID); err != nil {
+		return nil, err
 	}
 
 	// 更新
-	wish.Title = title
-	wish.Note = note
-	wish.OrderNo = orderNo
+	updated, err := s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, wishChange{
+		expectedVersion: expectedVersion,
+		tagIDs:          tagIDs,
+		reorder:         input.OrderNo != wish.OrderNo,
+		apply: func(wish *domain.Wish) error {
+			wish.Title = input.Title
+			wish.Note = input.Note
+			wish.OrderNo = input.OrderNo
+			switch {
+			case input.ClearPrice:
+				wish.Price = nil
+			case input.Price != nil:
+				wish.Price = input.Price
+			}
+			switch {
+			case input.ClearTargetDate:
+				wish.TargetDate = nil
+			case input.TargetDate != nil:
+				wish.TargetDate = input.TargetDate
+			}
+			switch {
+			case input.ClearAssignee:
+				wish.AssigneeID = nil
+			case input.AssigneeID != nil:
+				wish.AssigneeID = input.AssigneeID
+			}
+			if input.ClearPlace || input.Place != nil {
+				wish.Place = place
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *wishSvc) PatchWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion int, patch WishPatch) (*domain.Wish, error) {
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 変更する項目が無い場合は書き込まず、バージョンだけ確認する
+	// 確認から返すまでに更新されないよう、行をロックして読み直したWishで比べる
+	if len(patch.Mask) == 0 {
+		if expectedVersion > 0 {
+			err := s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+				locked, err := repo.FindByIDForUpdate(ctx, wish.OrganizationID, id)
+				if err != nil {
+					return err
+				}
+				if locked == nil {
+					return domain.ErrWishNotFound
+				}
+				if locked.Version != expectedVersion {
+					return domain.ErrWishVersionMismatch
+				}
+				wish = locked
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := s.attachDetails(ctx, wish); err != nil {
+			return nil, err
+		}
+		return wish, nil
+	}
+
+	// 現在の値に依存しない検証はロックの外で行う
+	var tagIDs []uuid.UUID
+	if patch.Has(WishFieldTagIDs) {
+		if tagIDs, err = s.resolveTagIDs(ctx, wish.OrganizationID, patch.TagIDs); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Has(WishFieldAssigneeID) {
+		if err := s.validateAssignee(ctx, wish.OrganizationID, patch.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, wishChange{
+		expectedVersion: expectedVersion,
+		tagIDs:          tagIDs,
+		reorder:         patch.Has(WishFieldOrderNo) && patch.OrderNo != wish.OrderNo,
+		apply: func(wish *domain.Wish) error {
+			return applyWishPatch(wish, patch)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// applyWishPatch - 指定された項目を上書きし、上書き後のWishを検証する
+func applyWishPatch(wish *domain.Wish, patch WishPatch) error {
+	if patch.Has(WishFieldTitle) {
+		wish.Title = patch.Title
+	}
+	if patch.Has(WishFieldNote) {
+		wish.Note = patch.Note
+	}
+	if patch.Has(WishFieldOrderNo) {
+		wish.OrderNo = patch.OrderNo
+	}
+	if patch.Has(WishFieldTargetDate) {
+		wish.TargetDate = patch.TargetDate
+	}
+	if patch.Has(WishFieldAssigneeID) {
+		wish.AssigneeID = patch.AssigneeID
+	}
+
+	// 価格は金額・通貨を個別に上書きしてから組み立て直す
+	var amount *int64
+	var currency *string
+	if wish.Price != nil {
+		amount, currency = &wish.Price.Amount, &wish.Price.Currency
+	}
+	if patch.Has(WishFieldPriceAmount) {
+		amount = patch.PriceAmount
+	}
+	if patch.Has(WishFieldPriceCurrency) {
+		currency = patch.PriceCurrency
+	}
+	switch {
+	case amount == nil && currency == nil:
+		wish.Price = nil
+	case amount == nil || currency == nil:
+		return domain.ErrInvalidMoney
+	default:
+		wish.Price = &domain.Money{Amount: *amount, Currency: *currency}
+	}
+
+	// 場所も項目ごとに上書きしてから組み立て直す
+	var place domain.Place
+	if wish.Place != nil {
+		place = *wish.Place
+	}
+	if patch.Has(WishFieldPlaceName) {
+		place.Name = patch.PlaceName
+	}
+	if patch.Has(WishFieldPlaceAddress) {
+		place.Address = patch.PlaceAddress
+	}
+	if patch.Has(WishFieldPlaceLat) {
+		place.Lat = patch.PlaceLat
+	}
+	if patch.Has(WishFieldPlaceLng) {
+		place.Lng = patch.PlaceLng
+	}
+	wish.Place = place.Normalize()
 
-	return s.wishRepository.Update(ctx, wish)
+	if wish.Title == "" {
+		return domain.ErrWishTitleRequired
+	}
+	if err := wish.Place.Validate(); err != nil {
+		return err
+	}
+	return validatePrice(wish.Price)
 }
 
-func (s *wishSvc) DeleteWish(ctx context.Context, id uuid.UUID) error {
+// DeleteWish - Wishを完全削除する。添付の行はカスケード削除されるため、削除後に元画像とサムネイルのBlobも消す
+func (s *wishSvc) DeleteWish(ctx context.Context, orgExternalID string, id uuid.UUID, expectedVersion int) error {
 	// 存在確認
-	wish, err := s.wishRepository.FindByID(ctx, id)
+	wish, err := s.findWish(ctx, orgExternalID, id)
 	if err != nil {
 		return err
 	}
-	if wish == nil {
-		return errors.New("wish not found")
+	attachments, err := s.attachmentRepository.FindByWishID(ctx, wish.OrganizationID, id)
+	if err != nil {
+		return err
 	}
 
-	return s.wishRepository.Delete(ctx, id)
+	if err := s.wishRepository.Delete(ctx, wish.OrganizationID, id, expectedVersion); err != nil {
+		return err
+	}
+	keys := attachmentBlobKeys(attachments)
+	if s.pendingBlobs != nil {
+		*s.pendingBlobs = append(*s.pendingBlobs, keys...)
+		return nil
+	}
+	deleteBlobs(ctx, s.blobStore, keys...)
+	return nil
 }
 
-func (s *wishSvc) SoftDeleteWish(ctx context.Context, id uuid.UUID) error {
+func (s *wishSvc) SoftDeleteWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion int) error {
 	// 存在確認
-	wish, err := s.wishRepository.FindByID(ctx, id)
+	wish, err := s.findWish(ctx, orgExternalID, id)
 	if err != nil {
 		return err
 	}
-	if wish == nil {
-		return errors.New("wish not found")
+	if wish.DeletedAt != nil {
+		return domain.ErrWishAlreadyDeleted
+	}
+
+	_, err = s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, softDeleteChange(actorID, expectedVersion))
+	return err
+}
+
+func (s *wishSvc) RestoreWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID) error {
+	// 存在確認（FindByIDは削除済みも含めて検索する）
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return err
+	}
+	if wish.DeletedAt == nil {
+		return domain.ErrWishNotDeleted
+	}
+
+	_, err = s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, wishChange{
+		apply: func(wish *domain.Wish) error {
+			if wish.DeletedAt == nil {
+				return domain.ErrWishNotDeleted
+			}
+			wish.DeletedAt = nil
+			wish.DeletedBy = nil
+			return nil
+		},
+		save: reloadAfter(func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) error {
+			return repo.Restore(ctx, wish.OrganizationID, wish.ID)
+		}),
+	})
+	return err
+}
+
+// ListTrash - ゴミ箱（ソフトデリート済み）のWishを完全削除予定日時と一緒に取得
+func (s *wishSvc) ListTrash(ctx context.Context, orgExternalID string) ([]*domain.TrashedWish, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	wishes, err := s.wishRepository.FindTrash(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, wishes...); err != nil {
+		return nil, err
+	}
+
+	trashed := make([]*domain.TrashedWish, len(wishes))
+	for i, wish := range wishes {
+		trashed[i] = &domain.TrashedWish{
+			Wish:    wish,
+			PurgeAt: domain.TrashPurgeAt(*wish.DeletedAt, org.TrashRetentionDays),
+		}
+	}
+	return trashed, nil
+}
+
+// UpdateWishOrder - 旧API（PATCH /wish/:id/order）。order_no（大きいほど先頭）を書き換え、
+// rank導入前と同じ並びになる位置へWishを移動する（移動は changeWithRevision が行う）
+func (s *wishSvc) UpdateWishOrder(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, expectedVersion, orderNo int) (*domain.Wish, error) {
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return nil, err
+	}
+	if wish.DeletedAt != nil {
+		return nil, domain.ErrWishNotFound
+	}
+
+	updated, err := s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, wishChange{
+		expectedVersion: expectedVersion,
+		reorder:         true,
+		apply: func(wish *domain.Wish) error {
+			if wish.DeletedAt != nil {
+				return domain.ErrWishNotFound
+			}
+			wish.OrderNo = orderNo
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachDetails(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// TransitionWishStatus - Wishのstatusを遷移させ、履歴に記録する
+// fulfilledへの遷移で達成日時と達成者を記録し、fulfilled・archived以外へ遷移する場合は記録を消す
+func (s *wishSvc) TransitionWishStatus(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, to domain.WishStatus) (*domain.Wish, error) {
+	if !to.Valid() {
+		return nil, domain.ErrInvalidWishStatus
+	}
+
+	wish, err := s.findWish(ctx, orgExternalID, id)
+	if err != nil {
+		return nil, err
 	}
 	if wish.DeletedAt != nil {
-		return errors.New("wish is already deleted")
+		return nil, domain.ErrWishNotFound
+	}
+
+	var from domain.WishStatus
+	updated, err := s.saveWithRevision(ctx, wish.OrganizationID, id, actorID, wishChange{
+		apply: func(wish *domain.Wish) error {
+			if wish.DeletedAt != nil {
+				return domain.ErrWishNotFound
+			}
+			from = wish.Status
+			if !from.CanTransitionTo(to) {
+				return domain.ErrInvalidStatusTransition
+			}
+
+			wish.Status = to
+			switch {
+			case to == domain.WishStatusFulfilled:
+				now := time.Now()
+				wish.FulfilledAt = &now
+				wish.FulfilledBy = &actorID
+			case to != domain.WishStatusArchived:
+				// 未達成の状態に戻した場合はクリア（アーカイブ時は達成履歴を残し、fulfilled → archived → idea でも残さない）
+				wish.FulfilledAt = nil
+				wish.FulfilledBy = nil
+			}
+			return nil
+		},
+		save: func(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) (*domain.Wish, error) {
+			return repo.UpdateStatus(ctx, wish, from)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ReorderWishes - リスト内の並び順を1トランザクションで変更する
+// Order指定時はリストの全てのWishを列挙させ、その順に等間隔のrankを振り直す。
+// Moves指定時は各Wishを前後のWishの間のrankへ移動するだけで、隣接するWishは書き換えない。ListIDがあればそのリストへ移す。
+// 移動した各Wishのリスト・rankの変更は履歴に記録する（Order指定時、振り直しで rank が変わっただけのWishは記録しない）
+func (s *wishSvc) ReorderWishes(ctx context.Context, orgExternalID string, actorID uuid.UUID, reorder domain.WishReorder) ([]*domain.Wish, error) {
+	if (len(reorder.Order) == 0) == (len(reorder.Moves) == 0) {
+		return nil, domain.ErrInvalidReorder
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved []uuid.UUID
+	err = s.wishRepository.Transaction(ctx, func(repo domain.WishRepository) error {
+		// 同じ組織の並び替えを直列化し、同時に同じ隙間へ移動しても順序が壊れないようにする
+		if err := repo.LockRanks(ctx, org.ID); err != nil {
+			return err
+		}
+
+		if len(reorder.Order) > 0 {
+			return applyOrder(ctx, repo, org.ID, actorID, reorder.Order, &moved)
+		}
+		for _, move := range reorder.Moves {
+			if err := s.applyMove(ctx, repo, org.ID, actorID, move); err != nil {
+				return err
+			}
+			moved = append(moved, move.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	wishes := make([]*domain.Wish, 0, len(moved))
+	for _, id := range moved {
+		wish, err := s.wishRepository.FindByID(ctx, org.ID, id)
+		if err != nil {
+			return nil, err
+		}
+		if wish != nil {
+			wishes = append(wishes, wish)
+		}
+	}
+	if err := s.attachDetails(ctx, wishes...); err != nil {
+		return nil, err
+	}
+	return wishes, nil
+}
+
+func (s *wishSvc) VoteWish(ctx context.Context, orgExternalID string, id, actorID uuid.UUID, value domain.VoteValue) (*domain.Wish, error) {
+	if value != domain.VoteNone && !value.Valid() {
+		return nil, domain.ErrInvalidVote
+	}
+
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	// ゴミ箱のWishには投票できない
+	wish, err := findLiveWish(ctx, s.wishRepository, org.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := s.voteRepository.SetVote(ctx, wish.ID, actorID, value)
+	if err != nil {
+		return nil, err
+	}
+	wish.Score = score
+	wish.MyVote = value
+
+	if err := s.attachDetails(ctx, wish); err != nil {
+		return nil, err
+	}
+	return wish, nil
+}
+
+func (s *wishSvc) ListConsensus(ctx context.Context, orgExternalID string, viewerID uuid.UUID, limit int) ([]*domain.Wish, error) {
+	org, err := findOrganization(ctx, s.orgRepository, orgExternalID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.wishRepository.SoftDelete(ctx, id)
+	if limit <= 0 {
+		limit = DefaultConsensusLimit
+	}
+	if limit > MaxConsensusLimit {
+		limit = MaxConsensusLimit
+	}
+
+	wishes, err := s.wishRepository.ListConsensus(ctx, org.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, wishes...); err != nil {
+		return nil, err
+	}
+	if err := s.attachMyVotes(ctx, viewerID, wishes...); err != nil {
+		return nil, err
+	}
+	return wishes, nil
+}
+
+// findOrganization - external_idから組織を取得
+func findOrganization(ctx context.Context, repo domain.OrganizationRepository, externalID string) (*domain.Organization, error) {
+	if externalID == "" {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	org, err := repo.FindByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// findWishInOrg - 呼び出し元の組織に属する、削除されていないWishを取得
+func findWishInOrg(ctx context.Context, orgRepo domain.OrganizationRepository, wishRepo domain.WishRepository, orgExternalID string, wishID uuid.UUID) (*domain.Wish, error) {
+	org, err := findOrganization(ctx, orgRepo, orgExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return findLiveWish(ctx, wishRepo, org.ID, wishID)
 }
 
-func (s *wishSvc) RestoreWish(ctx context.Context, id uuid.UUID) error {
-	// 存在確認（削除済みも含めて検索する必要があるため、別途実装が必要かもしれません）
-	wish, err := s.wishRepository.FindByID(ctx, id)
+// findLiveWish - 組織内の削除されていないWishを取得
+func findLiveWish(ctx context.Context, repo domain.WishRepository, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	wish, err := repo.FindByID(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if wish == nil || wish.DeletedAt != nil {
+		return nil, domain.ErrWishNotFound
+	}
+	return wish, nil
+}
+
+// topRank - listID の先頭に置くrankを返す。同時に作成・移動したWishと同じrankにならないよう、
+// rankの変更を組織ごとに直列化してから読む。呼び出し元のトランザクションの中で使う
+func topRank(ctx context.Context, repo domain.WishRepository, organizationID, listID uuid.UUID) (string, error) {
+	if err := repo.LockRanks(ctx, organizationID); err != nil {
+		return "", err
+	}
+	first, err := repo.AdjacentRank(ctx, organizationID, listID, "", true, uuid.Nil)
+	if err != nil {
+		return "", err
+	}
+	return domain.RankBetween("", first)
+}
+
+// priorityRank - 旧APIの order_no に合わせて、リストの中で wish より order_no が小さい最初のWishの前に置くrankを返す
+// order_no が同じ場合は作成日時が新しい方を先にする（rank導入前の並び order_no DESC, created_at DESC と同じ）
+func priorityRank(ctx context.Context, repo domain.WishRepository, wish *domain.Wish) (string, error) {
+	if err := repo.LockRanks(ctx, wish.OrganizationID); err != nil {
+		return "", err
+	}
+	wishes, err := repo.FindByList(ctx, wish.OrganizationID, wish.ListID)
+	if err != nil {
+		return "", err
+	}
+
+	var lower, upper string
+	for _, other := range wishes {
+		if other.ID == wish.ID {
+			continue
+		}
+		if other.OrderNo < wish.OrderNo || (other.OrderNo == wish.OrderNo && other.CreatedAt.Before(wish.CreatedAt)) {
+			upper = other.Rank
+			break
+		}
+		lower = other.Rank
+	}
+	return domain.RankBetween(lower, upper)
+}
+
+// applyOrder - 先頭のWishのリストの全てのWishを order の順に並べ直す
+func applyOrder(ctx context.Context, repo domain.WishRepository, organizationID, actorID uuid.UUID, order []uuid.UUID, moved *[]uuid.UUID) error {
+	first, err := findLiveWish(ctx, repo, organizationID, order[0])
 	if err != nil {
 		return err
 	}
-	if wish == nil {
-		return errors.New("wish not found")
+	wishes, err := repo.FindByList(ctx, organizationID, first.ListID)
+	if err != nil {
+		return err
 	}
-	if wish.DeletedAt == nil {
-		return errors.New("wish is not deleted")
+
+	// 一部だけ並べ替えると残りのWishとの前後が決まらないため、リストの全てのWishをちょうど1回ずつ指定させる
+	// （rankはリストごとの並びのため、別のリストのWishも混ぜられない）
+	if len(order) != len(wishes) {
+		return domain.ErrInvalidReorder
+	}
+	known := make(map[uuid.UUID]bool, len(wishes))
+	for _, wish := range wishes {
+		known[wish.ID] = true
+	}
+	seen := make(map[uuid.UUID]bool, len(order))
+	for _, id := range order {
+		if !known[id] || seen[id] {
+			return domain.ErrInvalidReorder
+		}
+		seen[id] = true
+	}
+
+	// 全てのWishのrankを振り直すが、履歴に記録するのは実際に動かしたWishだけにする。
+	// 元の並びの前後関係を保ったまま残せる最大のWishの集まりを動かしていないものとみなす
+	oldIndex := make(map[uuid.UUID]int, len(wishes))
+	for i, wish := range wishes {
+		oldIndex[wish.ID] = i
+	}
+	positions := make([]int, len(order))
+	for i, id := range order {
+		positions[i] = oldIndex[id]
 	}
+	kept := longestIncreasing(positions)
 
-	return s.wishRepository.Restore(ctx, id)
+	ranks := domain.RankSequence(len(order))
+	for i, id := range order {
+		if kept[i] {
+			if err := repo.UpdateRank(ctx, organizationID, id, ranks[i], 0); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := changeWithRevision(ctx, repo, organizationID, id, actorID, rankChange(first.ListID, ranks[i], 0)); err != nil {
+			return err
+		}
+	}
+	*moved = append(*moved, order...)
+	return nil
 }
 
-func (s *wishSvc) UpdateWishOrder(ctx context.Context, id uuid.UUID, orderNo int) error {
-	// 存在確認
-	wish, err := s.wishRepository.FindByID(ctx, id)
+// longestIncreasing - values の最長増加部分列に含まれる添字を返す
+func longestIncreasing(values []int) []bool {
+	// tails[k] は長さk+1の増加部分列の末尾のうち値が最小のものの添字、prev は部分列を遡るための直前の添字
+	tails := make([]int, 0, len(values))
+	prev := make([]int, len(values))
+	for i, v := range values {
+		k := sort.Search(len(tails), func(j int) bool { return values[tails[j]] >= v })
+		if k > 0 {
+			prev[i] = tails[k-1]
+		} else {
+			prev[i] = -1
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	in := make([]bool, len(values))
+	if len(tails) == 0 {
+		return in
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		in[i] = true
+	}
+	return in
+}
+
+func (s *wishSvc) applyMove(ctx context.Context, repo domain.WishRepository, organizationID, actorID uuid.UUID, move domain.WishMove) error {
+	if move.AfterID != nil && move.BeforeID != nil {
+		return domain.ErrInvalidReorder
+	}
+	if (move.AfterID != nil && *move.AfterID == move.ID) || (move.BeforeID != nil && *move.BeforeID == move.ID) {
+		return domain.ErrInvalidReorder
+	}
+	wish, err := findLiveWish(ctx, repo, organizationID, move.ID)
 	if err != nil {
 		return err
 	}
-	if wish == nil {
-		return errors.New("wish not found")
+
+	// 移動先のリスト。別のリストへ移す場合、アーカイブ済みのリストには移せない
+	listID := wish.ListID
+	if move.ListID != nil && *move.ListID != wish.ListID {
+		list, err := targetList(ctx, s.listRepository, organizationID, move.ListID)
+		if err != nil {
+			return err
+		}
+		listID = list.ID
 	}
 
-	return s.wishRepository.UpdateOrder(ctx, id, orderNo)
+	var lower, upper string
+	switch {
+	case move.AfterID != nil:
+		anchor, err := findLiveWish(ctx, repo, organizationID, *move.AfterID)
+		if err != nil {
+			return err
+		}
+		if anchor.ListID != listID {
+			return domain.ErrInvalidReorder
+		}
+		lower = anchor.Rank
+		if upper, err = repo.AdjacentRank(ctx, organizationID, listID, anchor.Rank, true, move.ID); err != nil {
+			return err
+		}
+	case move.BeforeID != nil:
+		anchor, err := findLiveWish(ctx, repo, organizationID, *move.BeforeID)
+		if err != nil {
+			return err
+		}
+		if anchor.ListID != listID {
+			return domain.ErrInvalidReorder
+		}
+		upper = anchor.Rank
+		if lower, err = repo.AdjacentRank(ctx, organizationID, listID, anchor.Rank, false, move.ID); err != nil {
+			return err
+		}
+	default:
+		// 先頭へ移動
+		first, err := repo.AdjacentRank(ctx, organizationID, listID, "", true, move.ID)
+		if err != nil {
+			return err
+		}
+		upper = first
+	}
+
+	rank, err := domain.RankBetween(lower, upper)
+	if err != nil {
+		return err
+	}
+	_, err = changeWithRevision(ctx, repo, organizationID, move.ID, actorID, rankChange(listID, rank, 0))
+	return err
 }
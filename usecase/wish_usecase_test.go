@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"taine-api/domain"
+
+	"github.com/google/uuid"
+)
+
+// fakeOrganizationRepository - external_id か id で組織を引くだけの OrganizationRepository
+type fakeOrganizationRepository struct {
+	domain.OrganizationRepository
+	orgs map[string]*domain.Organization
+}
+
+func (r *fakeOrganizationRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Organization, error) {
+	return r.orgs[externalID], nil
+}
+
+func (r *fakeOrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	for _, org := range r.orgs {
+		if org.ID == id {
+			return org, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeWishRepository - postgres の実装と同じく、組織が違うWishは見つからないものとして扱う WishRepository
+// 書き込みは writes に記録する
+type fakeWishRepository struct {
+	domain.WishRepository
+	wishes    map[uuid.UUID]*domain.Wish
+	writes    []string
+	revisions []*domain.WishRevision
+}
+
+func (r *fakeWishRepository) find(organizationID, id uuid.UUID) *domain.Wish {
+	wish, ok := r.wishes[id]
+	if !ok || wish.OrganizationID != organizationID {
+		return nil
+	}
+	copied := *wish
+	return &copied
+}
+
+func (r *fakeWishRepository) FindByID(ctx context.Context, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	return r.find(organizationID, id), nil
+}
+
+func (r *fakeWishRepository) FindByIDForUpdate(ctx context.Context, organizationID, id uuid.UUID) (*domain.Wish, error) {
+	return r.find(organizationID, id), nil
+}
+
+func (r *fakeWishRepository) FindTagsByWishIDs(ctx context.Context, wishIDs []uuid.UUID) (map[uuid.UUID][]*domain.Tag, error) {
+	return map[uuid.UUID][]*domain.Tag{}, nil
+}
+
+func (r *fakeWishRepository) Transaction(ctx context.Context, fn func(repo domain.WishRepository) error) error {
+	return fn(r)
+}
+
+func (r *fakeWishRepository) LockRanks(ctx context.Context, organizationID uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeWishRepository) Update(ctx context.Context, wish *domain.Wish, expectedVersion int) (*domain.Wish, error) {
+	r.writes = append(r.writes, "Update")
+	return wish, nil
+}
+
+func (r *fakeWishRepository) Delete(ctx context.Context, organizationID, id uuid.UUID, expectedVersion int) error {
+	r.writes = append(r.writes, "Delete")
+	return nil
+}
+
+func (r *fakeWishRepository) SoftDelete(ctx context.Context, organizationID, id, deletedBy uuid.UUID, expectedVersion int) error {
+	r.writes = append(r.writes, "SoftDelete")
+	now := time.Now()
+	r.wishes[id].DeletedAt = &now
+	r.wishes[id].DeletedBy = &deletedBy
+	return nil
+}
+
+func (r *fakeWishRepository) Restore(ctx context.Context, organizationID, id uuid.UUID) error {
+	r.writes = append(r.writes, "Restore")
+	return nil
+}
+
+func (r *fakeWishRepository) UpdateRank(ctx context.Context, organizationID, id uuid.UUID, rank string, expectedVersion int) error {
+	r.writes = append(r.writes, "UpdateRank")
+	r.wishes[id].Rank = rank
+	r.wishes[id].Version++
+	return nil
+}
+
+func (r *fakeWishRepository) FindByList(ctx context.Context, organizationID, listID uuid.UUID) ([]*domain.Wish, error) {
+	var wishes []*domain.Wish
+	for id, wish := range r.wishes {
+		if wish.ListID == listID && wish.DeletedAt == nil {
+			wishes = append(wishes, r.find(organizationID, id))
+		}
+	}
+	slices.SortFunc(wishes, func(a, b *domain.Wish) int { return strings.Compare(a.Rank, b.Rank) })
+	return wishes, nil
+}
+
+func (r *fakeWishRepository) FindRevisions(ctx context.Context, wishID uuid.UUID, before, limit int) ([]*domain.WishRevision, error) {
+	return nil, nil
+}
+
+func (r *fakeWishRepository) CreateRevision(ctx context.Context, revision *domain.WishRevision) (*domain.WishRevision, error) {
+	r.writes = append(r.writes, "CreateRevision")
+	r.revisions = append(r.revisions, revision)
+	return revision, nil
+}
+
+type orgScopeFixture struct {
+	svc      WishSvc
+	wishes   *fakeWishRepository
+	live     *domain.Wish // 組織Bのゴミ箱に入っていないWish
+	trashed  *domain.Wish // 組織Bのゴミ箱のWish
+	callerID uuid.UUID
+}
+
+// newOrgScopeFixture - 組織Aのメンバーから見た、組織BのWishを用意する
+func newOrgScopeFixture() *orgScopeFixture {
+	orgA := &domain.Organization{ID: uuid.New(), ExternalID: "org_a"}
+	orgB := &domain.Organization{ID: uuid.New(), ExternalID: "org_b"}
+	deletedAt := time.Now().Add(-time.Hour)
+	live := &domain.Wish{ID: uuid.New(), OrganizationID: orgB.ID, ListID: uuid.New(), Title: "Onsen trip", Rank: "m", Version: 1}
+	trashed := &domain.Wish{ID: uuid.New(), OrganizationID: orgB.ID, ListID: live.ListID, Title: "Old idea", Rank: "t", Version: 1, DeletedAt: &deletedAt}
+
+	wishes := &fakeWishRepository{wishes: map[uuid.UUID]*domain.Wish{live.ID: live, trashed.ID: trashed}}
+	orgs := &fakeOrganizationRepository{orgs: map[string]*domain.Organization{"org_a": orgA, "org_b": orgB}}
+	return &orgScopeFixture{
+		svc:      NewWishSvc(wishes, orgs, nil, nil, nil, nil, nil, nil, nil),
+		wishes:   wishes,
+		live:     live,
+		trashed:  trashed,
+		callerID: uuid.New(),
+	}
+}
+
+func TestWishSvcHidesWishesOfOtherOrganizations(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name string
+		call func(f *orgScopeFixture) error
+	}{
+		{"GetWish", func(f *orgScopeFixture) error {
+			_, err := f.svc.GetWish(ctx, "org_a", f.live.ID, f.callerID)
+			return err
+		}},
+		{"UpdateWish", func(f *orgScopeFixture) error {
+			_, err := f.svc.UpdateWish(ctx, "org_a", f.live.ID, f.callerID, 0, WishInput{Title: "Renamed"})
+			return err
+		}},
+		{"DeleteWish", func(f *orgScopeFixture) error {
+			return f.svc.DeleteWish(ctx, "org_a", f.live.ID, 0)
+		}},
+		{"SoftDeleteWish", func(f *orgScopeFixture) error {
+			return f.svc.SoftDeleteWish(ctx, "org_a", f.live.ID, f.callerID, 0)
+		}},
+		{"RestoreWish", func(f *orgScopeFixture) error {
+			return f.svc.RestoreWish(ctx, "org_a", f.trashed.ID, f.callerID)
+		}},
+		{"UpdateWishOrder", func(f *orgScopeFixture) error {
+			_, err := f.svc.UpdateWishOrder(ctx, "org_a", f.live.ID, f.callerID, 0, 1)
+			return err
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOrgScopeFixture()
+			if err := tt.call(f); !errors.Is(err, domain.ErrWishNotFound) {
+				t.Fatalf("error = %v, want %v", err, domain.ErrWishNotFound)
+			}
+			if len(f.wishes.writes) != 0 {
+				t.Errorf("wrote %v to another organization's wish", f.wishes.writes)
+			}
+		})
+	}
+}
+
+func TestWishSvcSoftDeletesWishOfOwnOrganization(t *testing.T) {
+	// 同じ組織からは見つかること（上のテストが組織の違い以外の理由で失敗していないことの確認）
+	f := newOrgScopeFixture()
+
+	if err := f.svc.SoftDeleteWish(context.Background(), "org_b", f.live.ID, f.callerID, 0); err != nil {
+		t.Fatalf("SoftDeleteWish() error = %v", err)
+	}
+	if f.wishes.wishes[f.live.ID].DeletedAt == nil {
+		t.Error("wish was not soft deleted")
+	}
+}
+
+func TestLongestIncreasing(t *testing.T) {
+	for _, tt := range []struct {
+		values []int
+		want   []bool
+	}{
+		{nil, []bool{}},
+		{[]int{0, 1, 2}, []bool{true, true, true}},
+		// 末尾のWishを先頭へ移した
+		{[]int{3, 0, 1, 2}, []bool{false, true, true, true}},
+		// 先頭のWishを末尾へ移した
+		{[]int{1, 2, 3, 0}, []bool{true, true, true, false}},
+		// 隣同士を入れ替えた（どちらか一方だけを動かしたとみなす）
+		{[]int{0, 2, 1, 3}, []bool{true, false, true, true}},
+		{[]int{2, 1, 0}, []bool{false, false, true}},
+	} {
+		got := longestIncreasing(tt.values)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("longestIncreasing(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestApplyOrderRecordsRevisionsOnlyForMovedWishes(t *testing.T) {
+	orgID, listID := uuid.New(), uuid.New()
+	wishes := &fakeWishRepository{wishes: map[uuid.UUID]*domain.Wish{}}
+	var ids []uuid.UUID
+	for _, rank := range []string{"a", "b", "c", "d"} {
+		wish := &domain.Wish{ID: uuid.New(), OrganizationID: orgID, ListID: listID, Title: "Wish " + rank, Rank: rank, Version: 1}
+		wishes.wishes[wish.ID] = wish
+		ids = append(ids, wish.ID)
+	}
+
+	// d を先頭へドラッグした
+	order := []uuid.UUID{ids[3], ids[0], ids[1], ids[2]}
+	var moved []uuid.UUID
+	if err := applyOrder(context.Background(), wishes, orgID, uuid.New(), order, &moved); err != nil {
+		t.Fatalf("applyOrder() error = %v", err)
+	}
+
+	got, err := wishes.FindByList(context.Background(), orgID, listID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, wish := range got {
+		if wish.ID != order[i] {
+			t.Fatalf("position %d = %s, want %s", i, wish.Title, wishes.wishes[order[i]].Title)
+		}
+	}
+	if !slices.Equal(moved, order) {
+		t.Errorf("moved = %v, want every wish of the order", moved)
+	}
+
+	recorded := map[uuid.UUID]bool{}
+	for _, revision := range wishes.revisions {
+		recorded[revision.WishID] = true
+	}
+	if len(recorded) != 1 || !recorded[ids[3]] {
+		var titles []string
+		for id := range recorded {
+			titles = append(titles, wishes.wishes[id].Title)
+		}
+		t.Errorf("recorded revisions for %v, want only Wish d", titles)
+	}
+}